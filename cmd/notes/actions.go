@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// notificationActions applies the buttons clicked on desktop reminders.
+// It runs in whichever process holds notification duty, so the change
+// reaches open TUIs through storage like any other edit. Snooze postpones
+// the reminder by snooze.
+func notificationActions(ctx context.Context, s storage.Storage, dataDir string, snooze time.Duration) reminder.ActionHandler {
+	return func(id models.TaskID, action reminder.Action) (string, error) {
+		if action == reminder.ActionOpen {
+			return "", openTask(dataDir, id)
+		}
+
+		tasks, err := s.GetAllTasks(ctx)
+		if err != nil {
+			return "", err
+		}
+		index := models.IndexTasks(tasks)
+		task, ok := index[id]
+		if !ok {
+			return "", fmt.Errorf("task %s no longer exists", id)
+		}
+		task = task.Clone()
+
+		switch action {
+		case reminder.ActionComplete:
+			if task.IsBlocked(index) {
+				return "", errors.New("it is waiting on other tasks")
+			}
+			if err := task.Complete(); err != nil {
+				return "", err
+			}
+			if parent := task.ParentToComplete(index); parent != nil {
+				parent = parent.Clone()
+				if parent.Complete() == nil {
+					return "Completed", s.SaveTasksBatch(ctx, []*models.Task{task, parent})
+				}
+			}
+			return "Completed", s.SaveTask(ctx, task)
+		case reminder.ActionSnooze:
+			snoozed, err := reminder.Snooze(ctx, s, id, snooze)
+			if err != nil {
+				return "", err
+			}
+			return "Snoozed until " + snoozed.SnoozedUntil.Format("15:04"), nil
+		case reminder.ActionAcknowledge:
+			// Stops the reminder repeating
+			task.Acknowledge("desktop")
+			return "", s.SaveTask(ctx, task)
+		}
+		return "", fmt.Errorf("unknown action %q", action)
+	}
+}
+
+// openTask starts the TUI in a new terminal window with the task selected.
+func openTask(dataDir string, id models.TaskID) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the notes binary: %w", err)
+	}
+	args := []string{exe, "--data", dataDir, "--view", "tasks", "--task", string(id)}
+
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		quoted := make([]string, len(args))
+		for i, arg := range args {
+			quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+		script := fmt.Sprintf(`tell application "Terminal" to do script %q`, strings.Join(quoted, " "))
+		cmd = exec.Command("osascript", "-e", script, "-e", `tell application "Terminal" to activate`)
+	default:
+		terminal := os.Getenv("TERMINAL")
+		if terminal == "" {
+			terminal = "x-terminal-emulator"
+		}
+		cmd = exec.Command(terminal, append([]string{"-e"}, args...)...)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to open a terminal: %w", err)
+	}
+	go cmd.Wait()
+	return nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+const addUsage = "usage: notes add [--task] [--due 'YYYY-MM-DD [HH:MM]'] [--estimate 1h30m] [--cron 'expr'] [--tz zone] [--project name] [--context @where] [--source name] [--mail] <title> [body...]"
+
+// sourceRoute is a source's routing with its project looked up.
+type sourceRoute struct {
+	source    string
+	tags      []string
+	projectID models.ProjectID
+}
+
+// routeSource resolves the routing configured for source.
+func routeSource(env *cmdEnv, source string) (sourceRoute, error) {
+	sc := env.config.Capture.Route(source)
+	route := sourceRoute{source: source, tags: sc.Tags}
+	if sc.Project != "" {
+		project, err := findProject(env, sc.Project)
+		if err != nil {
+			return route, fmt.Errorf("capture.sources.%s: %w", source, err)
+		}
+		route.projectID = project.ID
+	}
+	return route, nil
+}
+
+// captureNote stamps a newly captured note with its source and applies the
+// source's routing. A project the note already has wins over the routed
+// one.
+func captureNote(route sourceRoute, note *models.Note) {
+	note.Source = route.source
+	for _, tag := range route.tags {
+		note.AddTag(tag)
+	}
+	if note.ProjectID == "" {
+		note.ProjectID = route.projectID
+	}
+}
+
+func captureTask(route sourceRoute, task *models.Task) {
+	task.Source = route.source
+	for _, tag := range route.tags {
+		task.AddTag(tag)
+	}
+	if task.ProjectID == "" {
+		task.ProjectID = route.projectID
+	}
+}
+
+// runAdd captures a single note or task. With --mail the title and body
+// come from an email message on stdin, so a mail filter can pipe messages
+// straight in.
+func runAdd(env *cmdEnv, args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	asTask := fs.Bool("task", false, "capture a task instead of a note")
+	due := fs.String("due", "", "due date for tasks (YYYY-MM-DD, optionally with HH:MM)")
+	estimate := fs.Duration("estimate", 0, "expected effort for tasks")
+	cron := fs.String("cron", "", "remind on a cron schedule instead, e.g. '0 9 * * MON'")
+	tz := fs.String("tz", "", "IANA timezone the due date and schedule are meant in, e.g. Europe/Berlin")
+	source := fs.String("source", "", "name of the capturing integration (default cli, or email with --mail)")
+	fromMail := fs.Bool("mail", false, "read an email message from stdin")
+	projectName := fs.String("project", "", "name of an existing project to add to")
+	context := fs.String("context", "", "where a task can be done, e.g. @home")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return errors.New(addUsage)
+	}
+
+	var title, body string
+	if *fromMail {
+		title, body, err = readMail(env.stdin)
+		if err != nil {
+			return err
+		}
+		if *source == "" {
+			*source = "email"
+		}
+	} else {
+		if len(positional) == 0 {
+			return errors.New(addUsage)
+		}
+		title, body = positional[0], strings.Join(positional[1:], " ")
+	}
+	if *source == "" {
+		*source = "cli"
+	}
+	if strings.TrimSpace(title) == "" {
+		return errors.New("title must not be empty")
+	}
+	var projectID models.ProjectID
+	if *projectName != "" {
+		project, err := findProject(env, *projectName)
+		if err != nil {
+			return err
+		}
+		projectID = project.ID
+	}
+	route, err := routeSource(env, *source)
+	if err != nil {
+		return err
+	}
+
+	if !*asTask {
+		note := models.NewNote(title, body)
+		note.ProjectID = projectID
+		captureNote(route, note)
+		if err := env.storage.SaveNote(env.ctx, note); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.stdout, "Captured note %s from %s\n", note.ID, *source)
+		return nil
+	}
+
+	loc := time.Local
+	if *tz != "" {
+		if loc, err = time.LoadLocation(*tz); err != nil {
+			return fmt.Errorf("unknown timezone %q: %w", *tz, err)
+		}
+	}
+	var dueDate time.Time
+	if *due != "" {
+		dueDate, err = time.ParseInLocation("2006-01-02 15:04", *due, loc)
+		if err != nil {
+			// A date alone falls due at the configured time of day
+			dueDate, err = time.ParseInLocation("2006-01-02", *due, loc)
+			if err != nil {
+				return fmt.Errorf("invalid due date %q: %w", *due, err)
+			}
+			dueTime, err := models.ParseTimeOfDay(env.config.Tasks.DueTime)
+			if err != nil {
+				return err
+			}
+			dueDate = dueTime.On(dueDate)
+		}
+	}
+	task := models.NewTask(title, body, dueDate)
+	task.Timezone = *tz
+	if dueDate.IsZero() {
+		task.ReminderAt = time.Time{}
+	}
+	if *cron != "" {
+		if _, err := models.ParseCron(*cron); err != nil {
+			return err
+		}
+		task.Cron = *cron
+	}
+	task.SetEstimate(*estimate)
+	task.ProjectID = projectID
+	captureTask(route, task)
+	task.Context = models.NormalizeContext(*context)
+	if err := env.storage.SaveTask(env.ctx, task); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "Captured task %s from %s\n", task.ID, *source)
+	return nil
+}
+
+// readMail takes the subject as the title and the text body as the body.
+func readMail(r io.Reader) (title, body string, err error) {
+	msg, err := mail.ReadMessage(r)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read email: %w", err)
+	}
+	data, err := io.ReadAll(msg.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read email: %w", err)
+	}
+
+	title = msg.Header.Get("Subject")
+	if decoded, err := new(mime.WordDecoder).DecodeHeader(title); err == nil {
+		title = decoded
+	}
+	if title == "" {
+		title = "(no subject)"
+	}
+	return title, strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/san-kum/reminder-tui/internal/config"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// cmdEnv carries the shared state every subcommand needs. localPath is
+// the device-local overlay layered over configPath.
+type cmdEnv struct {
+	ctx        context.Context
+	storage    storage.Storage
+	config     *config.Config
+	configPath string
+	localPath  string
+	dataDir    string
+	stdin      io.Reader
+	stdout     io.Writer
+}
+
+func runCommand(env *cmdEnv, args []string) error {
+	if env.ctx == nil {
+		env.ctx = context.Background()
+	}
+	if env.stdin == nil {
+		env.stdin = os.Stdin
+	}
+	if env.stdout == nil {
+		env.stdout = os.Stdout
+	}
+
+	switch args[0] {
+	case "tz":
+		return runTZ(env, args[1:])
+	case "import":
+		return runImport(env, args[1:])
+	case "smartlist":
+		return runSmartList(env, args[1:])
+	case "project":
+		return runProject(env, args[1:])
+	case "search":
+		return runSearch(env, args[1:])
+	case "stats":
+		return runStats(env, args[1:])
+	case "report":
+		return runReport(env, args[1:])
+	case "notify":
+		return runNotify(env, args[1:])
+	case "config":
+		return runConfig(env, args[1:])
+	case "version":
+		return runVersion(env, args[1:])
+	case "self-update":
+		return runSelfUpdate(env, args[1:])
+	case "add":
+		return runAdd(env, args[1:])
+	case "sources":
+		return runSources(env, args[1:])
+	case "migrate":
+		return runMigrate(env, args[1:])
+	case "serve":
+		return runServe(env, args[1:])
+	case "token":
+		return runToken(env, args[1:])
+	case "pause":
+		return runPause(env, args[1:])
+	case "resume":
+		return runResume(env, args[1:])
+	case "daemon":
+		return runDaemon(env, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// exitError makes the process exit with a specific status.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// exitCode is the status to exit with after err.
+func exitCode(err error) int {
+	var exit *exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	return 1
+}
+
+// parseInterspersed parses flags that may appear before, between or after
+// positional arguments and returns the positionals in order.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// confirm asks a yes/no question on stdin, defaulting to no.
+func confirm(env *cmdEnv, question string) bool {
+	fmt.Fprintf(env.stdout, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(env.stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
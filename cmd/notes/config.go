@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/san-kum/reminder-tui/internal/config"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+)
+
+const configUsage = "usage: notes config doctor"
+
+func runConfig(env *cmdEnv, args []string) error {
+	if len(args) != 1 || args[0] != "doctor" {
+		return errors.New(configUsage)
+	}
+	return runConfigDoctor(env)
+}
+
+// runConfigDoctor reports every problem in the config file and the
+// device-local overlay along with anything the current machine is missing
+// to honour them. Unlike normal startup it keeps going past the first
+// mistake.
+func runConfigDoctor(env *cmdEnv) error {
+	fmt.Fprintf(env.stdout, "Checking %s\n", env.configPath)
+
+	data, err := os.ReadFile(env.configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	var local []byte
+	if env.localPath != "" {
+		local, err = os.ReadFile(env.localPath)
+		if err == nil {
+			fmt.Fprintf(env.stdout, "with local overlay %s\n", env.localPath)
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("failed to read local overlay: %w", err)
+		}
+	}
+	fmt.Fprintln(env.stdout)
+	if data == nil && local == nil {
+		fmt.Fprintln(env.stdout, "No config file; using defaults.")
+		return nil
+	}
+
+	cfg, problems, err := config.CheckLayered(data, local)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		fmt.Fprintf(env.stdout, "error    %s\n", p)
+	}
+
+	warnings := environmentWarnings(cfg)
+	for _, w := range warnings {
+		fmt.Fprintf(env.stdout, "warning  %s\n", w)
+	}
+
+	if len(problems) == 0 && len(warnings) == 0 {
+		fmt.Fprintln(env.stdout, "No problems found.")
+		return nil
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d error(s) in config", len(problems))
+	}
+	return nil
+}
+
+// environmentWarnings lists configured features whose helper programs are
+// not installed.
+func environmentWarnings(cfg *config.Config) []string {
+	var warnings []string
+	if cfg.Notification.Desktop.Enabled {
+		tool := reminder.DesktopTool()
+		if _, err := exec.LookPath(tool); err != nil {
+			warnings = append(warnings, fmt.Sprintf("notification.desktop.enabled: %s is not installed; reminders will fail to show", tool))
+		}
+	}
+	if usesChannel(cfg, "tmux") {
+		if _, err := exec.LookPath("tmux"); err != nil {
+			warnings = append(warnings, "notification: the tmux channel is used but tmux is not installed; reminders will fail to show")
+		}
+	}
+	if fields := strings.Fields(cfg.Transcription.Command); len(fields) > 0 {
+		if _, err := exec.LookPath(fields[0]); err != nil {
+			warnings = append(warnings, fmt.Sprintf("transcription.command: %s is not on PATH", fields[0]))
+		}
+	}
+	return warnings
+}
+
+// usesChannel reports whether reminders are delivered through the named
+// channel.
+func usesChannel(cfg *config.Config, name string) bool {
+	for _, channel := range defaultChannels(cfg) {
+		if channel == name {
+			return true
+		}
+	}
+	for _, route := range cfg.Notification.Routes {
+		for _, channel := range route.Channels {
+			if channel == name {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"syscall"
+
+	"github.com/san-kum/reminder-tui/internal/reminder"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+const daemonUsage = "usage: notes daemon [--once] [install [--print] | uninstall]"
+
+// Exit statuses of a one-shot run, besides 0 for success and 1 when the
+// check couldn't run.
+const (
+	exitDeliveryFailed = 2
+	exitSessionActive  = 3
+)
+
+// The service the daemon is installed as.
+const (
+	systemdUnit  = "notes-reminders.service"
+	launchdLabel = "com.github.san-kum.reminder-tui"
+)
+
+// runDaemon delivers reminders without the TUI until interrupted, so they
+// fire even when no terminal is open. It shares notification duty with
+// any TUI like a second session would: whichever starts first delivers,
+// and the other takes over when it exits.
+func runDaemon(env *cmdEnv, args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "install":
+			return runDaemonInstall(env, args[1:])
+		case "uninstall":
+			return runDaemonUninstall(env, args[1:])
+		}
+	}
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	once := fs.Bool("once", false, "check reminders once and exit")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errors.New(daemonUsage)
+	}
+	if *once || env.config.Scheduler.OneShot {
+		return runDaemonOnce(env)
+	}
+
+	ctx, stop := signal.NotifyContext(env.ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if fs, ok := env.storage.(*storage.FileStorage); ok {
+		if err := fs.Watch(ctx); err != nil {
+			fmt.Fprintf(env.stdout, "Warning: changes made elsewhere won't be picked up: %v\n", err)
+		}
+	}
+
+	session, closeSession, err := newReminders(ctx, env.config, env.storage, env.dataDir, nil)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+	go session.Run(ctx)
+	go func() {
+		// Reminders relayed from a TUI holding duty need no showing here
+		for range session.Events() {
+		}
+	}()
+
+	fmt.Fprintf(env.stdout, "Delivering reminders for %s through %s; stop with Ctrl+C\n", env.dataDir, channelLabel(env.config))
+	<-session.Done()
+	return nil
+}
+
+// runDaemonOnce sends the reminders due now and exits, for cron. Which
+// reminders went out is kept in the data directory, so the next run only
+// repeats them as their escalation policy says. The exit status is 2 when
+// a reminder couldn't be delivered and 3 when skipped because another
+// session delivers reminders.
+func runDaemonOnce(env *cmdEnv) error {
+	session, closeSession, err := newReminders(env.ctx, env.config, env.storage, env.dataDir, nil)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+
+	result, err := session.CheckOnce()
+	switch {
+	case errors.Is(err, reminder.ErrSessionActive):
+		return &exitError{code: exitSessionActive, err: err}
+	case err != nil:
+		return fmt.Errorf("failed to check reminders: %w", err)
+	case result.Failed > 0:
+		return &exitError{code: exitDeliveryFailed, err: fmt.Errorf("%d of %d reminder(s) couldn't be delivered; see `notes report reminders`", result.Failed, result.Sent)}
+	}
+	fmt.Fprintf(env.stdout, "Sent %d reminder(s)\n", result.Sent)
+	return nil
+}
+
+// runDaemonInstall writes a systemd user unit on Linux or a launchd agent
+// on macOS that starts the daemon at login. With --print the file is
+// written to stdout instead.
+func runDaemonInstall(env *cmdEnv, args []string) error {
+	fs := flag.NewFlagSet("daemon install", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	printOnly := fs.Bool("print", false, "print the service file instead of installing it")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errors.New(daemonUsage)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the notes binary: %w", err)
+	}
+	path, err := serviceFilePath()
+	if err != nil {
+		return err
+	}
+	var content []byte
+	var next string
+	switch runtime.GOOS {
+	case "darwin":
+		content = launchdPlist(exe, env.dataDir)
+		next = "launchctl load -w " + path
+	default:
+		content = systemdUnitFile(exe, env.dataDir)
+		next = "systemctl --user daemon-reload && systemctl --user enable --now " + systemdUnit
+	}
+
+	if *printOnly {
+		_, err := env.stdout.Write(content)
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write service file: %w", err)
+	}
+	fmt.Fprintf(env.stdout, "Wrote %s\nStart it with: %s\n", path, next)
+	return nil
+}
+
+func runDaemonUninstall(env *cmdEnv, args []string) error {
+	if len(args) > 0 {
+		return errors.New(daemonUsage)
+	}
+	path, err := serviceFilePath()
+	if err != nil {
+		return err
+	}
+	stop := "systemctl --user disable --now " + systemdUnit
+	if runtime.GOOS == "darwin" {
+		stop = "launchctl unload -w " + path
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return fmt.Errorf("the daemon isn't installed (no %s)", path)
+	}
+	fmt.Fprintf(env.stdout, "Stop it first if it is running: %s\n", stop)
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove service file: %w", err)
+	}
+	fmt.Fprintf(env.stdout, "Removed %s\n", path)
+	return nil
+}
+
+// serviceFilePath is where the daemon's service file is installed on this
+// platform.
+func serviceFilePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join(home, "Library", "LaunchAgents", launchdLabel+".plist"), nil
+	case "windows":
+		return "", errors.New("installing the daemon isn't supported on Windows; run `notes daemon` from Task Scheduler instead")
+	}
+	configDir := os.Getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		configDir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configDir, "systemd", "user", systemdUnit), nil
+}
+
+func systemdUnitFile(exe, dataDir string) []byte {
+	return []byte(fmt.Sprintf(`[Unit]
+Description=notes reminders
+After=graphical-session.target
+
+[Service]
+ExecStart=%s --data %s daemon
+Restart=on-failure
+
+[Install]
+WantedBy=default.target
+`, strconv.Quote(exe), strconv.Quote(dataDir)))
+}
+
+func launchdPlist(exe, dataDir string) []byte {
+	var b bytes.Buffer
+	str := func(s string) string {
+		b.Reset()
+		xml.EscapeText(&b, []byte(s))
+		return "<string>" + b.String() + "</string>"
+	}
+	logPath := filepath.Join(dataDir, "daemon.log")
+	return []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	` + str(launchdLabel) + `
+	<key>ProgramArguments</key>
+	<array>
+		` + str(exe) + `
+		<string>--data</string>
+		` + str(dataDir) + `
+		<string>daemon</string>
+	</array>
+	<key>RunAtLoad</key>
+	<true/>
+	<key>KeepAlive</key>
+	<true/>
+	<key>StandardOutPath</key>
+	` + str(logPath) + `
+	<key>StandardErrorPath</key>
+	` + str(logPath) + `
+</dict>
+</plist>
+`)
+}
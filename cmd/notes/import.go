@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+const importUsage = "usage: notes import <file.json> [--source name]"
+
+// importFile is the on-disk format accepted by `notes import`: the same
+// shape as notes.json and tasks.json, combined.
+type importFile struct {
+	Notes []*models.Note `json:"notes"`
+	Tasks []*models.Task `json:"tasks"`
+}
+
+func runImport(env *cmdEnv, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	source := fs.String("source", "", "name of the source the data comes from (defaults to the file name)")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New(importUsage)
+	}
+
+	path := positional[0]
+	if *source == "" {
+		*source = filepath.Base(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read import file: %w", err)
+	}
+	var incoming importFile
+	if err := json.Unmarshal(data, &incoming); err != nil {
+		return fmt.Errorf("failed to parse import file: %w", err)
+	}
+
+	route, err := routeSource(env, *source)
+	if err != nil {
+		return err
+	}
+	trusted := env.config.Review.Trusted(*source)
+	var applied, staged, unchanged int
+
+	for _, note := range incoming.Notes {
+		local, err := env.storage.GetNote(env.ctx, note.ID)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("failed to look up note %s: %w", note.ID, err)
+		}
+		if local == nil {
+			captureNote(route, note)
+		} else {
+			// Updates keep the source that first captured the note
+			note.Source = local.Source
+			if len(models.DiffNotes(local, note)) == 0 {
+				unchanged++
+				continue
+			}
+		}
+		if trusted {
+			err = env.storage.SaveNote(env.ctx, note)
+			applied++
+		} else {
+			err = env.storage.StageChange(env.ctx, models.NewNoteChange(*source, note))
+			staged++
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, task := range incoming.Tasks {
+		local, err := env.storage.GetTask(env.ctx, task.ID)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("failed to look up task %s: %w", task.ID, err)
+		}
+		if local == nil {
+			captureTask(route, task)
+		} else {
+			task.Source = local.Source
+			if len(models.DiffTasks(local, task)) == 0 {
+				unchanged++
+				continue
+			}
+		}
+		if trusted {
+			err = env.storage.SaveTask(env.ctx, task)
+			applied++
+		} else {
+			err = env.storage.StageChange(env.ctx, models.NewTaskChange(*source, task))
+			staged++
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(env.stdout, "Imported from %s: %d applied, %d staged for review, %d unchanged\n", *source, applied, staged, unchanged)
+	return nil
+}
@@ -1,30 +1,55 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/charmbracelet/bubbletea"
-	"github.com/san-kum/reminder-tui/internal/reminder"
+	"github.com/san-kum/reminder-tui/internal/config"
+	"github.com/san-kum/reminder-tui/internal/models"
 	"github.com/san-kum/reminder-tui/internal/storage"
 	"github.com/san-kum/reminder-tui/internal/ui"
 )
 
 func main() {
 	var dataDir string
+	var start ui.StartOptions
+	var startTask string
 
-	homeDir, err := os.UserHomeDir()
-
+	defaultDir, err := defaultDataDir()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting home directory: %v\n", err)
 		os.Exit(1)
 	}
-	defaultDataDir := filepath.Join(homeDir, ".cli-notes")
-	flag.StringVar(&dataDir, "data", defaultDataDir, "Directory to store notes and and tasks data")
+	flag.StringVar(&dataDir, "data", defaultDir, "Directory to store notes and and tasks data")
+	flag.StringVar(&start.View, "view", "", "View to open: "+strings.Join(ui.StartViews, ", "))
+	flag.StringVar(&start.Filter, "filter", "", "Smart-list query to open with, e.g. 'tag=work status=overdue'")
+	flag.StringVar(&startTask, "task", "", "ID of a task to select")
 	flag.Parse()
+	start.Task = models.TaskID(startTask)
+
+	localPath, err := config.LocalPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting home directory: %v\n", err)
+		os.Exit(1)
+	}
+	if !flagSet("data") {
+		// This device may keep its data somewhere else
+		dir, err := config.LocalDataDir(localPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		if dir != "" {
+			dataDir = dir
+		}
+	}
 
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
 		fmt.Fprintf(os.Stderr, "Error creating data directory: %v\n", err)
@@ -36,18 +61,103 @@ func main() {
 		os.Exit(1)
 	}
 
-	notifier := &reminder.ConsoleNotifier{}
-	reminderService := reminder.NewReminderService(s, notifier, 1*time.Minute)
+	cfgPath := config.DefaultPath(dataDir)
+	switch flag.Arg(0) {
+	case "config", "version", "self-update":
+		// These have to run even when the config fails to load
+		env := &cmdEnv{storage: s, configPath: cfgPath, localPath: localPath, dataDir: dataDir}
+		if err := runCommand(env, flag.Args()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	cfg, err := config.LoadLayered(cfgPath, localPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	loc, err := cfg.Location()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	time.Local = loc
+	models.DefaultSubtaskPolicy = models.SubtaskPolicy(cfg.Tasks.Subtasks)
+	if models.DefaultWorkflow, err = cfg.Tasks.Workflow(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if flag.NArg() > 0 {
+		env := &cmdEnv{storage: s, config: cfg, configPath: cfgPath, localPath: localPath, dataDir: dataDir}
+		if err := runCommand(env, flag.Args()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(exitCode(err))
+		}
+		return
+	}
+
+	if err := runTUI(cfg, s, dataDir, start); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
 
-	reminderService.Start()
-	defer reminderService.Stop()
+// runTUI runs the app until it quits or the process is told to stop by
+// SIGINT or SIGTERM. Either way the reminder session is stopped, the
+// last storage write is let finish and the terminal is restored before
+// it returns.
+func runTUI(cfg *config.Config, s *storage.FileStorage, dataDir string, start ui.StartOptions) error {
+	app := ui.NewNotesApp(s, cfg, dataDir)
+	if err := app.Open(start); err != nil {
+		return err
+	}
+	defer s.Close()
+	defer app.Close()
 
-	app := ui.NewNotesApp(s)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := s.Watch(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: external changes won't be picked up: %v\n", err)
+	}
 
-	p := tea.NewProgram(app, tea.WithAltScreen())
+	// Printing to the terminal would garble the TUI; show a banner instead
+	banner := &ui.BannerNotifier{}
+	session, closeSession, err := newReminders(ctx, cfg, s, dataDir, banner)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+	sessionCtx, stopSession := context.WithCancel(ctx)
+	go session.Run(sessionCtx)
+	defer func() {
+		stopSession()
+		<-session.Done()
+	}()
+	app.SetReminderEvents(session.Events())
+
+	// Signals are handled here rather than by Bubble Tea, so they go
+	// through the same shutdown as quitting
+	p := tea.NewProgram(app, tea.WithAltScreen(), tea.WithoutSignalHandler())
+	banner.Attach(p)
+	go func() {
+		<-ctx.Done()
+		p.Quit()
+	}()
 	if _, err := p.Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error running application: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("failed to run application: %w", err)
 	}
+	return nil
+}
 
+// flagSet reports whether the named flag was given on the command line.
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
 }
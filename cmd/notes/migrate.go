@@ -0,0 +1,309 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+const migrateUsage = "usage: notes migrate --to <backend> [--from <backend>]\n       notes migrate ids [--dry-run]\n\nbackends: file:<dir> (or a plain directory path)"
+
+// migrateBatchSize is how many items are written per storage call.
+const migrateBatchSize = 100
+
+// openBackend opens a storage backend from a spec such as "file:/path".
+// The file backend is the only one this build knows.
+func openBackend(spec string) (storage.Storage, error) {
+	kind, location := "file", spec
+	if i := strings.Index(spec, ":"); i > 1 && !strings.ContainsAny(spec[:i], `/\.`) {
+		kind, location = spec[:i], spec[i+1:]
+	}
+	switch kind {
+	case "file":
+		if location == "" {
+			return nil, errors.New("file backend needs a directory")
+		}
+		return storage.NewFileStorage(location)
+	default:
+		return nil, fmt.Errorf("unsupported backend %q (available: file)", kind)
+	}
+}
+
+// runMigrate copies everything from one backend to another without
+// touching the source, so the app can keep running until the switch. The
+// destination must be empty; when verification finds a mismatch every
+// item written is removed again.
+func runMigrate(env *cmdEnv, args []string) error {
+	if len(args) > 0 && args[0] == "ids" {
+		return runMigrateIDs(env, args[1:])
+	}
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	from := fs.String("from", "", "backend to read from (defaults to the current data directory)")
+	to := fs.String("to", "", "backend to write to")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 || *to == "" {
+		return errors.New(migrateUsage)
+	}
+
+	src := env.storage
+	if *from != "" {
+		var err error
+		if src, err = openBackend(*from); err != nil {
+			return err
+		}
+	}
+	dst, err := openBackend(*to)
+	if err != nil {
+		return err
+	}
+
+	m := &migration{env: env, src: src, dst: dst}
+	if err := m.checkEmpty(); err != nil {
+		return err
+	}
+	if err := m.copy(); err != nil {
+		fmt.Fprintf(env.stdout, "\nCopy failed: %v\n", err)
+		return m.rollback(err)
+	}
+	if err := m.verify(); err != nil {
+		fmt.Fprintf(env.stdout, "Verification failed: %v\n", err)
+		return m.rollback(err)
+	}
+
+	fmt.Fprintf(env.stdout, "Migrated %d note(s), %d task(s), %d project(s), %d smart list(s) and %d pending change(s) to %s\n",
+		len(m.notes), len(m.tasks), len(m.projects), len(m.lists), len(m.changes), *to)
+	return nil
+}
+
+type migration struct {
+	env      *cmdEnv
+	src, dst storage.Storage
+
+	notes    []*models.Note
+	tasks    []*models.Task
+	projects []*models.Project
+	lists    []*models.SmartList
+	changes  []*models.Change
+
+	// written records what reached the destination, for rollback
+	writtenNotes    []models.NoteID
+	writtenTasks    []models.TaskID
+	writtenProjects []models.ProjectID
+	writtenLists    []models.SmartListID
+	writtenChanges  []models.ChangeID
+}
+
+func (m *migration) checkEmpty() error {
+	ctx := m.env.ctx
+	notes, err := m.dst.GetAllNotes(ctx)
+	if err != nil {
+		return err
+	}
+	tasks, err := m.dst.GetAllTasks(ctx)
+	if err != nil {
+		return err
+	}
+	if len(notes) > 0 || len(tasks) > 0 {
+		return errors.New("destination already holds data; migrate into an empty backend")
+	}
+	return nil
+}
+
+func (m *migration) copy() error {
+	ctx := m.env.ctx
+	var err error
+	if m.notes, err = m.src.GetAllNotes(ctx); err != nil {
+		return err
+	}
+	if m.tasks, err = m.src.GetAllTasks(ctx); err != nil {
+		return err
+	}
+	if m.projects, err = m.src.GetProjects(ctx); err != nil {
+		return err
+	}
+	if m.lists, err = m.src.GetSmartLists(ctx); err != nil {
+		return err
+	}
+	if m.changes, err = m.src.GetPendingChanges(ctx); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(m.notes); start += migrateBatchSize {
+		batch := m.notes[start:minInt(start+migrateBatchSize, len(m.notes))]
+		if err := m.dst.SaveNotesBatch(ctx, batch); err != nil {
+			return err
+		}
+		for _, note := range batch {
+			m.writtenNotes = append(m.writtenNotes, note.ID)
+		}
+		m.progress("notes", len(m.writtenNotes), len(m.notes))
+	}
+
+	for start := 0; start < len(m.tasks); start += migrateBatchSize {
+		batch := m.tasks[start:minInt(start+migrateBatchSize, len(m.tasks))]
+		if err := m.dst.SaveTasksBatch(ctx, batch); err != nil {
+			return err
+		}
+		for _, task := range batch {
+			m.writtenTasks = append(m.writtenTasks, task.ID)
+		}
+		m.progress("tasks", len(m.writtenTasks), len(m.tasks))
+	}
+
+	for _, project := range m.projects {
+		if err := m.dst.SaveProject(ctx, project); err != nil {
+			return err
+		}
+		m.writtenProjects = append(m.writtenProjects, project.ID)
+	}
+	for _, list := range m.lists {
+		if err := m.dst.SaveSmartList(ctx, list); err != nil {
+			return err
+		}
+		m.writtenLists = append(m.writtenLists, list.ID)
+	}
+	for _, change := range m.changes {
+		if err := m.dst.StageChange(ctx, change); err != nil {
+			return err
+		}
+		m.writtenChanges = append(m.writtenChanges, change.ID)
+	}
+	return nil
+}
+
+func (m *migration) progress(kind string, done, total int) {
+	fmt.Fprintf(m.env.stdout, "\rCopying %s: %d/%d", kind, done, total)
+	if done == total {
+		fmt.Fprintln(m.env.stdout)
+	}
+}
+
+// verify reads everything back from the destination and compares counts
+// and per-item checksums with the source.
+func (m *migration) verify() error {
+	ctx := m.env.ctx
+	fmt.Fprintln(m.env.stdout, "Verifying...")
+
+	notes, err := m.dst.GetAllNotes(ctx)
+	if err != nil {
+		return err
+	}
+	if err := compareChecksums("note", m.notes, notes, func(n *models.Note) string { return string(n.ID) }); err != nil {
+		return err
+	}
+
+	tasks, err := m.dst.GetAllTasks(ctx)
+	if err != nil {
+		return err
+	}
+	if err := compareChecksums("task", m.tasks, tasks, func(t *models.Task) string { return string(t.ID) }); err != nil {
+		return err
+	}
+
+	projects, err := m.dst.GetProjects(ctx)
+	if err != nil {
+		return err
+	}
+	if err := compareChecksums("project", m.projects, projects, func(p *models.Project) string { return string(p.ID) }); err != nil {
+		return err
+	}
+
+	lists, err := m.dst.GetSmartLists(ctx)
+	if err != nil {
+		return err
+	}
+	if err := compareChecksums("smart list", m.lists, lists, func(l *models.SmartList) string { return string(l.ID) }); err != nil {
+		return err
+	}
+
+	changes, err := m.dst.GetPendingChanges(ctx)
+	if err != nil {
+		return err
+	}
+	return compareChecksums("pending change", m.changes, changes, func(c *models.Change) string { return string(c.ID) })
+}
+
+func compareChecksums[T any](kind string, want, got []T, id func(T) string) error {
+	if len(want) != len(got) {
+		return fmt.Errorf("expected %d %s(s), found %d", len(want), kind, len(got))
+	}
+	sums := make(map[string][32]byte, len(want))
+	for _, item := range want {
+		sum, err := checksum(item)
+		if err != nil {
+			return err
+		}
+		sums[id(item)] = sum
+	}
+	for _, item := range got {
+		sum, err := checksum(item)
+		if err != nil {
+			return err
+		}
+		if expected, ok := sums[id(item)]; !ok || expected != sum {
+			return fmt.Errorf("%s %s differs from the source", kind, id(item))
+		}
+	}
+	return nil
+}
+
+func checksum(v interface{}) ([32]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("failed to checksum item: %w", err)
+	}
+	return sha256.Sum256(data), nil
+}
+
+// rollback removes everything the migration wrote and returns the cause.
+func (m *migration) rollback(cause error) error {
+	ctx := m.env.ctx
+	fmt.Fprintln(m.env.stdout, "Rolling back...")
+
+	var failed []string
+	for _, id := range m.writtenNotes {
+		if err := m.dst.DeleteNote(ctx, id); err != nil {
+			failed = append(failed, string(id))
+		}
+	}
+	for _, id := range m.writtenTasks {
+		if err := m.dst.DeleteTask(ctx, id); err != nil {
+			failed = append(failed, string(id))
+		}
+	}
+	for _, id := range m.writtenProjects {
+		if err := m.dst.DeleteProject(ctx, id); err != nil {
+			failed = append(failed, string(id))
+		}
+	}
+	for _, id := range m.writtenLists {
+		if err := m.dst.DeleteSmartList(ctx, id); err != nil {
+			failed = append(failed, string(id))
+		}
+	}
+	for _, id := range m.writtenChanges {
+		if err := m.dst.RejectChange(ctx, id); err != nil {
+			failed = append(failed, string(id))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("migration failed (%v) and %d item(s) could not be rolled back: %s",
+			cause, len(failed), strings.Join(failed, ", "))
+	}
+	return fmt.Errorf("migration failed and was rolled back: %w", cause)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+const migrateIDsUsage = "usage: notes migrate ids [--dry-run]"
+
+// runMigrateIDs gives every note and task created before the switch to
+// UUIDs a new ID, updating the references between them, trashed items,
+// staged review changes and the reminder service's pauses, sent reminders
+// and retries. The new IDs are recorded in the data directory before
+// anything is written and reused by the next run, so running it again
+// after an interruption finishes the job.
+func runMigrateIDs(env *cmdEnv, args []string) error {
+	fs := flag.NewFlagSet("migrate ids", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	dryRun := fs.Bool("dry-run", false, "report what would change without writing")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errors.New(migrateIDsUsage)
+	}
+
+	notes, err := env.storage.GetAllNotes(env.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load notes: %w", err)
+	}
+	tasks, err := env.storage.GetAllTasks(env.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load tasks: %w", err)
+	}
+	trash, err := env.storage.GetTrash(env.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load trash: %w", err)
+	}
+	changes, err := env.storage.GetPendingChanges(env.ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load review queue: %w", err)
+	}
+
+	mapPath := idMapPath(env.dataDir)
+	rewrite, err := loadIDRewrite(mapPath)
+	if err != nil {
+		return err
+	}
+	if !rewrite.Empty() {
+		fmt.Fprintln(env.stdout, "Finishing an interrupted migration")
+	}
+	allNotes, allTasks := notes, tasks
+	for _, trashed := range trash.Notes {
+		allNotes = append(allNotes, trashed.Note)
+	}
+	for _, trashed := range trash.Tasks {
+		allTasks = append(allTasks, trashed.Task)
+	}
+	rewrite.Add(allNotes, allTasks)
+	if rewrite.Empty() {
+		fmt.Fprintln(env.stdout, "All IDs are already UUIDs")
+		return nil
+	}
+	fmt.Fprintf(env.stdout, "%d note(s) and %d task(s) need new IDs\n", len(rewrite.Notes), len(rewrite.Tasks))
+	if *dryRun {
+		return nil
+	}
+	if err := saveIDRewrite(mapPath, rewrite); err != nil {
+		return err
+	}
+
+	// An interrupted run may have saved the new copy already; keep it, as
+	// it may have been edited since
+	noteIDs := make(map[models.NoteID]bool, len(notes))
+	for _, note := range notes {
+		noteIDs[note.ID] = true
+	}
+	taskIDs := make(map[models.TaskID]bool, len(tasks))
+	for _, task := range tasks {
+		taskIDs[task.ID] = true
+	}
+
+	var newNotes []*models.Note
+	for _, note := range notes {
+		if id, ok := rewrite.Notes[note.ID]; ok && noteIDs[id] {
+			continue
+		}
+		if rewritten, changed := rewrite.Note(note); changed {
+			newNotes = append(newNotes, rewritten)
+		}
+	}
+	var newTasks []*models.Task
+	for _, task := range tasks {
+		if id, ok := rewrite.Tasks[task.ID]; ok && taskIDs[id] {
+			continue
+		}
+		if rewritten, changed := rewrite.Task(task); changed {
+			newTasks = append(newTasks, rewritten)
+		}
+	}
+
+	if err := env.storage.SaveNotesBatch(env.ctx, newNotes); err != nil {
+		return fmt.Errorf("failed to save notes: %w", err)
+	}
+	if err := env.storage.SaveTasksBatch(env.ctx, newTasks); err != nil {
+		return fmt.Errorf("failed to save tasks: %w", err)
+	}
+	for old := range rewrite.Notes {
+		if err := env.storage.DeleteNote(env.ctx, old); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("failed to remove note %s: %w", old, err)
+		}
+	}
+	for old := range rewrite.Tasks {
+		if err := env.storage.DeleteTask(env.ctx, old); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("failed to remove task %s: %w", old, err)
+		}
+	}
+
+	trashChanged := false
+	for _, trashed := range trash.Notes {
+		if rewritten, changed := rewrite.Note(trashed.Note); changed {
+			trashed.Note, trashChanged = rewritten, true
+		}
+	}
+	for _, trashed := range trash.Tasks {
+		if rewritten, changed := rewrite.Task(trashed.Task); changed {
+			trashed.Task, trashChanged = rewritten, true
+		}
+	}
+	if trashChanged {
+		if err := env.storage.SaveTrash(env.ctx, trash); err != nil {
+			return fmt.Errorf("failed to save trash: %w", err)
+		}
+	}
+
+	restaged := 0
+	for _, change := range changes {
+		updated := &models.Change{ID: models.ChangeID(models.GenerateUniqueID()), Source: change.Source, ReceivedAt: change.ReceivedAt}
+		changed := false
+		if change.Note != nil {
+			updated.Note, changed = rewrite.Note(change.Note)
+		}
+		if change.Task != nil {
+			updated.Task, changed = rewrite.Task(change.Task)
+		}
+		if !changed {
+			continue
+		}
+		if err := env.storage.StageChange(env.ctx, updated); err != nil {
+			return fmt.Errorf("failed to restage change: %w", err)
+		}
+		// Staging replaces the old change itself when the item kept its ID
+		if err := env.storage.RejectChange(env.ctx, change.ID); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return fmt.Errorf("failed to remove old change: %w", err)
+		}
+		restaged++
+	}
+
+	pausePath := reminder.PausePath(env.dataDir)
+	pause, err := reminder.LoadPause(pausePath)
+	if err != nil {
+		return err
+	}
+	if pause != nil && len(pause.Missed) > 0 {
+		rewrite.TaskIDs(pause.Missed)
+		if err := reminder.SavePause(pausePath, pause); err != nil {
+			return err
+		}
+	}
+	if err := reminder.RewriteStateIDs(reminder.StatePath(env.dataDir), rewrite); err != nil {
+		return err
+	}
+	if err := reminder.RewriteRetryIDs(reminder.RetryPath(env.dataDir), rewrite); err != nil {
+		return err
+	}
+
+	if err := os.Remove(mapPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove ID map: %w", err)
+	}
+	fmt.Fprintf(env.stdout, "Rewrote %d note(s), %d task(s) and %d staged change(s)\n", len(rewrite.Notes), len(rewrite.Tasks), restaged)
+	return nil
+}
+
+// idMapPath is where a migration in progress keeps the IDs it assigned.
+func idMapPath(dataDir string) string {
+	return filepath.Join(dataDir, "id-migration.json")
+}
+
+// loadIDRewrite reads the IDs assigned by an interrupted migration, or
+// returns an empty rewrite when there was none.
+func loadIDRewrite(path string) (*models.IDRewrite, error) {
+	rewrite := models.NewIDRewrite(nil, nil)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return rewrite, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ID map: %w", err)
+	}
+	if err := json.Unmarshal(data, rewrite); err != nil {
+		return nil, fmt.Errorf("failed to parse ID map: %w", err)
+	}
+	return rewrite, nil
+}
+
+func saveIDRewrite(path string, rewrite *models.IDRewrite) error {
+	data, err := json.MarshalIndent(rewrite, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal ID map: %w", err)
+	}
+	if err := storage.WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write ID map: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/config"
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+)
+
+// buildNotifier creates the notifier described by the config. Desktop
+// reminders get action buttons handled by actions, if it is set. A
+// non-nil console replaces the console channel, e.g. with a banner inside
+// the TUI.
+func buildNotifier(ctx context.Context, cfg *config.Config, actions reminder.ActionHandler, console reminder.Notifier) (reminder.Notifier, error) {
+	channels, err := buildChannels(ctx, cfg, actions)
+	if err != nil {
+		return nil, err
+	}
+	if console != nil {
+		channels["console"] = console
+	}
+	named := func(names []string) []reminder.Channel {
+		list := make([]reminder.Channel, len(names))
+		for i, name := range names {
+			list[i] = reminder.Channel{Name: name, Notifier: channels[name]}
+		}
+		return list
+	}
+
+	defaults := defaultChannels(cfg)
+	if len(defaults) == 1 && len(cfg.Notification.Routes) == 0 {
+		return channels[defaults[0]], nil
+	}
+	fanOut := &reminder.FanOut{Default: named(defaults)}
+	for _, rc := range cfg.Notification.Routes {
+		route := reminder.Route{Channels: named(rc.Channels)}
+		for _, name := range rc.Priorities {
+			priority, err := models.ParsePriority(name)
+			if err != nil {
+				return nil, fmt.Errorf("notification.routes: %w", err)
+			}
+			route.Priorities = append(route.Priorities, priority)
+		}
+		for _, tag := range rc.Tags {
+			route.Tags = append(route.Tags, strings.TrimPrefix(tag, "#"))
+		}
+		fanOut.Routes = append(fanOut.Routes, route)
+	}
+	return fanOut, nil
+}
+
+// buildChannels creates every notifier this build can deliver through, keyed
+// by channel name. Desktop reminders stop waiting for clicks once ctx is
+// done.
+func buildChannels(ctx context.Context, cfg *config.Config, actions reminder.ActionHandler) (map[string]reminder.Notifier, error) {
+	alerts, err := buildAlerts(cfg.Notification.Priorities)
+	if err != nil {
+		return nil, err
+	}
+	tmuxDuration, err := configDuration("notification.tmux.duration", cfg.Notification.Tmux.Duration)
+	if err != nil {
+		return nil, err
+	}
+	snooze, err := snoozeFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	console := &reminder.ConsoleNotifier{Alerts: alerts}
+	desktop := &reminder.DesktopNotifier{Alerts: alerts, OnAction: actions, SnoozeFor: snooze, Context: ctx}
+	tmux := &reminder.TmuxNotifier{Duration: tmuxDuration, StatusOption: cfg.Notification.Tmux.StatusOption}
+	if console.Template, err = reminder.ParseMessageTemplate("console", cfg.Notification.Console.Template); err != nil {
+		return nil, fmt.Errorf("notification.console: %w", err)
+	}
+	if desktop.Template, err = reminder.ParseMessageTemplate("desktop", cfg.Notification.Desktop.Template); err != nil {
+		return nil, fmt.Errorf("notification.desktop: %w", err)
+	}
+	if tmux.Template, err = reminder.ParseMessageTemplate("tmux", cfg.Notification.Tmux.Template); err != nil {
+		return nil, fmt.Errorf("notification.tmux: %w", err)
+	}
+	channels := map[string]reminder.Notifier{
+		"console": console,
+		"desktop": desktop,
+		"tmux":    tmux,
+	}
+	for _, sc := range cfg.Notification.Slack {
+		tmpl, err := reminder.ParseSlackTemplate(sc.Template)
+		if err != nil {
+			return nil, fmt.Errorf("notification.slack %s: %w", sc.ChannelName(), err)
+		}
+		channels[sc.ChannelName()] = &reminder.SlackNotifier{Webhook: sc.Webhook, Template: tmpl}
+	}
+	for _, nc := range cfg.Notification.Ntfy {
+		priorities := make(map[models.Priority]int, len(nc.Priorities))
+		for name, value := range nc.Priorities {
+			priority, err := models.ParsePriority(name)
+			if err != nil {
+				return nil, fmt.Errorf("notification.ntfy %s: %w", nc.ChannelName(), err)
+			}
+			if priorities[priority], err = reminder.ParseNtfyPriority(value); err != nil {
+				return nil, fmt.Errorf("notification.ntfy %s: %w", nc.ChannelName(), err)
+			}
+		}
+		tmpl, err := reminder.ParseMessageTemplate("ntfy", nc.Template)
+		if err != nil {
+			return nil, fmt.Errorf("notification.ntfy %s: %w", nc.ChannelName(), err)
+		}
+		channels[nc.ChannelName()] = &reminder.NtfyNotifier{
+			Server:     nc.Server,
+			Topic:      nc.Topic,
+			Token:      nc.Token,
+			Priorities: priorities,
+			Template:   tmpl,
+		}
+	}
+	for _, wc := range cfg.Notification.Webhook {
+		tmpl, err := reminder.ParseMessageTemplate("webhook", wc.Template)
+		if err != nil {
+			return nil, fmt.Errorf("notification.webhook %s: %w", wc.ChannelName(), err)
+		}
+		channels[wc.ChannelName()] = &reminder.WebhookNotifier{URL: wc.URL, Template: tmpl}
+	}
+	for _, ec := range cfg.Notification.Email {
+		tmpl, err := reminder.ParseMessageTemplate("email", ec.Template)
+		if err != nil {
+			return nil, fmt.Errorf("notification.email %s: %w", ec.ChannelName(), err)
+		}
+		channels[ec.ChannelName()] = &reminder.EmailNotifier{
+			Server:   ec.Server,
+			Username: ec.Username,
+			Password: ec.Password,
+			From:     ec.From,
+			To:       ec.To,
+			Template: tmpl,
+		}
+	}
+	return channels, nil
+}
+
+// defaultChannels names the channels reminders not matched by a route are
+// delivered through.
+func defaultChannels(cfg *config.Config) []string {
+	if len(cfg.Notification.Channels) > 0 {
+		return cfg.Notification.Channels
+	}
+	if cfg.Notification.Desktop.Enabled {
+		return []string{"desktop"}
+	}
+	return []string{"console"}
+}
+
+// channelLabel names the delivery channels in the delivery log.
+func channelLabel(cfg *config.Config) string {
+	label := strings.Join(defaultChannels(cfg), "+")
+	if len(cfg.Notification.Routes) > 0 {
+		label += " (routed)"
+	}
+	return label
+}
+
+// buildAlerts overlays the configured priority alerts on the defaults.
+func buildAlerts(priorities map[string]config.AlertConfig) (reminder.AlertMap, error) {
+	alerts := reminder.DefaultAlerts()
+	for name, ac := range priorities {
+		priority, err := models.ParsePriority(name)
+		if err != nil {
+			return nil, fmt.Errorf("notification.priorities: %w", err)
+		}
+
+		alert := alerts[priority]
+		if ac.Urgency != "" {
+			urgency, err := reminder.ParseUrgency(ac.Urgency)
+			if err != nil {
+				return nil, fmt.Errorf("notification.priorities.%s: %w", name, err)
+			}
+			alert.Urgency = urgency
+		}
+		switch ac.Sound {
+		case "":
+		case "none":
+			alert.Sound = ""
+		default:
+			alert.Sound = ac.Sound
+		}
+		alerts[priority] = alert
+	}
+	return alerts, nil
+}
+
+// snoozeFor is how long the Snooze button on a reminder postpones it.
+func snoozeFor(cfg *config.Config) (time.Duration, error) {
+	d, err := configDuration("notification.snooze", cfg.Notification.Snooze)
+	if err != nil || d > 0 {
+		return d, err
+	}
+	return reminder.DefaultSnooze, nil
+}
+
+// configDuration parses the duration set for key, which is zero when unset.
+func configDuration(key, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", key, err)
+	}
+	return d, nil
+}
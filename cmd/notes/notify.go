@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+)
+
+const notifyUsage = "usage: notes notify test [--channel console|desktop|tmux|email|webhook|slack|ntfy[,...]] [--priority low|medium|high]"
+
+func runNotify(env *cmdEnv, args []string) error {
+	if len(args) == 0 || args[0] != "test" {
+		return errors.New(notifyUsage)
+	}
+	return runNotifyTest(env, args[1:])
+}
+
+// runNotifyTest sends a synthetic reminder through each requested channel
+// and reports whether delivery succeeded. Without --channel it uses the
+// channels reminders are normally delivered through.
+func runNotifyTest(env *cmdEnv, args []string) error {
+	fs := flag.NewFlagSet("notify test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	channelFlag := fs.String("channel", "", "comma-separated channels to test")
+	priorityFlag := fs.String("priority", "medium", "priority of the test reminder")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errors.New(notifyUsage)
+	}
+
+	priority, err := models.ParsePriority(*priorityFlag)
+	if err != nil {
+		return err
+	}
+
+	channels, err := buildChannels(env.ctx, env.config, nil)
+	if err != nil {
+		return err
+	}
+
+	names := defaultChannels(env.config)
+	if *channelFlag != "" {
+		names = strings.Split(*channelFlag, ",")
+	}
+
+	task := models.NewTask("Test reminder", "Sent by notes notify test", time.Now())
+	task.SetPriority(priority)
+
+	failed := 0
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		notifier, ok := channels[name]
+		if !ok {
+			failed++
+			fmt.Fprintf(env.stdout, "FAIL  %-8s not available (channels: %s)\n", name, channelNames(channels))
+			continue
+		}
+
+		start := time.Now()
+		if err := notifier.Notify(task); err != nil {
+			failed++
+			fmt.Fprintf(env.stdout, "FAIL  %-8s %v\n", name, err)
+			continue
+		}
+		fmt.Fprintf(env.stdout, "ok    %-8s delivered in %s\n", name, time.Since(start).Round(time.Millisecond))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d channel(s) failed", failed, len(names))
+	}
+	return nil
+}
+
+func channelNames(channels map[string]reminder.Notifier) string {
+	names := make([]string, 0, len(channels))
+	for name := range channels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
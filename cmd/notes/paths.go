@@ -0,0 +1,29 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// defaultDataDir is ~/.cli-notes. On Windows new installs use the roaming
+// application data folder instead, where per-user app data belongs; an
+// existing ~/.cli-notes keeps being used.
+func defaultDataDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	legacy := filepath.Join(homeDir, ".cli-notes")
+	if runtime.GOOS != "windows" {
+		return legacy, nil
+	}
+	if _, err := os.Stat(legacy); err == nil {
+		return legacy, nil
+	}
+	appData, err := os.UserConfigDir()
+	if err != nil {
+		return legacy, nil
+	}
+	return filepath.Join(appData, "cli-notes"), nil
+}
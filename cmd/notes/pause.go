@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/reminder"
+)
+
+const pauseUsage = "usage: notes pause [--until YYYY-MM-DD|3d|4h] | notes resume"
+
+// runPause pauses non-critical reminders, or shows the current pause when
+// no end is given.
+func runPause(env *cmdEnv, args []string) error {
+	fs := flag.NewFlagSet("pause", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	until := fs.String("until", "", "when reminders resume")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil || len(positional) > 1 || (*until != "" && len(positional) == 1) {
+		return errors.New(pauseUsage)
+	}
+	if len(positional) == 1 {
+		*until = positional[0]
+	}
+
+	path := reminder.PausePath(env.dataDir)
+	if *until == "" {
+		pause, err := reminder.LoadPause(path)
+		if err != nil {
+			return err
+		}
+		if !pause.Active(time.Now()) {
+			fmt.Fprintln(env.stdout, "Reminders are not paused.")
+			return nil
+		}
+		fmt.Fprintf(env.stdout, "Reminders paused until %s; %d reminder(s) held back so far.\n",
+			pause.Until.Format("Mon Jan 2, 2006 15:04"), len(pause.Missed))
+		return nil
+	}
+
+	at, err := reminder.ParsePauseUntil(*until, time.Now())
+	if err != nil {
+		return err
+	}
+	if _, err := reminder.PauseUntil(path, at); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "Non-critical reminders paused until %s. Run 'notes resume' to end early.\n",
+		at.Format("Mon Jan 2, 2006 15:04"))
+	return nil
+}
+
+func runResume(env *cmdEnv, args []string) error {
+	if len(args) > 0 {
+		return errors.New(pauseUsage)
+	}
+	pause, err := reminder.Resume(reminder.PausePath(env.dataDir))
+	if err != nil {
+		return err
+	}
+	if pause == nil {
+		fmt.Fprintln(env.stdout, "Reminders were not paused.")
+		return nil
+	}
+	fmt.Fprintf(env.stdout, "Reminders resumed. %d missed reminder(s) will arrive as a digest.\n", len(pause.Missed))
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+const projectUsage = "usage: notes project [ls | add <name> | rm <name>]"
+
+func runProject(env *cmdEnv, args []string) error {
+	if len(args) == 0 || args[0] == "ls" {
+		projects, err := env.storage.GetProjects(env.ctx)
+		if err != nil {
+			return err
+		}
+		if len(projects) == 0 {
+			fmt.Fprintln(env.stdout, "No projects defined.")
+		}
+		for _, p := range projects {
+			fmt.Fprintln(env.stdout, p.Name)
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) != 2 {
+			return errors.New(projectUsage)
+		}
+		if existing, _ := findProject(env, args[1]); existing != nil {
+			return fmt.Errorf("project %q already exists", existing.Name)
+		}
+		project := models.NewProject(args[1])
+		if project.Name == "" {
+			return errors.New("project name must not be empty")
+		}
+		if err := env.storage.SaveProject(env.ctx, project); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.stdout, "Added project %q\n", project.Name)
+		return nil
+
+	case "rm":
+		if len(args) != 2 {
+			return errors.New(projectUsage)
+		}
+		project, err := findProject(env, args[1])
+		if err != nil {
+			return err
+		}
+		if err := env.storage.DeleteProject(env.ctx, project.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.stdout, "Removed project %q; its notes and tasks were kept\n", project.Name)
+		return nil
+
+	default:
+		return errors.New(projectUsage)
+	}
+}
+
+func findProject(env *cmdEnv, name string) (*models.Project, error) {
+	projects, err := env.storage.GetProjects(env.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p := models.FindProject(projects, name); p != nil {
+		return p, nil
+	}
+	return nil, fmt.Errorf("no project named %q", name)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/config"
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+	"github.com/san-kum/reminder-tui/internal/report"
+)
+
+const reportUsage = "usage: notes report [ls | reminders [--days n] | <name> [--post]]"
+
+// buildReports turns the configured reports into jobs for the runner.
+func buildReports(cfg *config.Config) ([]*report.Job, error) {
+	jobs := make([]*report.Job, 0, len(cfg.Reports))
+	for _, rc := range cfg.Reports {
+		schedule, err := report.ParseSchedule(rc.At, rc.Days)
+		if err != nil {
+			return nil, fmt.Errorf("report %s: %w", rc.Name, err)
+		}
+		filter, err := models.ParseFilter(rc.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("report %s: %w", rc.Name, err)
+		}
+		tmpl, err := report.ParseTemplate(rc.Template)
+		if err != nil {
+			return nil, fmt.Errorf("report %s: %w", rc.Name, err)
+		}
+		jobs = append(jobs, &report.Job{
+			Name:     rc.Name,
+			Webhook:  rc.Webhook,
+			Schedule: schedule,
+			Filter:   filter,
+			Project:  rc.Project,
+			Template: tmpl,
+		})
+	}
+	return jobs, nil
+}
+
+// runReport lists the configured reports, or prints one as it would be
+// posted now. With --post it is sent to the webhook straight away.
+func runReport(env *cmdEnv, args []string) error {
+	if len(args) > 0 && args[0] == "reminders" {
+		return runReminderReport(env, args[1:])
+	}
+
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	post := fs.Bool("post", false, "post the report to its webhook now")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil || len(positional) > 1 {
+		return errors.New(reportUsage)
+	}
+
+	jobs, err := buildReports(env.config)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	if len(positional) == 0 || positional[0] == "ls" {
+		if len(jobs) == 0 {
+			fmt.Fprintln(env.stdout, "No reports configured; add them under reports: in config.yaml.")
+		}
+		for _, job := range jobs {
+			fmt.Fprintf(env.stdout, "%-16s next %s\n", job.Name, job.Schedule.Next(now).Format("Mon Jan 2 15:04"))
+		}
+		return nil
+	}
+
+	for _, job := range jobs {
+		if job.Name != positional[0] {
+			continue
+		}
+		text, err := job.Run(env.ctx, env.storage, now)
+		if err != nil {
+			return err
+		}
+		if !*post {
+			fmt.Fprint(env.stdout, text)
+			return nil
+		}
+		if err := report.Post(env.ctx, job.Webhook, text); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.stdout, "Posted report %q\n", job.Name)
+		return nil
+	}
+	return fmt.Errorf("no report named %q", positional[0])
+}
+
+// runReminderReport shows how promptly reminders went out over the last
+// few days, listing late and failed deliveries with their reasons.
+func runReminderReport(env *cmdEnv, args []string) error {
+	fs := flag.NewFlagSet("report reminders", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	days := fs.Int("days", 7, "how many days back to report on")
+	positional, err := parseInterspersed(fs, args)
+	if err != nil || len(positional) > 0 || *days <= 0 {
+		return errors.New(reportUsage)
+	}
+
+	since := time.Now().AddDate(0, 0, -*days)
+	deliveries, err := reminder.LoadDeliveries(reminder.DeliveryPath(env.dataDir), since)
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(env.stdout, reminder.SummarizeDeliveries(deliveries, since).Text())
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/san-kum/reminder-tui/internal/script"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// loadScripts starts the user's automation scripts, logging their output
+// to scripts.log in the data directory. It returns nil when there are none.
+func loadScripts(dataDir string, s storage.Storage) (*script.Engine, error) {
+	logFile, err := os.OpenFile(filepath.Join(dataDir, "scripts.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open script log: %w", err)
+	}
+
+	engine, err := script.Load(script.DefaultDir(dataDir), s, log.New(logFile, "", log.LstdFlags))
+	if err != nil || engine == nil {
+		logFile.Close()
+		return nil, err
+	}
+	return engine, nil
+}
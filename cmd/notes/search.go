@@ -0,0 +1,34 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func runSearch(env *cmdEnv, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: notes search <query>")
+	}
+
+	results, err := env.storage.SearchAll(env.ctx, strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		fmt.Fprintln(env.stdout, "No matches.")
+		return nil
+	}
+
+	for _, r := range results {
+		kind := "note"
+		id := ""
+		if r.Task != nil {
+			kind, id = "task", string(r.Task.ID)
+		} else {
+			id = string(r.Note.ID)
+		}
+		fmt.Fprintf(env.stdout, "%-4s  %-22s  %s\n", kind, id, r.Title())
+	}
+	return nil
+}
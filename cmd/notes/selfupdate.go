@@ -0,0 +1,235 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	selfUpdateUsage = "usage: notes self-update [--check]"
+	releasesURL     = "https://api.github.com/repos/san-kum/reminder-tui/releases/latest"
+	checksumsAsset  = "checksums.txt"
+)
+
+// releaseKey is the base64 ed25519 public key release checksums are signed
+// with, set by the release build. Without it only checksums are verified.
+var releaseKey = ""
+
+type release struct {
+	TagName string         `json:"tag_name"`
+	Assets  []releaseAsset `json:"assets"`
+}
+
+type releaseAsset struct {
+	FileName string `json:"name"`
+	URL      string `json:"browser_download_url"`
+}
+
+func runSelfUpdate(env *cmdEnv, args []string) error {
+	fs := flag.NewFlagSet("self-update", flag.ContinueOnError)
+	check := fs.Bool("check", false, "only report whether an update is available")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errors.New(selfUpdateUsage)
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the running binary: %w", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return fmt.Errorf("failed to locate the running binary: %w", err)
+	}
+
+	client := &http.Client{Timeout: time.Minute}
+	latest, err := fetchRelease(client)
+	if err != nil {
+		return err
+	}
+	if !newerVersion(latest.TagName, version) {
+		fmt.Fprintf(env.stdout, "notes %s is up to date\n", version)
+		return nil
+	}
+	fmt.Fprintf(env.stdout, "Update available: %s -> %s\n", version, latest.TagName)
+	if *check {
+		return nil
+	}
+	if manager := packageManager(exe); manager != "" {
+		return fmt.Errorf("notes was installed with %s; update it there instead", manager)
+	}
+
+	name := assetName(runtime.GOOS, runtime.GOARCH)
+	binary, ok := latest.asset(name)
+	if !ok {
+		return fmt.Errorf("release %s has no build for %s/%s", latest.TagName, runtime.GOOS, runtime.GOARCH)
+	}
+	sums, ok := latest.asset(checksumsAsset)
+	if !ok {
+		return fmt.Errorf("release %s has no %s; refusing to install an unverified binary", latest.TagName, checksumsAsset)
+	}
+
+	checksums, err := download(client, sums.URL)
+	if err != nil {
+		return err
+	}
+	if err := verifySignature(client, latest, checksums); err != nil {
+		return err
+	}
+	want, err := checksumFor(checksums, name)
+	if err != nil {
+		return err
+	}
+	data, err := download(client, binary.URL)
+	if err != nil {
+		return err
+	}
+	if got := sha256.Sum256(data); hex.EncodeToString(got[:]) != want {
+		return fmt.Errorf("checksum mismatch for %s; the download may be corrupted", name)
+	}
+
+	if err := replaceBinary(exe, data); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "Updated to %s\n", latest.TagName)
+	return nil
+}
+
+func fetchRelease(client *http.Client) (*release, error) {
+	data, err := download(client, releasesURL)
+	if err != nil {
+		return nil, err
+	}
+	var r release
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse release: %w", err)
+	}
+	return &r, nil
+}
+
+func (r *release) asset(name string) (releaseAsset, bool) {
+	for _, a := range r.Assets {
+		if a.FileName == name {
+			return a, true
+		}
+	}
+	return releaseAsset{}, false
+}
+
+func download(client *http.Client, url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return data, nil
+}
+
+// assetName is the release file for a platform, e.g. notes_linux_amd64.
+func assetName(goos, goarch string) string {
+	name := fmt.Sprintf("notes_%s_%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// checksumFor finds a file's hash in a sha256sum-style checksums file.
+func checksumFor(checksums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("%s doesn't list %s", checksumsAsset, name)
+}
+
+// verifySignature checks checksums.txt.sig against releaseKey when the
+// build has a key.
+func verifySignature(client *http.Client, r *release, checksums []byte) error {
+	if releaseKey == "" {
+		return nil
+	}
+	key, err := base64.StdEncoding.DecodeString(releaseKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return errors.New("this build has an invalid release key")
+	}
+	sigAsset, ok := r.asset(checksumsAsset + ".sig")
+	if !ok {
+		return fmt.Errorf("release %s is not signed", r.TagName)
+	}
+	encoded, err := download(client, sigAsset.URL)
+	if err != nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil || !ed25519.Verify(key, checksums, sig) {
+		return fmt.Errorf("release %s has a bad signature", r.TagName)
+	}
+	return nil
+}
+
+// packageManager names the package manager that owns the binary, if any,
+// so it isn't replaced behind the manager's back.
+func packageManager(exe string) string {
+	path := filepath.ToSlash(strings.ToLower(exe))
+	switch {
+	case strings.Contains(path, "/cellar/") || strings.Contains(path, "/homebrew/"):
+		return "Homebrew (brew upgrade)"
+	case strings.Contains(path, "/scoop/apps/"):
+		return "Scoop (scoop update)"
+	default:
+		return ""
+	}
+}
+
+// replaceBinary swaps in the new binary. The running one is renamed aside
+// first, since Windows won't overwrite an executable that is running.
+func replaceBinary(exe string, data []byte) error {
+	info, err := os.Stat(exe)
+	if err != nil {
+		return fmt.Errorf("failed to stat binary: %w", err)
+	}
+	newPath, oldPath := exe+".new", exe+".old"
+	if err := os.WriteFile(newPath, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write new binary: %w", err)
+	}
+	os.Remove(oldPath)
+	if err := os.Rename(exe, oldPath); err != nil {
+		os.Remove(newPath)
+		return fmt.Errorf("failed to move old binary aside: %w", err)
+	}
+	if err := os.Rename(newPath, exe); err != nil {
+		os.Rename(oldPath, exe)
+		return fmt.Errorf("failed to install new binary: %w", err)
+	}
+	// Windows keeps the running binary locked; the .old file is removed
+	// on the next update instead
+	os.Remove(oldPath)
+	return nil
+}
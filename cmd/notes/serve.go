@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/api"
+)
+
+const (
+	serveUsage = "usage: notes serve [--addr 127.0.0.1:7878]"
+	tokenUsage = "usage: notes token [ls | add <name> [--scope read|tasks|full] | rm <name>]"
+)
+
+// runServe exposes notes and tasks over HTTP until interrupted. Every
+// request needs a token from `notes token add`.
+func runServe(env *cmdEnv, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	addr := fs.String("addr", "127.0.0.1:7878", "address to listen on")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errors.New(serveUsage)
+	}
+
+	tokens, err := api.LoadTokens(api.TokensPath(env.dataDir))
+	if err != nil {
+		return err
+	}
+	if len(tokens) == 0 {
+		fmt.Fprintln(env.stdout, "Warning: no tokens yet, so every request will be refused; add one with `notes token add <name>`")
+	}
+
+	server, err := api.NewServer(env.storage, api.TokensPath(env.dataDir), api.AuditPath(env.dataDir))
+	if err != nil {
+		return err
+	}
+	defer server.Close()
+
+	httpServer := &http.Server{Addr: *addr, Handler: server, ReadHeaderTimeout: 10 * time.Second}
+	ctx, stop := signal.NotifyContext(env.ctx, os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(env.stdout, "Serving the API on http://%s/api/ (audit log: %s)\n", *addr, api.AuditPath(env.dataDir))
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
+
+func runToken(env *cmdEnv, args []string) error {
+	path := api.TokensPath(env.dataDir)
+	tokens, err := api.LoadTokens(path)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 || args[0] == "ls" {
+		if len(tokens) == 0 {
+			fmt.Fprintln(env.stdout, "No API tokens.")
+		}
+		for _, t := range tokens {
+			fmt.Fprintf(env.stdout, "%-20s %-6s created %s\n", t.Name, t.Scope, t.CreatedAt.Format("Jan 2, 2006"))
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "add":
+		fs := flag.NewFlagSet("token add", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		scopeName := fs.String("scope", string(api.ScopeRead), "read, tasks or full")
+		positional, err := parseInterspersed(fs, args[1:])
+		if err != nil || len(positional) != 1 {
+			return errors.New(tokenUsage)
+		}
+		scope, err := api.ParseScope(*scopeName)
+		if err != nil {
+			return err
+		}
+		for _, t := range tokens {
+			if t.Name == positional[0] {
+				return fmt.Errorf("token %q already exists", t.Name)
+			}
+		}
+		token, secret := api.NewToken(positional[0], scope)
+		if err := api.SaveTokens(path, append(tokens, token)); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.stdout, "Added %s token %q. Its secret is shown only once:\n%s\n", scope, token.Name, secret)
+		return nil
+
+	case "rm":
+		if len(args) != 2 {
+			return errors.New(tokenUsage)
+		}
+		for i, t := range tokens {
+			if t.Name == args[1] {
+				if err := api.SaveTokens(path, append(tokens[:i], tokens[i+1:]...)); err != nil {
+					return err
+				}
+				fmt.Fprintf(env.stdout, "Revoked token %q\n", t.Name)
+				return nil
+			}
+		}
+		return fmt.Errorf("no token named %q", args[1])
+
+	default:
+		return errors.New(tokenUsage)
+	}
+}
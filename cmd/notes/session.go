@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/config"
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+	"github.com/san-kum/reminder-tui/internal/report"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// reminderSession makes sure exactly one process delivers reminders for a
+// data directory. The first one to start takes notification duty; later
+// ones follow it over the socket and receive its reminders instead of
+// running a second loop, taking over if it exits. Scheduled duties such as
+// webhook reports run alongside the loop in the same process.
+type reminderSession struct {
+	path       string
+	notifier   reminder.Notifier
+	log        *log.Logger
+	newService func(reminder.Notifier) *reminder.ReminderService
+	duties     []func(reminder.Notifier) duty
+
+	events chan reminder.Event
+	done   chan struct{}
+}
+
+// duty is background work only the session with notification duty does.
+type duty interface {
+	Start()
+	Stop()
+}
+
+func newReminderSession(dataDir string, notifier reminder.Notifier, logger *log.Logger, newService func(reminder.Notifier) *reminder.ReminderService) *reminderSession {
+	return &reminderSession{
+		path:       reminder.SocketPath(dataDir),
+		notifier:   notifier,
+		log:        logger,
+		newService: newService,
+		events:     make(chan reminder.Event),
+		done:       make(chan struct{}),
+	}
+}
+
+// AddDuty registers work to start whenever this process takes
+// notification duty. newDuty is called each time, since a duty can't be
+// restarted once stopped, with the notifier reminders go out through.
+func (s *reminderSession) AddDuty(newDuty func(reminder.Notifier) duty) {
+	s.duties = append(s.duties, newDuty)
+}
+
+// Run coordinates until ctx is cancelled, then stops any reminder loop it
+// started before closing Done.
+func (s *reminderSession) Run(ctx context.Context) {
+	defer close(s.done)
+	for ctx.Err() == nil {
+		hub, err := reminder.Listen(s.path, s.notifier)
+		switch {
+		case err == nil:
+			s.lead(ctx, hub, hub.Events())
+			hub.Close()
+			return
+		case !errors.Is(err, reminder.ErrSessionActive):
+			// Without the socket there is nothing to coordinate over;
+			// deliver reminders ourselves as before
+			s.log.Printf("delivering reminders without coordinating: %v", err)
+			s.lead(ctx, s.notifier, nil)
+			return
+		}
+
+		feed, err := reminder.Follow(ctx, s.path)
+		if err != nil {
+			// The other session is shutting down; try again shortly
+			s.log.Printf("retrying: %v", err)
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+			}
+			continue
+		}
+		s.forward(ctx, feed)
+	}
+}
+
+// lead runs the reminder loop with notifier until ctx is cancelled
+func (s *reminderSession) lead(ctx context.Context, notifier reminder.Notifier, feed <-chan reminder.Event) {
+	service := s.newService(notifier)
+	service.Start()
+	defer service.Stop()
+	for _, newDuty := range s.duties {
+		d := newDuty(notifier)
+		d.Start()
+		defer d.Stop()
+	}
+	s.forward(ctx, feed)
+	<-ctx.Done()
+}
+
+// forward passes reminders on to the TUI until feed closes or ctx is
+// cancelled. A nil feed returns immediately.
+func (s *reminderSession) forward(ctx context.Context, feed <-chan reminder.Event) {
+	if feed == nil {
+		return
+	}
+	for {
+		select {
+		case e, ok := <-feed:
+			if !ok {
+				return
+			}
+			select {
+			case s.events <- e:
+			case <-ctx.Done():
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// CheckOnce takes notification duty just long enough for a single
+// reminder check. It fails with reminder.ErrSessionActive while another
+// session delivers reminders.
+func (s *reminderSession) CheckOnce() (reminder.CheckResult, error) {
+	hub, err := reminder.Listen(s.path, s.notifier)
+	var notifier reminder.Notifier = hub
+	switch {
+	case errors.Is(err, reminder.ErrSessionActive):
+		return reminder.CheckResult{}, err
+	case err != nil:
+		// Nothing to coordinate over; deliver them ourselves
+		s.log.Printf("delivering reminders without coordinating: %v", err)
+		notifier = s.notifier
+	default:
+		defer hub.Close()
+	}
+	return s.newService(notifier).CheckOnce()
+}
+
+// Events delivers every reminder shown by whichever session has duty.
+func (s *reminderSession) Events() <-chan reminder.Event {
+	return s.events
+}
+
+// Done is closed once the session has stopped.
+func (s *reminderSession) Done() <-chan struct{} {
+	return s.done
+}
+
+// newReminders sets up the reminder session for dataDir as configured:
+// the notifier, user scripts, the reminder service and the duties run
+// alongside it. A non-nil console replaces the console channel. Errors in
+// the background, such as trouble coordinating with other sessions, are
+// logged to session.log in dataDir.
+// The returned func releases the scripts and the log once the session has
+// stopped.
+func newReminders(ctx context.Context, cfg *config.Config, s storage.Storage, dataDir string, console reminder.Notifier) (*reminderSession, func(), error) {
+	snooze, err := snoozeFor(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	notifier, err := buildNotifier(ctx, cfg, notificationActions(ctx, s, dataDir, snooze), console)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	alerts, err := buildAlerts(cfg.Notification.Priorities)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	escalation, err := cfg.Notification.EscalationPolicies()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	retry, retrying, err := cfg.Notification.Retry.Policy()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	reports, err := buildReports(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	batchWindow, err := configDuration("notification.batch", cfg.Notification.Batch)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	overdueEvery, err := configDuration("notification.overdue_digest", cfg.Notification.OverdueDigest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	logFile, err := os.OpenFile(filepath.Join(dataDir, "session.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open session log: %w", err)
+	}
+
+	engine, err := loadScripts(dataDir, s)
+	if err != nil {
+		logFile.Close()
+		return nil, nil, fmt.Errorf("failed to load scripts: %w", err)
+	}
+	release := func() { logFile.Close() }
+	if engine != nil {
+		engine.Start()
+		release = func() {
+			engine.Stop()
+			logFile.Close()
+		}
+		notifier = engine.Notifier(notifier)
+	}
+
+	logger := log.New(logFile, "", log.LstdFlags)
+	session := newReminderSession(dataDir, notifier, logger, func(n reminder.Notifier) *reminder.ReminderService {
+		reminderService := reminder.NewReminderService(s, n, 15*time.Minute)
+		reminderService.SetLogger(logger)
+		reminderService.SetPause(reminder.PausePath(dataDir), func(task *models.Task) bool {
+			return alerts.For(task.Priority).Urgency == reminder.UrgencyCritical
+		})
+		reminderService.SetDeliveryLog(reminder.DeliveryPath(dataDir), channelLabel(cfg))
+		reminderService.SetEscalation(escalation)
+		reminderService.SetStatePath(reminder.StatePath(dataDir))
+		reminderService.SetBatchWindow(batchWindow)
+		if retrying {
+			reminderService.SetRetry(retry, reminder.RetryPath(dataDir), reminder.DeadLetterPath(dataDir))
+		}
+		return reminderService
+	})
+	session.AddDuty(func(reminder.Notifier) duty {
+		runner := report.NewRunner(s, reports, report.StatePath(dataDir), 1*time.Minute)
+		runner.SetLogger(logger)
+		return runner
+	})
+	session.AddDuty(func(n reminder.Notifier) duty {
+		digest := reminder.NewOverdueDigest(s, n, overdueEvery, reminder.OverdueStatePath(dataDir), 1*time.Minute)
+		digest.SetPause(reminder.PausePath(dataDir))
+		digest.SetLogger(logger)
+		return digest
+	})
+	return session, release, nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+const smartListUsage = `usage: notes smartlist [ls | add <name> <query> | rm <name>]
+query terms: tag=<tag> status=<status> priority=<priority> context=@<context> due<7d due>2024-08-15`
+
+func runSmartList(env *cmdEnv, args []string) error {
+	if len(args) == 0 || args[0] == "ls" {
+		lists, err := env.storage.GetSmartLists(env.ctx)
+		if err != nil {
+			return err
+		}
+		if len(lists) == 0 {
+			fmt.Fprintln(env.stdout, "No smart lists defined.")
+		}
+		for _, l := range lists {
+			fmt.Fprintf(env.stdout, "%-24s %s\n", l.Name, l.Query)
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "add":
+		if len(args) != 3 {
+			return errors.New(smartListUsage)
+		}
+		if existing, _ := findSmartList(env, args[1]); existing != nil {
+			return fmt.Errorf("smart list %q already exists", args[1])
+		}
+		list, err := models.NewSmartList(args[1], args[2])
+		if err != nil {
+			return err
+		}
+		if err := env.storage.SaveSmartList(env.ctx, list); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.stdout, "Added smart list %q\n", list.Name)
+		return nil
+
+	case "rm":
+		if len(args) != 2 {
+			return errors.New(smartListUsage)
+		}
+		list, err := findSmartList(env, args[1])
+		if err != nil {
+			return err
+		}
+		if err := env.storage.DeleteSmartList(env.ctx, list.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(env.stdout, "Removed smart list %q\n", list.Name)
+		return nil
+
+	default:
+		return errors.New(smartListUsage)
+	}
+}
+
+func findSmartList(env *cmdEnv, name string) (*models.SmartList, error) {
+	lists, err := env.storage.GetSmartLists(env.ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range lists {
+		if l.Name == name {
+			return l, nil
+		}
+	}
+	return nil, fmt.Errorf("no smart list named %q", name)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+const sourcesUsage = "usage: notes sources [name]"
+
+// runSources audits what each capture integration created: a summary of
+// every source, or the items from one source newest first.
+func runSources(env *cmdEnv, args []string) error {
+	if len(args) > 1 {
+		return errors.New(sourcesUsage)
+	}
+
+	notes, err := env.storage.GetAllNotes(env.ctx)
+	if err != nil {
+		return err
+	}
+	tasks, err := env.storage.GetAllTasks(env.ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		return listSource(env, args[0], notes, tasks)
+	}
+
+	type summary struct {
+		notes, tasks int
+		last         time.Time
+	}
+	sources := make(map[string]*summary)
+	get := func(name string) *summary {
+		if sources[name] == nil {
+			sources[name] = &summary{}
+		}
+		return sources[name]
+	}
+	for _, note := range notes {
+		if note.Source != "" {
+			s := get(note.Source)
+			s.notes++
+			if note.CreatedAt.After(s.last) {
+				s.last = note.CreatedAt
+			}
+		}
+	}
+	for _, task := range tasks {
+		if task.Source != "" {
+			s := get(task.Source)
+			s.tasks++
+			if task.CreatedAt.After(s.last) {
+				s.last = task.CreatedAt
+			}
+		}
+	}
+	for name := range env.config.Capture.Sources {
+		get(name)
+	}
+
+	if len(sources) == 0 {
+		fmt.Fprintln(env.stdout, "Nothing has been captured from an integration yet.")
+		return nil
+	}
+
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(env.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tNOTES\tTASKS\tLAST CAPTURE\tROUTED TAGS\tROUTED PROJECT")
+	for _, name := range names {
+		s := sources[name]
+		last := "-"
+		if !s.last.IsZero() {
+			last = s.last.Format("Jan 2, 2006 15:04")
+		}
+		route := env.config.Capture.Route(name)
+		project := route.Project
+		if project == "" {
+			project = "-"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%v\t%s\n", name, s.notes, s.tasks, last, route.Tags, project)
+	}
+	return w.Flush()
+}
+
+func listSource(env *cmdEnv, source string, notes []*models.Note, tasks []*models.Task) error {
+	type item struct {
+		kind, id, title string
+		created         time.Time
+	}
+	var items []item
+	for _, note := range notes {
+		if note.Source == source {
+			items = append(items, item{"note", string(note.ID), note.Title, note.CreatedAt})
+		}
+	}
+	for _, task := range tasks {
+		if task.Source == source {
+			items = append(items, item{"task", string(task.ID), task.Title, task.CreatedAt})
+		}
+	}
+	if len(items) == 0 {
+		fmt.Fprintf(env.stdout, "Nothing captured from %s.\n", source)
+		return nil
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].created.After(items[j].created)
+	})
+
+	w := tabwriter.NewWriter(env.stdout, 0, 0, 2, ' ', 0)
+	for _, it := range items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", it.created.Format("Jan 2 15:04"), it.kind, it.id, it.title)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+const statsUsage = "usage: notes stats notes | tasks [--date YYYY-MM-DD]"
+
+func runStats(env *cmdEnv, args []string) error {
+	if len(args) == 0 {
+		return errors.New(statsUsage)
+	}
+	switch args[0] {
+	case "notes":
+		if len(args) != 1 {
+			return errors.New(statsUsage)
+		}
+		return runNoteStats(env)
+	case "tasks":
+		return runTaskStats(env, args[1:])
+	default:
+		return errors.New(statsUsage)
+	}
+}
+
+// runTaskStats sums the effort planned for a day and compares estimates
+// with the time completed tasks actually took.
+func runTaskStats(env *cmdEnv, args []string) error {
+	fs := flag.NewFlagSet("stats tasks", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	date := fs.String("date", "", "day to plan (defaults to today)")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		return errors.New(statsUsage)
+	}
+
+	day := time.Now()
+	if *date != "" {
+		var err error
+		if day, err = time.ParseInLocation("2006-01-02", *date, time.Local); err != nil {
+			return fmt.Errorf("invalid date %q: %w", *date, err)
+		}
+	}
+
+	tasks, err := env.storage.GetAllTasks(env.ctx)
+	if err != nil {
+		return err
+	}
+
+	y, m, d := day.Date()
+	var due, unestimated, completed int
+	var estimated, actual time.Duration
+	for _, task := range tasks {
+		if !task.DueDate.IsZero() && task.IsOpen() {
+			if ty, tm, td := task.DueDate.Date(); ty == y && tm == m && td == d {
+				due++
+				if task.Estimate == 0 {
+					unestimated++
+				}
+			}
+		}
+		if task.Status == models.TaskStatusCompleted && task.Estimate > 0 && !task.StartedAt.IsZero() {
+			completed++
+			estimated += task.Estimate
+			actual += task.Actual(time.Now())
+		}
+	}
+
+	fmt.Fprintf(env.stdout, "%s\n", day.Format("Monday, Jan 2 2006"))
+	fmt.Fprintf(env.stdout, "Open tasks due:     %d\n", due)
+	fmt.Fprintf(env.stdout, "Estimated effort:   %s", models.FormatEffort(models.SumEstimates(tasks, day)))
+	if unestimated > 0 {
+		fmt.Fprintf(env.stdout, " (%d without an estimate)", unestimated)
+	}
+	fmt.Fprintln(env.stdout)
+
+	if completed > 0 {
+		fmt.Fprintf(env.stdout, "\nAcross %d completed task(s) with an estimate:\n", completed)
+		fmt.Fprintf(env.stdout, "Estimated %s, took %s (%.0f%% of estimate)\n",
+			models.FormatEffort(estimated), models.FormatEffort(actual), 100*actual.Hours()/estimated.Hours())
+	}
+	return nil
+}
+
+// runNoteStats summarizes word totals, notes per tag and how the collection
+// has grown month by month. Notes keep no revision history, so growth is
+// attributed to the month each note was created using its current size.
+func runNoteStats(env *cmdEnv) error {
+	notes, err := env.storage.GetAllNotes(env.ctx)
+	if err != nil {
+		return err
+	}
+	if len(notes) == 0 {
+		fmt.Fprintln(env.stdout, "No notes yet.")
+		return nil
+	}
+
+	type month struct {
+		notes, words int
+	}
+	var totalWords int
+	var reading time.Duration
+	perTag := make(map[string]int)
+	growth := make(map[string]*month)
+	for _, note := range notes {
+		words := note.WordCount()
+		totalWords += words
+		reading += note.ReadingTime()
+		for _, tag := range note.Tags {
+			perTag[tag]++
+		}
+		key := note.CreatedAt.Format("2006-01")
+		if growth[key] == nil {
+			growth[key] = &month{}
+		}
+		growth[key].notes++
+		growth[key].words += words
+	}
+
+	fmt.Fprintf(env.stdout, "Notes:         %d\n", len(notes))
+	fmt.Fprintf(env.stdout, "Words:         %d (avg %d per note)\n", totalWords, totalWords/len(notes))
+	fmt.Fprintf(env.stdout, "Reading time:  %s\n", reading)
+
+	if len(perTag) > 0 {
+		tags := make([]string, 0, len(perTag))
+		for tag := range perTag {
+			tags = append(tags, tag)
+		}
+		sort.Slice(tags, func(i, j int) bool {
+			if perTag[tags[i]] != perTag[tags[j]] {
+				return perTag[tags[i]] > perTag[tags[j]]
+			}
+			return tags[i] < tags[j]
+		})
+
+		fmt.Fprintln(env.stdout, "\nNotes per tag:")
+		w := tabwriter.NewWriter(env.stdout, 0, 0, 2, ' ', 0)
+		for _, tag := range tags {
+			fmt.Fprintf(w, "  %s\t%d\n", tag, perTag[tag])
+		}
+		w.Flush()
+	}
+
+	months := make([]string, 0, len(growth))
+	maxWords := 0
+	for key, m := range growth {
+		months = append(months, key)
+		if m.words > maxWords {
+			maxWords = m.words
+		}
+	}
+	sort.Strings(months)
+
+	fmt.Fprintln(env.stdout, "\nGrowth by month created:")
+	w := tabwriter.NewWriter(env.stdout, 0, 0, 2, ' ', 0)
+	cumulative := 0
+	for _, key := range months {
+		m := growth[key]
+		cumulative += m.words
+		bar := ""
+		if maxWords > 0 {
+			bar = strings.Repeat("█", (m.words*30+maxWords-1)/maxWords)
+		}
+		fmt.Fprintf(w, "  %s\t+%d notes\t+%d words\t%d total\t%s\n", key, m.notes, m.words, cumulative, bar)
+	}
+	return w.Flush()
+}
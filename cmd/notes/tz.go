@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/config"
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+const tzUsage = "usage: notes tz [show | set <zone> [--shift-reminders] [--yes]]"
+
+func runTZ(env *cmdEnv, args []string) error {
+	if len(args) == 0 || args[0] == "show" {
+		loc, err := env.config.Location()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(env.stdout, "Timezone: %s\n", loc)
+		return nil
+	}
+	if args[0] != "set" {
+		return errors.New(tzUsage)
+	}
+
+	fs := flag.NewFlagSet("tz set", flag.ContinueOnError)
+	shift := fs.Bool("shift-reminders", false, "keep the wall-clock time of due dates and reminders in the new zone, except for tasks with their own")
+	yes := fs.Bool("yes", false, "apply without asking for confirmation")
+	positional, err := parseInterspersed(fs, args[1:])
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return errors.New(tzUsage)
+	}
+
+	from, err := env.config.Location()
+	if err != nil {
+		return err
+	}
+	to, err := time.LoadLocation(positional[0])
+	if err != nil {
+		return fmt.Errorf("unknown timezone %q: %w", positional[0], err)
+	}
+
+	if *shift {
+		if err := shiftTasks(env, from, to, *yes); err != nil {
+			return err
+		}
+	}
+
+	// Save to the shared file alone so the overlay's settings stay local
+	if err := config.SetValue(env.configPath, "timezone", to.String()); err != nil {
+		return err
+	}
+	fmt.Fprintf(env.stdout, "Timezone set to %s\n", to)
+	if cfg, err := config.LoadLayered(env.configPath, env.localPath); err == nil && cfg.Timezone != to.String() {
+		fmt.Fprintf(env.stdout, "Note: %s sets %s on this device\n", env.localPath, cfg.Timezone)
+	}
+	return nil
+}
+
+// shiftTasks previews the wall-clock shift of every open task and applies it
+// once confirmed. Tasks with their own timezone keep their times.
+func shiftTasks(env *cmdEnv, from, to *time.Location, skipConfirm bool) error {
+	tasks, err := env.storage.GetAllTasks(env.ctx)
+	if err != nil {
+		return err
+	}
+
+	var affected []*models.Task
+	for _, task := range tasks {
+		if !task.IsOpen() || task.DueDate.IsZero() || task.Timezone != "" {
+			continue
+		}
+		affected = append(affected, task)
+	}
+	if len(affected) == 0 {
+		fmt.Fprintln(env.stdout, "No open tasks to shift.")
+		return nil
+	}
+
+	const layout = "Jan 2, 2006 at 3:04 PM MST"
+	fmt.Fprintf(env.stdout, "Shifting %d task(s) from %s to %s:\n", len(affected), from, to)
+	for _, task := range affected {
+		shifted := *task
+		shifted.ShiftTimezone(from, to)
+		fmt.Fprintf(env.stdout, "  %s\n", task.Title)
+		fmt.Fprintf(env.stdout, "    due:      %s -> %s\n", task.DueDate.In(from).Format(layout), shifted.DueDate.Format(layout))
+		fmt.Fprintf(env.stdout, "    reminder: %s -> %s\n", task.ReminderAt.In(from).Format(layout), shifted.ReminderAt.Format(layout))
+	}
+
+	if !skipConfirm && !confirm(env, "Apply these changes?") {
+		return fmt.Errorf("aborted, nothing was changed")
+	}
+
+	for _, task := range affected {
+		task.ShiftTimezone(from, to)
+		if err := env.storage.SaveTask(env.ctx, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
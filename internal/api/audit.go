@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AuditPath is where every API request is logged.
+func AuditPath(dataDir string) string {
+	return filepath.Join(dataDir, "audit.log")
+}
+
+// AuditEntry records one request, one JSON object per line.
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Token  string    `json:"token,omitempty"`
+	Method string    `json:"method"`
+	Path   string    `json:"path"`
+	Status int       `json:"status"`
+	Remote string    `json:"remote"`
+}
+
+type auditLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func openAudit(path string) (*auditLog, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &auditLog{file: file}, nil
+}
+
+func (a *auditLog) record(e AuditEntry) {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.file.Write(append(line, '\n'))
+}
+
+func (a *auditLog) Close() error {
+	return a.file.Close()
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// Server answers /api/notes and /api/tasks requests. Tokens are re-read on
+// every request, so adding or revoking one takes effect immediately.
+type Server struct {
+	storage    storage.Storage
+	tokensPath string
+	audit      *auditLog
+}
+
+func NewServer(s storage.Storage, tokensPath, auditPath string) (*Server, error) {
+	audit, err := openAudit(auditPath)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{storage: s, tokensPath: tokensPath, audit: audit}, nil
+}
+
+func (s *Server) Close() error {
+	return s.audit.Close()
+}
+
+// statusRecorder keeps the response status for the audit log.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	entry := AuditEntry{Time: time.Now(), Method: r.Method, Path: r.URL.Path, Remote: r.RemoteAddr}
+	defer func() {
+		entry.Status = rec.status
+		s.audit.record(entry)
+	}()
+
+	tokens, err := LoadTokens(s.tokensPath)
+	if err != nil {
+		writeError(rec, http.StatusInternalServerError, err)
+		return
+	}
+	token := authenticate(tokens, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+	if token == nil {
+		writeError(rec, http.StatusUnauthorized, errors.New("missing or unknown token"))
+		return
+	}
+	entry.Token = token.Name
+
+	kind, id := splitPath(r.URL.Path)
+	if kind != "notes" && kind != "tasks" {
+		writeError(rec, http.StatusNotFound, fmt.Errorf("no such endpoint %s", r.URL.Path))
+		return
+	}
+	if !token.Allows(r.Method != http.MethodGet, kind == "tasks") {
+		writeError(rec, http.StatusForbidden, fmt.Errorf("token %q has %s scope", token.Name, token.Scope))
+		return
+	}
+
+	if kind == "tasks" {
+		s.serveTasks(rec, r, models.TaskID(id))
+	} else {
+		s.serveNotes(rec, r, models.NoteID(id))
+	}
+}
+
+// splitPath turns /api/tasks/<id> into "tasks" and "<id>".
+func splitPath(path string) (kind, id string) {
+	rest, ok := strings.CutPrefix(path, "/api/")
+	if !ok {
+		return "", ""
+	}
+	kind, id, _ = strings.Cut(strings.Trim(rest, "/"), "/")
+	return kind, id
+}
+
+func (s *Server) serveTasks(w http.ResponseWriter, r *http.Request, id models.TaskID) {
+	ctx := r.Context()
+	switch {
+	case r.Method == http.MethodGet && id == "":
+		tasks, err := s.storage.GetAllTasks(ctx)
+		writeResult(w, http.StatusOK, tasks, err)
+	case r.Method == http.MethodGet:
+		task, err := s.storage.GetTask(ctx, id)
+		writeResult(w, http.StatusOK, task, err)
+	case r.Method == http.MethodPost && id == "":
+		var task models.Task
+		if !readBody(w, r, &task) {
+			return
+		}
+		created := models.NewTask(task.Title, task.Description, task.DueDate)
+		task.ID, task.CreatedAt, task.UpdatedAt = created.ID, created.CreatedAt, created.UpdatedAt
+		if task.ReminderAt.IsZero() {
+			task.ReminderAt = created.ReminderAt
+		}
+		writeResult(w, http.StatusCreated, &task, s.storage.SaveTask(ctx, &task))
+	case r.Method == http.MethodPut && id != "":
+		existing, err := s.storage.GetTask(ctx, id)
+		if err != nil {
+			writeResult(w, 0, nil, err)
+			return
+		}
+		var task models.Task
+		if !readBody(w, r, &task) {
+			return
+		}
+		task.ID, task.CreatedAt, task.UpdatedAt = id, existing.CreatedAt, time.Now()
+		writeResult(w, http.StatusOK, &task, s.storage.SaveTask(ctx, &task))
+	case r.Method == http.MethodDelete && id != "":
+		writeResult(w, http.StatusNoContent, nil, s.storage.TrashTask(ctx, id))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s not supported here", r.Method))
+	}
+}
+
+func (s *Server) serveNotes(w http.ResponseWriter, r *http.Request, id models.NoteID) {
+	ctx := r.Context()
+	switch {
+	case r.Method == http.MethodGet && id == "":
+		notes, err := s.storage.GetAllNotes(ctx)
+		writeResult(w, http.StatusOK, notes, err)
+	case r.Method == http.MethodGet:
+		note, err := s.storage.GetNote(ctx, id)
+		writeResult(w, http.StatusOK, note, err)
+	case r.Method == http.MethodPost && id == "":
+		var note models.Note
+		if !readBody(w, r, &note) {
+			return
+		}
+		created := models.NewNote(note.Title, note.Content)
+		note.ID, note.CreatedAt, note.UpdatedAt = created.ID, created.CreatedAt, created.UpdatedAt
+		writeResult(w, http.StatusCreated, &note, s.storage.SaveNote(ctx, &note))
+	case r.Method == http.MethodPut && id != "":
+		existing, err := s.storage.GetNote(ctx, id)
+		if err != nil {
+			writeResult(w, 0, nil, err)
+			return
+		}
+		var note models.Note
+		if !readBody(w, r, &note) {
+			return
+		}
+		note.ID, note.CreatedAt, note.UpdatedAt = id, existing.CreatedAt, time.Now()
+		writeResult(w, http.StatusOK, &note, s.storage.SaveNote(ctx, &note))
+	case r.Method == http.MethodDelete && id != "":
+		writeResult(w, http.StatusNoContent, nil, s.storage.TrashNote(ctx, id))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("%s not supported here", r.Method))
+	}
+}
+
+func readBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid JSON body: %w", err))
+		return false
+	}
+	return true
+}
+
+func writeResult(w http.ResponseWriter, status int, v interface{}, err error) {
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, err)
+	case v == nil:
+		w.WriteHeader(status)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
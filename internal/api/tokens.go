@@ -0,0 +1,127 @@
+// Package api serves notes and tasks over HTTP to scripts and other tools,
+// authenticated by scoped tokens.
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// Scope limits what a token may do.
+type Scope string
+
+const (
+	// ScopeRead may only read notes and tasks.
+	ScopeRead Scope = "read"
+	// ScopeTasks may read everything and create, edit and delete tasks.
+	ScopeTasks Scope = "tasks"
+	// ScopeFull may do anything the API offers.
+	ScopeFull Scope = "full"
+)
+
+func ParseScope(s string) (Scope, error) {
+	switch scope := Scope(strings.ToLower(strings.TrimSpace(s))); scope {
+	case ScopeRead, ScopeTasks, ScopeFull:
+		return scope, nil
+	default:
+		return "", fmt.Errorf("unknown scope %q; use read, tasks or full", s)
+	}
+}
+
+// Token is a named API credential. Only a hash of the secret is stored.
+type Token struct {
+	Name      string    `json:"name"`
+	Hash      string    `json:"hash"`
+	Scope     Scope     `json:"scope"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Allows reports whether the token may make a request of the given kind:
+// write is false for reads, and tasks is true when only tasks are touched.
+func (t *Token) Allows(write, tasks bool) bool {
+	switch t.Scope {
+	case ScopeFull:
+		return true
+	case ScopeTasks:
+		return !write || tasks
+	default:
+		return !write
+	}
+}
+
+// tokenPrefix marks secrets so they are easy to spot in scripts and logs.
+const tokenPrefix = "nt_"
+
+// NewToken creates a token and returns it with its secret, which is shown
+// once and can't be recovered later.
+func NewToken(name string, scope Scope) (*Token, string) {
+	secret := tokenPrefix + models.RandomString(32)
+	return &Token{
+		Name:      name,
+		Hash:      hashSecret(secret),
+		Scope:     scope,
+		CreatedAt: time.Now(),
+	}, secret
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// TokensPath is where the tokens are kept.
+func TokensPath(dataDir string) string {
+	return filepath.Join(dataDir, "tokens.json")
+}
+
+// LoadTokens reads the token file; a missing file means no tokens.
+func LoadTokens(path string) ([]*Token, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tokens: %w", err)
+	}
+	var tokens []*Token
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to parse tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// SaveTokens writes the token file readable by the owner only.
+func SaveTokens(path string, tokens []*Token) error {
+	data, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tokens: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write tokens: %w", err)
+	}
+	return nil
+}
+
+// authenticate finds the token whose secret was presented.
+func authenticate(tokens []*Token, secret string) *Token {
+	if secret == "" {
+		return nil
+	}
+	hash := []byte(hashSecret(secret))
+	var found *Token
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare(hash, []byte(t.Hash)) == 1 {
+			found = t
+		}
+	}
+	return found
+}
@@ -0,0 +1,605 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+type Config struct {
+	// Data is the data directory to use on this device. It is only read
+	// from the local overlay, since the shared config lives in the data
+	// directory itself.
+	Data string `yaml:"data,omitempty"`
+
+	// Timezone is the IANA zone the app treats as local time. Empty means
+	// the system zone.
+	Timezone string `yaml:"timezone,omitempty"`
+
+	Transcription TranscriptionConfig `yaml:"transcription,omitempty"`
+	Review        ReviewConfig        `yaml:"review,omitempty"`
+	Notification  NotificationConfig  `yaml:"notification,omitempty"`
+	Planning      PlanningConfig      `yaml:"planning,omitempty"`
+	Capture       CaptureConfig       `yaml:"capture,omitempty"`
+	Tasks         TasksConfig         `yaml:"tasks,omitempty"`
+	Notes         NotesConfig         `yaml:"notes,omitempty"`
+	Reports       []ReportConfig      `yaml:"reports,omitempty"`
+	Scheduler     SchedulerConfig     `yaml:"scheduler,omitempty"`
+}
+
+type SchedulerConfig struct {
+	// OneShot makes `notes daemon` check reminders once and exit, for
+	// driving it from cron or anacron instead of keeping it running.
+	OneShot bool `yaml:"one_shot,omitempty"`
+}
+
+// ReportConfig posts a standup summary of completed, planned and overdue
+// tasks to a chat webhook on a schedule.
+type ReportConfig struct {
+	Name string `yaml:"name"`
+	// Webhook is a Slack or Discord incoming webhook URL.
+	Webhook string `yaml:"webhook"`
+	// At is the time of day to post, e.g. "09:00".
+	At string `yaml:"at"`
+	// Days are the days to post on, e.g. [mon, wed, fri]; empty means
+	// weekdays.
+	Days []string `yaml:"days,omitempty"`
+	// Filter is a smart-list query the tasks must match, e.g. "tag=work".
+	Filter string `yaml:"filter,omitempty"`
+	// Project limits the report to one project, by name.
+	Project string `yaml:"project,omitempty"`
+	// Template is a Go text/template; see report.DefaultTemplate.
+	Template string `yaml:"template,omitempty"`
+}
+
+type TasksConfig struct {
+	// Subtasks is the default completion policy for tasks with a
+	// checklist: manual, auto (complete with the last subtask) or block
+	// (refuse completion while subtasks are open).
+	Subtasks string `yaml:"subtasks,omitempty"`
+	// Statuses replaces the pending, in progress, completed cycle with
+	// an ordered list that may include custom statuses.
+	Statuses []StatusConfig `yaml:"statuses,omitempty"`
+	// UpcomingDays is how many days past today the Upcoming view
+	// covers; 0 means 7.
+	UpcomingDays int `yaml:"upcoming_days,omitempty"`
+	// DueTime is the time of day, as HH:MM, that a task given only a
+	// due date falls due; blank means midnight.
+	DueTime string `yaml:"due_time,omitempty"`
+	// Sort is the order of the task list: created, due, priority,
+	// urgency or title; blank means created. The app saves it when the
+	// order is changed.
+	Sort string `yaml:"sort,omitempty"`
+}
+
+type NotesConfig struct {
+	// Sort is the order of the note list: created, updated or title;
+	// blank means created. The app saves it when the order is changed.
+	Sort string `yaml:"sort,omitempty"`
+}
+
+type StatusConfig struct {
+	Name string `yaml:"name"`
+	// Glyph is shown before the task title, e.g. "⛔".
+	Glyph string `yaml:"glyph,omitempty"`
+	// Color is a terminal color number or hex code for the glyph.
+	Color string `yaml:"color,omitempty"`
+	// Base is the built-in status a custom status behaves as: pending,
+	// in progress, completed or cancelled.
+	Base string `yaml:"base,omitempty"`
+}
+
+// Workflow builds the configured status cycle.
+func (c TasksConfig) Workflow() (models.Workflow, error) {
+	defs := make([]models.StatusDef, 0, len(c.Statuses))
+	for _, sc := range c.Statuses {
+		def, err := models.NewStatusDef(sc.Name, sc.Glyph, sc.Color, sc.Base)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, def)
+	}
+	return models.NewWorkflow(defs)
+}
+
+type CaptureConfig struct {
+	// Sources routes items captured by each integration, keyed by source
+	// name such as "cli" or "email".
+	Sources map[string]SourceConfig `yaml:"sources,omitempty"`
+}
+
+type SourceConfig struct {
+	// Tags are added to every item captured from the source.
+	Tags []string `yaml:"tags,omitempty"`
+	// Project is the name of the project items captured from the source
+	// are filed under, unless they name one themselves.
+	Project string `yaml:"project,omitempty"`
+}
+
+// Route returns the routing for a source; unknown sources get none.
+func (c CaptureConfig) Route(source string) SourceConfig {
+	return c.Sources[source]
+}
+
+type PlanningConfig struct {
+	// DailyCapacity is how many open tasks may be due on one day before
+	// the planner moves on to the next.
+	DailyCapacity int `yaml:"daily_capacity,omitempty"`
+}
+
+type NotificationConfig struct {
+	// Channels are the channels reminders are delivered through, all at
+	// once: console, desktop, tmux or the name of a configured notifier.
+	// Empty means desktop when it is enabled, otherwise console.
+	Channels []string `yaml:"channels,omitempty"`
+	// Routes send reminders for some priorities through other channels
+	// instead; the first matching route wins.
+	Routes  []RouteConfig `yaml:"routes,omitempty"`
+	Console ConsoleConfig `yaml:"console,omitempty"`
+	Desktop DesktopConfig `yaml:"desktop,omitempty"`
+	// Slack posts reminders to incoming webhooks, one entry per
+	// workspace or channel.
+	Slack []SlackConfig `yaml:"slack,omitempty"`
+	// Ntfy publishes reminders to ntfy topics.
+	Ntfy []NtfyConfig `yaml:"ntfy,omitempty"`
+	// Webhook posts reminders as JSON to other HTTP endpoints.
+	Webhook []WebhookConfig `yaml:"webhook,omitempty"`
+	// Email mails reminders through an SMTP server.
+	Email []EmailConfig `yaml:"email,omitempty"`
+	// Tmux tunes the tmux channel.
+	Tmux TmuxConfig `yaml:"tmux,omitempty"`
+	// Priorities overrides the alert style per task priority, keyed by
+	// "low", "medium" or "high".
+	Priorities map[string]AlertConfig `yaml:"priorities,omitempty"`
+	// Snooze is how long the Snooze button on a reminder postpones it,
+	// e.g. "15m"; empty means an hour.
+	Snooze string `yaml:"snooze,omitempty"`
+	// OverdueDigest is how often to send one digest listing every overdue
+	// task, e.g. "24h"; empty sends none.
+	OverdueDigest string `yaml:"overdue_digest,omitempty"`
+	// Escalation holds the policies deciding how often a reminder repeats
+	// until acknowledged, by name. "default" applies to tasks that don't
+	// pick one.
+	Escalation map[string]EscalationConfig `yaml:"escalation,omitempty"`
+	// Retry decides how a channel that failed to deliver a reminder is
+	// tried again before the reminder is given up on.
+	Retry RetryConfig `yaml:"retry,omitempty"`
+	// Batch coalesces reminders falling within this window of each other,
+	// e.g. "5m", into one notification; empty sends each on its own.
+	Batch string `yaml:"batch,omitempty"`
+}
+
+// RetryConfig tunes retries of failed deliveries. Fields left empty keep
+// reminder.DefaultRetry.
+type RetryConfig struct {
+	// Attempts is how many times to retry.
+	Attempts int `yaml:"attempts,omitempty"`
+	// Backoff is the wait before the first retry, e.g. "1m", doubling
+	// after each; "off" doesn't retry at all.
+	Backoff string `yaml:"backoff,omitempty"`
+	// MaxBackoff caps the wait between retries.
+	MaxBackoff string `yaml:"max_backoff,omitempty"`
+}
+
+// Policy builds the retry policy; ok is false when retries are off.
+func (r RetryConfig) Policy() (policy reminder.RetryPolicy, ok bool, err error) {
+	policy = reminder.DefaultRetry
+	if r.Attempts < 0 {
+		return policy, false, fmt.Errorf("attempts: must not be negative")
+	}
+	if r.Attempts > 0 {
+		policy.Attempts = r.Attempts
+	}
+	if policy.Backoff, err = parseRepeat(r.Backoff, policy.Backoff); err != nil {
+		return policy, false, fmt.Errorf("backoff: %w", err)
+	}
+	if policy.MaxBackoff, err = parseRepeat(r.MaxBackoff, policy.MaxBackoff); err != nil {
+		return policy, false, fmt.Errorf("max_backoff: %w", err)
+	}
+	return policy, policy.Backoff > 0, nil
+}
+
+// EscalationConfig is a repeat policy. Durations look like "30m" or "6h";
+// "off" stops repeating, and fields left empty keep the built-in policy.
+type EscalationConfig struct {
+	// Every is how often the reminder repeats while the due time is far
+	// off.
+	Every string `yaml:"every,omitempty"`
+	// Before maps time left until due to a shorter interval, e.g.
+	// {"24h": "2h", "1h": "15m"}.
+	Before map[string]string `yaml:"before,omitempty"`
+	// Overdue is how often an overdue task is nagged.
+	Overdue string `yaml:"overdue,omitempty"`
+}
+
+// Escalation builds the policy, starting from reminder.DefaultEscalation.
+func (e EscalationConfig) Escalation() (reminder.Escalation, error) {
+	policy := reminder.DefaultEscalation
+	var err error
+	if policy.Every, err = parseRepeat(e.Every, policy.Every); err != nil {
+		return policy, fmt.Errorf("every: %w", err)
+	}
+	if policy.Overdue, err = parseRepeat(e.Overdue, policy.Overdue); err != nil {
+		return policy, fmt.Errorf("overdue: %w", err)
+	}
+	if e.Before != nil {
+		policy.Before = nil
+		for within, every := range e.Before {
+			step := reminder.EscalationStep{}
+			if step.Within, err = time.ParseDuration(within); err != nil || step.Within <= 0 {
+				return policy, fmt.Errorf("before: %q isn't a duration such as \"1h\"", within)
+			}
+			if step.Every, err = parseRepeat(every, 0); err != nil {
+				return policy, fmt.Errorf("before.%s: %w", within, err)
+			}
+			policy.Before = append(policy.Before, step)
+		}
+	}
+	return policy, nil
+}
+
+// parseRepeat reads a repeat interval: "off" is 0 and empty keeps def.
+func parseRepeat(s string, def time.Duration) (time.Duration, error) {
+	switch s {
+	case "":
+		return def, nil
+	case "off":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%q isn't a duration such as \"30m\" or off", s)
+	}
+	return d, nil
+}
+
+// EscalationPolicies builds every configured escalation policy.
+func (n NotificationConfig) EscalationPolicies() (reminder.EscalationPolicies, error) {
+	policies := make(reminder.EscalationPolicies, len(n.Escalation))
+	for name, ec := range n.Escalation {
+		policy, err := ec.Escalation()
+		if err != nil {
+			return nil, fmt.Errorf("notification.escalation.%s.%w", name, err)
+		}
+		policies[name] = policy
+	}
+	return policies, nil
+}
+
+// RouteConfig sends reminders for tasks of the listed priorities or tags
+// through its channels, e.g. high → [desktop, ntfy] or #work → [slack].
+// A route listing both needs a task to match both.
+type RouteConfig struct {
+	Priorities []string `yaml:"priorities,omitempty"`
+	// Tags may be written with or without the leading #.
+	Tags     []string `yaml:"tags,omitempty"`
+	Channels []string `yaml:"channels"`
+}
+
+// ConsoleConfig tunes the console channel. Its Template, like the other
+// channels', is a Go template rendered with a reminder.Message, e.g.
+// `{{.Title}} due {{.DueDate | humanize}} [{{.Priority}}]`; empty keeps
+// the built-in wording.
+type ConsoleConfig struct {
+	Template string `yaml:"template,omitempty"`
+}
+
+type DesktopConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Template renders the notification body; the title stays the task.
+	Template string `yaml:"template,omitempty"`
+}
+
+type SlackConfig struct {
+	// Name is the channel name to pick this webhook by; defaults to
+	// "slack".
+	Name    string `yaml:"name,omitempty"`
+	Webhook string `yaml:"webhook"`
+	// Template is the message; see reminder.DefaultSlackTemplate.
+	Template string `yaml:"template,omitempty"`
+}
+
+// ChannelName is the name the webhook is picked by.
+func (s SlackConfig) ChannelName() string {
+	if s.Name == "" {
+		return "slack"
+	}
+	return s.Name
+}
+
+type TmuxConfig struct {
+	// Duration is how long the message is displayed, e.g. "5s"; empty
+	// uses tmux's display-time.
+	Duration string `yaml:"duration,omitempty"`
+	// StatusOption is a global user option such as "@reminder" set to the
+	// latest reminder, for showing it in status-right as #{@reminder}.
+	StatusOption string `yaml:"status_option,omitempty"`
+	Template     string `yaml:"template,omitempty"`
+}
+
+type NtfyConfig struct {
+	// Name is the channel name to pick this topic by; defaults to "ntfy".
+	Name string `yaml:"name,omitempty"`
+	// Server is the ntfy server; defaults to https://ntfy.sh.
+	Server string `yaml:"server,omitempty"`
+	Topic  string `yaml:"topic"`
+	// Token is an access token for protected topics.
+	Token string `yaml:"token,omitempty"`
+	// Priorities maps "low", "medium" and "high" to an ntfy priority:
+	// min, low, default, high, urgent or 1-5.
+	Priorities map[string]string `yaml:"priorities,omitempty"`
+	// Template renders the message body; the title stays the task.
+	Template string `yaml:"template,omitempty"`
+}
+
+// ChannelName is the name the topic is picked by.
+func (n NtfyConfig) ChannelName() string {
+	if n.Name == "" {
+		return "ntfy"
+	}
+	return n.Name
+}
+
+type WebhookConfig struct {
+	// Name is the channel name to pick this endpoint by; defaults to
+	// "webhook".
+	Name string `yaml:"name,omitempty"`
+	URL  string `yaml:"url"`
+	// Template renders the message field; the title stays the task.
+	Template string `yaml:"template,omitempty"`
+}
+
+// ChannelName is the name the endpoint is picked by.
+func (w WebhookConfig) ChannelName() string {
+	if w.Name == "" {
+		return "webhook"
+	}
+	return w.Name
+}
+
+type EmailConfig struct {
+	// Name is the channel name to pick this mailbox by; defaults to
+	// "email".
+	Name string `yaml:"name,omitempty"`
+	// Server is the SMTP server as host:port, e.g. "smtp.example.com:587".
+	Server string `yaml:"server"`
+	// Username and Password log in to the server; leave them empty for
+	// servers that don't need it.
+	Username string   `yaml:"username,omitempty"`
+	Password string   `yaml:"password,omitempty"`
+	From     string   `yaml:"from"`
+	To       []string `yaml:"to"`
+	// Template renders the body; the subject stays the task.
+	Template string `yaml:"template,omitempty"`
+}
+
+// ChannelName is the name the mailbox is picked by.
+func (e EmailConfig) ChannelName() string {
+	if e.Name == "" {
+		return "email"
+	}
+	return e.Name
+}
+
+// ChannelNames lists every channel the config makes available.
+func (n NotificationConfig) ChannelNames() []string {
+	names := []string{"console", "desktop", "tmux"}
+	for _, s := range n.Slack {
+		names = append(names, s.ChannelName())
+	}
+	for _, nc := range n.Ntfy {
+		names = append(names, nc.ChannelName())
+	}
+	for _, w := range n.Webhook {
+		names = append(names, w.ChannelName())
+	}
+	for _, e := range n.Email {
+		names = append(names, e.ChannelName())
+	}
+	return names
+}
+
+type AlertConfig struct {
+	// Urgency is one of low, normal or critical.
+	Urgency string `yaml:"urgency,omitempty"`
+	// Sound is a sound theme name or file path; "none" silences it.
+	Sound string `yaml:"sound,omitempty"`
+}
+
+type TranscriptionConfig struct {
+	// Command is run for each attached audio file; "{file}" is replaced
+	// with the file path and the transcript is read from stdout, e.g.
+	// "whisper-cli -nt -m ggml-base.en.bin -f {file}".
+	Command string `yaml:"command,omitempty"`
+}
+
+func Default() *Config {
+	return &Config{}
+}
+
+func DefaultPath(dataDir string) string {
+	return filepath.Join(dataDir, "config.yaml")
+}
+
+// LocalName is the device-local overlay's file name in the home
+// directory.
+const LocalName = ".notes-cli.local.yaml"
+
+// LocalPath is the device-local overlay. It sits outside the data
+// directory so it isn't synced along with the shared config, and holds
+// machine-specific settings such as notification channels or the data
+// directory.
+func LocalPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, LocalName), nil
+}
+
+// LocalDataDir returns the data directory set in the overlay at path, or
+// "" when there is none. It is read before anything else, since the
+// shared config is found in the data directory.
+func LocalDataDir(path string) (string, error) {
+	data, err := readOptional(path)
+	if err != nil || data == nil {
+		return "", err
+	}
+	var overlay struct {
+		Data string `yaml:"data"`
+	}
+	if err := yaml.Unmarshal(data, &overlay); err != nil {
+		return "", fmt.Errorf("failed to parse local overlay: %w", err)
+	}
+	if strings.HasPrefix(overlay.Data, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, overlay.Data[2:]), nil
+		}
+	}
+	return overlay.Data, nil
+}
+
+// Load reads the config file, returning the defaults when it doesn't exist.
+// Unknown keys and invalid values are reported as a *ValidationError rather
+// than ignored.
+func Load(path string) (*Config, error) {
+	return LoadLayered(path, "")
+}
+
+// LoadLayered is Load with the device-local overlay at localPath layered
+// over the shared config. Either file may be missing; an empty localPath
+// skips the overlay.
+func LoadLayered(path, localPath string) (*Config, error) {
+	shared, err := readOptional(path)
+	if err != nil {
+		return nil, err
+	}
+	var local []byte
+	if localPath != "" {
+		if local, err = readOptional(localPath); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg, problems, err := CheckLayered(shared, local)
+	if err != nil {
+		return nil, err
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// readOptional reads a config file, returning nil when it doesn't exist.
+func readOptional(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return data, nil
+}
+
+// setMu serializes SetValue's read-modify-write of the config files.
+var setMu sync.Mutex
+
+// SetValue sets the dotted key, e.g. "tasks.sort", to value in the config
+// file at path, creating the file and any missing sections. The file is
+// edited in place, so its comments and key order are kept.
+func SetValue(path, key, value string) error {
+	setMu.Lock()
+	defer setMu.Unlock()
+
+	data, err := readOptional(path)
+	if err != nil {
+		return err
+	}
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(root.Content) == 0 {
+		root = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	node := root.Content[0]
+	names := strings.Split(key, ".")
+	for i, name := range names {
+		if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+			// An empty section, e.g. "tasks:" with nothing under it
+			*node = yaml.Node{Kind: yaml.MappingNode}
+		}
+		if node.Kind != yaml.MappingNode {
+			return fmt.Errorf("failed to set %s: %s is not a section", key, strings.Join(names[:i], "."))
+		}
+		node = mappingValue(node, name)
+	}
+	*node = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, LineComment: node.LineComment}
+
+	var b bytes.Buffer
+	enc := yaml.NewEncoder(&b)
+	enc.SetIndent(2)
+	if err := enc.Encode(&root); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := storage.WriteFileAtomic(path, b.Bytes()); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// mappingValue returns the value under key in a mapping node, adding an
+// empty one when the key is missing.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	return value
+}
+
+// Location resolves the configured timezone, falling back to time.Local.
+func (c *Config) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", c.Timezone, err)
+	}
+	return loc, nil
+}
+
+type ReviewConfig struct {
+	// AutoAccept lists import/sync sources whose changes are applied
+	// directly instead of being staged for review.
+	AutoAccept []string `yaml:"auto_accept,omitempty"`
+}
+
+func (r ReviewConfig) Trusted(source string) bool {
+	for _, s := range r.AutoAccept {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
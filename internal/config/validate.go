@@ -0,0 +1,610 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+	"github.com/san-kum/reminder-tui/internal/report"
+)
+
+// Problem is one mistake found in a config file.
+type Problem struct {
+	// Path is the dotted key the problem is about, e.g. "notification.desktop".
+	Path string
+	// Line is the line in the file, or 0 when unknown.
+	Line    int
+	Message string
+	// Local is set for problems in the device-local overlay.
+	Local bool
+}
+
+func (p Problem) String() string {
+	where := ""
+	if p.Local {
+		where = "local overlay, "
+	}
+	if p.Line > 0 {
+		return fmt.Sprintf("%sline %d: %s: %s", where, p.Line, p.Path, p.Message)
+	}
+	return fmt.Sprintf("%s%s: %s", where, p.Path, p.Message)
+}
+
+// ValidationError is returned by Load when the file parses but contains
+// unknown keys or invalid values.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		lines[i] = "  " + p.String()
+	}
+	return fmt.Sprintf("%d problem(s) in config:\n%s\nrun 'notes config doctor' for details",
+		len(e.Problems), strings.Join(lines, "\n"))
+}
+
+// Check parses config file contents and reports every unknown key and
+// invalid value. It only returns an error when the YAML itself is malformed.
+func Check(data []byte) (*Config, []Problem, error) {
+	return CheckLayered(data, nil)
+}
+
+// CheckLayered is Check for the shared config with the device-local
+// overlay decoded over it: keys set in the overlay win, nested sections
+// are merged and lists are replaced whole. Problems in the overlay are
+// marked Local.
+func CheckLayered(shared, local []byte) (*Config, []Problem, error) {
+	cfg := Default()
+
+	sharedDoc, err := parseDoc(shared)
+	if err != nil {
+		return nil, nil, err
+	}
+	localDoc, err := parseDoc(local)
+	if err != nil {
+		return nil, nil, fmt.Errorf("local overlay: %w", err)
+	}
+
+	var problems []Problem
+	if sharedDoc != nil {
+		if problems, err = decodeDoc(sharedDoc, cfg, false); err != nil {
+			return nil, nil, err
+		}
+		if cfg.Data != "" {
+			problems = append(problems, Problem{
+				Path:    "data",
+				Line:    lineOf(sharedDoc, "data"),
+				Message: "the data directory can only be set in the local overlay, " + LocalName,
+			})
+		}
+	}
+	if localDoc != nil {
+		localProblems, err := decodeDoc(localDoc, cfg, true)
+		if err != nil {
+			return nil, nil, fmt.Errorf("local overlay: %w", err)
+		}
+		problems = append(problems, localProblems...)
+	}
+
+	for _, p := range cfg.Validate() {
+		// Blame the overlay when it set the value
+		if definedIn(localDoc, p.Path) {
+			p.Line, p.Local = lineOf(localDoc, p.Path), true
+		} else {
+			p.Line = lineOf(sharedDoc, p.Path)
+		}
+		problems = append(problems, p)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Local != problems[j].Local {
+			return !problems[i].Local
+		}
+		return problems[i].Line < problems[j].Line
+	})
+	return cfg, problems, nil
+}
+
+// parseDoc returns the top-level mapping of a config file, or nil when it
+// is empty.
+func parseDoc(data []byte) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, errors.New("failed to parse config file: top level must be a mapping")
+	}
+	return doc, nil
+}
+
+// decodeDoc decodes doc over cfg and reports its unknown keys and
+// mistyped values.
+func decodeDoc(doc *yaml.Node, cfg *Config, local bool) ([]Problem, error) {
+	var problems []Problem
+	unknownKeys(doc, reflect.TypeOf(Config{}), "", &problems)
+
+	if err := doc.Decode(cfg); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("failed to parse config file: %w", err)
+		}
+		for _, msg := range typeErr.Errors {
+			p := Problem{Path: "config", Message: msg}
+			if n, _ := fmt.Sscanf(msg, "line %d:", &p.Line); n == 1 {
+				if i := strings.Index(msg, ": "); i >= 0 {
+					p.Message = msg[i+2:]
+				}
+				if path := pathAtLine(doc, p.Line, ""); path != "" {
+					p.Path = path
+				}
+			}
+			problems = append(problems, p)
+		}
+	}
+
+	for i := range problems {
+		problems[i].Local = local
+	}
+	return problems, nil
+}
+
+// Validate checks values that parse but make no sense.
+func (c *Config) Validate() []Problem {
+	var problems []Problem
+	add := func(path, format string, args ...interface{}) {
+		problems = append(problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			add("timezone", "unknown zone %q; use an IANA name such as \"Europe/Berlin\"", c.Timezone)
+		}
+	}
+
+	if c.Planning.DailyCapacity < 0 {
+		add("planning.daily_capacity", "must not be negative")
+	}
+
+	if c.Tasks.UpcomingDays < 0 {
+		add("tasks.upcoming_days", "must not be negative")
+	}
+
+	if _, err := models.ParseTimeOfDay(c.Tasks.DueTime); err != nil {
+		add("tasks.due_time", "%q isn't a time of day; use HH:MM such as \"09:00\"", c.Tasks.DueTime)
+	}
+
+	if _, err := models.ParseTaskSort(c.Tasks.Sort); err != nil {
+		add("tasks.sort", "unknown order %q; use created, due, priority, urgency or title", c.Tasks.Sort)
+	}
+
+	if _, err := models.ParseNoteSort(c.Notes.Sort); err != nil {
+		add("notes.sort", "unknown order %q; use created, updated or title", c.Notes.Sort)
+	}
+
+	if _, err := models.ParseSubtaskPolicy(c.Tasks.Subtasks); err != nil {
+		add("tasks.subtasks", "unknown policy %q; use manual, auto or block", c.Tasks.Subtasks)
+	}
+
+	validStatuses := true
+	for i, sc := range c.Tasks.Statuses {
+		if _, err := models.NewStatusDef(sc.Name, sc.Glyph, sc.Color, sc.Base); err != nil {
+			add(fmt.Sprintf("tasks.statuses.%d", i), "%v", err)
+			validStatuses = false
+		}
+	}
+	workflow, err := c.Tasks.Workflow()
+	if err != nil {
+		if validStatuses {
+			add("tasks.statuses", "%v", err)
+		}
+		workflow = models.BuiltinWorkflow()
+	}
+
+	names := make([]string, 0, len(c.Notification.Priorities))
+	for name := range c.Notification.Priorities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := "notification.priorities." + name
+		if _, err := models.ParsePriority(name); err != nil {
+			add(path, "unknown priority; use low, medium or high")
+		}
+		switch urgency := c.Notification.Priorities[name].Urgency; urgency {
+		case "", "low", "normal", "critical":
+		default:
+			add(path+".urgency", "unknown urgency %q; use low, normal or critical", urgency)
+		}
+	}
+
+	policies := make([]string, 0, len(c.Notification.Escalation))
+	for name := range c.Notification.Escalation {
+		policies = append(policies, name)
+	}
+	sort.Strings(policies)
+	for _, name := range policies {
+		if _, err := c.Notification.Escalation[name].Escalation(); err != nil {
+			add("notification.escalation."+name, "%v", err)
+		}
+	}
+	if _, _, err := c.Notification.Retry.Policy(); err != nil {
+		add("notification.retry", "%v", err)
+	}
+	if d := c.Notification.OverdueDigest; d != "" {
+		if parsed, err := time.ParseDuration(d); err != nil || parsed <= 0 {
+			add("notification.overdue_digest", "must be a duration such as \"24h\"")
+		}
+	}
+	if d := c.Notification.Batch; d != "" {
+		if parsed, err := time.ParseDuration(d); err != nil || parsed <= 0 {
+			add("notification.batch", "must be a duration such as \"5m\"")
+		}
+	}
+	if d := c.Notification.Snooze; d != "" {
+		if parsed, err := time.ParseDuration(d); err != nil || parsed <= 0 {
+			add("notification.snooze", "must be a duration such as \"15m\"")
+		}
+	}
+	if d := c.Notification.Tmux.Duration; d != "" {
+		if parsed, err := time.ParseDuration(d); err != nil || parsed <= 0 {
+			add("notification.tmux.duration", "must be a duration such as \"5s\"")
+		}
+	}
+	if opt := c.Notification.Tmux.StatusOption; opt != "" && !strings.HasPrefix(opt, "@") {
+		add("notification.tmux.status_option", "user options start with @, e.g. \"@reminder\"")
+	}
+
+	for _, t := range []struct{ channel, text string }{
+		{"console", c.Notification.Console.Template},
+		{"desktop", c.Notification.Desktop.Template},
+		{"tmux", c.Notification.Tmux.Template},
+	} {
+		if _, err := reminder.ParseMessageTemplate(t.channel, t.text); err != nil {
+			add("notification."+t.channel+".template", "%v", err)
+		}
+	}
+
+	channels := map[string]bool{"console": true, "desktop": true, "tmux": true}
+	for i, s := range c.Notification.Slack {
+		path := fmt.Sprintf("notification.slack.%d", i)
+		if channels[s.ChannelName()] {
+			add(path+".name", "channel name %q is already taken", s.ChannelName())
+		}
+		channels[s.ChannelName()] = true
+		if u, err := url.Parse(s.Webhook); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			add(path+".webhook", "must be an http(s) URL")
+		}
+		if _, err := reminder.ParseSlackTemplate(s.Template); err != nil {
+			add(path+".template", "%v", err)
+		}
+	}
+	for i, nc := range c.Notification.Ntfy {
+		path := fmt.Sprintf("notification.ntfy.%d", i)
+		if channels[nc.ChannelName()] {
+			add(path+".name", "channel name %q is already taken", nc.ChannelName())
+		}
+		channels[nc.ChannelName()] = true
+		if nc.Server != "" {
+			if u, err := url.Parse(nc.Server); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				add(path+".server", "must be an http(s) URL")
+			}
+		}
+		if strings.TrimSpace(nc.Topic) == "" || strings.Contains(nc.Topic, "/") {
+			add(path+".topic", "must be a topic name such as \"my-reminders\"")
+		}
+		if _, err := reminder.ParseMessageTemplate("ntfy", nc.Template); err != nil {
+			add(path+".template", "%v", err)
+		}
+		priorities := make([]string, 0, len(nc.Priorities))
+		for name := range nc.Priorities {
+			priorities = append(priorities, name)
+		}
+		sort.Strings(priorities)
+		for _, name := range priorities {
+			value := nc.Priorities[name]
+			if _, err := models.ParsePriority(name); err != nil {
+				add(path+".priorities."+name, "unknown priority; use low, medium or high")
+			}
+			if _, err := reminder.ParseNtfyPriority(value); err != nil {
+				add(path+".priorities."+name, "%v", err)
+			}
+		}
+	}
+	for i, w := range c.Notification.Webhook {
+		path := fmt.Sprintf("notification.webhook.%d", i)
+		if channels[w.ChannelName()] {
+			add(path+".name", "channel name %q is already taken", w.ChannelName())
+		}
+		channels[w.ChannelName()] = true
+		if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			add(path+".url", "must be an http(s) URL")
+		}
+		if _, err := reminder.ParseMessageTemplate("webhook", w.Template); err != nil {
+			add(path+".template", "%v", err)
+		}
+	}
+	for i, e := range c.Notification.Email {
+		path := fmt.Sprintf("notification.email.%d", i)
+		if channels[e.ChannelName()] {
+			add(path+".name", "channel name %q is already taken", e.ChannelName())
+		}
+		channels[e.ChannelName()] = true
+		if _, port, err := net.SplitHostPort(e.Server); err != nil || port == "" {
+			add(path+".server", "must be host:port, e.g. \"smtp.example.com:587\"")
+		}
+		if _, err := mail.ParseAddress(e.From); err != nil {
+			add(path+".from", "must be an email address")
+		}
+		if len(e.To) == 0 {
+			add(path+".to", "needs at least one address")
+		}
+		for j, to := range e.To {
+			if _, err := mail.ParseAddress(to); err != nil {
+				add(fmt.Sprintf("%s.to.%d", path, j), "must be an email address")
+			}
+		}
+		if _, err := reminder.ParseMessageTemplate("email", e.Template); err != nil {
+			add(path+".template", "%v", err)
+		}
+	}
+	checkChannels := func(path string, names []string) {
+		for i, name := range names {
+			if !channels[name] {
+				add(fmt.Sprintf("%s.%d", path, i), "unknown channel %q; use one of %s", name, strings.Join(c.Notification.ChannelNames(), ", "))
+			}
+		}
+	}
+	checkChannels("notification.channels", c.Notification.Channels)
+	for i, route := range c.Notification.Routes {
+		path := fmt.Sprintf("notification.routes.%d", i)
+		if len(route.Priorities) == 0 && len(route.Tags) == 0 {
+			add(path, "list the priorities or tags the route is for")
+		}
+		for j, tag := range route.Tags {
+			if strings.TrimPrefix(tag, "#") == "" {
+				add(fmt.Sprintf("%s.tags.%d", path, j), "tag is empty")
+			}
+		}
+		for j, name := range route.Priorities {
+			if _, err := models.ParsePriority(name); err != nil {
+				add(fmt.Sprintf("%s.priorities.%d", path, j), "unknown priority %q; use low, medium or high", name)
+			}
+		}
+		if len(route.Channels) == 0 {
+			add(path+".channels", "list the channels to deliver through")
+		}
+		checkChannels(path+".channels", route.Channels)
+	}
+
+	seen := make(map[string]bool)
+	for i, r := range c.Reports {
+		path := fmt.Sprintf("reports.%d", i)
+		switch {
+		case strings.TrimSpace(r.Name) == "":
+			add(path+".name", "report name is empty")
+		case seen[r.Name]:
+			add(path+".name", "duplicate report name %q", r.Name)
+		}
+		seen[r.Name] = true
+		if u, err := url.Parse(r.Webhook); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			add(path+".webhook", "must be an http(s) URL")
+		}
+		if _, err := report.ParseSchedule(r.At, r.Days); err != nil {
+			add(path, "%v", err)
+		}
+		if _, err := workflow.ParseFilter(r.Filter); err != nil {
+			add(path+".filter", "%v", err)
+		}
+		if _, err := report.ParseTemplate(r.Template); err != nil {
+			add(path+".template", "%v", err)
+		}
+	}
+
+	for i, source := range c.Review.AutoAccept {
+		if strings.TrimSpace(source) == "" {
+			add(fmt.Sprintf("review.auto_accept.%d", i), "source name is empty")
+		}
+	}
+
+	return problems
+}
+
+// unknownKeys walks a mapping node alongside the struct it decodes into and
+// records keys that have no matching field.
+func unknownKeys(node *yaml.Node, t reflect.Type, prefix string, problems *[]Problem) {
+	switch t.Kind() {
+	case reflect.Ptr:
+		unknownKeys(node, t.Elem(), prefix, problems)
+		return
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			unknownKeys(node.Content[i+1], t.Elem(), prefix+"."+node.Content[i].Value, problems)
+		}
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	fields := yamlFields(t)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		path := strings.TrimPrefix(prefix+"."+key.Value, ".")
+
+		field, ok := fields[key.Value]
+		if !ok {
+			msg := "unknown key"
+			if guess := closest(key.Value, fields); guess != "" {
+				msg += fmt.Sprintf("; did you mean %q?", guess)
+			}
+			*problems = append(*problems, Problem{Path: path, Line: key.Line, Message: msg})
+			continue
+		}
+		unknownKeys(node.Content[i+1], field.Type, path, problems)
+	}
+}
+
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f
+	}
+	return fields
+}
+
+// closest returns the known key nearest to a misspelt one, or "" when
+// nothing is close enough to be a likely typo.
+func closest(key string, fields map[string]reflect.StructField) string {
+	best, bestDist := "", 3
+	for name := range fields {
+		if d := editDistance(key, name); d < bestDist || (d == bestDist && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// pathAtLine returns the dotted key whose value sits on the given line.
+func pathAtLine(node *yaml.Node, line int, prefix string) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		path := strings.TrimPrefix(prefix+"."+node.Content[i].Value, ".")
+		if node.Content[i].Line == line {
+			return path
+		}
+		if found := pathAtLine(node.Content[i+1], line, path); found != "" {
+			return found
+		}
+	}
+	return ""
+}
+
+// lineOf finds the line of a dotted key in a mapping node, falling back to
+// the nearest ancestor that exists. Numeric keys index into sequences.
+func lineOf(node *yaml.Node, path string) int {
+	line := 0
+	if node == nil {
+		return 0
+	}
+	for _, key := range strings.Split(path, ".") {
+		if node.Kind == yaml.SequenceNode {
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				break
+			}
+			node = node.Content[i]
+			line = node.Line
+			continue
+		}
+		if node.Kind != yaml.MappingNode {
+			break
+		}
+		found := false
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				line = node.Content[i].Line
+				node = node.Content[i+1]
+				found = true
+				break
+			}
+		}
+		if !found {
+			break
+		}
+	}
+	return line
+}
+
+// definedIn reports whether the file sets the key at path itself, rather
+// than only one of its parents.
+func definedIn(node *yaml.Node, path string) bool {
+	if node == nil {
+		return false
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch node.Kind {
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				return false
+			}
+			node = node.Content[i]
+		case yaml.MappingNode:
+			found := false
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					node = node.Content[i+1]
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
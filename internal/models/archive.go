@@ -0,0 +1,45 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrArchiveOpen is returned when archiving a task that still needs doing.
+var ErrArchiveOpen = errors.New("only completed or cancelled tasks can be archived")
+
+// Archive moves a finished task out of the task list into the archive.
+func (t *Task) Archive() error {
+	if t.IsOpen() {
+		return ErrArchiveOpen
+	}
+	t.ArchivedAt = time.Now()
+	t.UpdatedAt = t.ArchivedAt
+	return nil
+}
+
+// Unarchive returns the task to the task list.
+func (t *Task) Unarchive() {
+	t.ArchivedAt = time.Time{}
+	t.UpdatedAt = time.Now()
+}
+
+func (t *Task) IsArchived() bool {
+	return !t.ArchivedAt.IsZero()
+}
+
+// Archive moves the note out of the note list into the archive.
+func (n *Note) Archive() {
+	n.ArchivedAt = time.Now()
+	n.UpdatedAt = n.ArchivedAt
+}
+
+// Unarchive returns the note to the note list.
+func (n *Note) Unarchive() {
+	n.ArchivedAt = time.Time{}
+	n.UpdatedAt = time.Now()
+}
+
+func (n *Note) IsArchived() bool {
+	return !n.ArchivedAt.IsZero()
+}
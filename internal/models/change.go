@@ -0,0 +1,148 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ChangeID string
+
+// Change is an incoming version of a note or task from an import or sync
+// source, held for review before it overwrites local data.
+type Change struct {
+	ID         ChangeID  `json:"id"`
+	Source     string    `json:"source"`
+	ReceivedAt time.Time `json:"received_at"`
+	Note       *Note     `json:"note,omitempty"`
+	Task       *Task     `json:"task,omitempty"`
+}
+
+func NewNoteChange(source string, note *Note) *Change {
+	return &Change{
+		ID:         ChangeID(GenerateUniqueID()),
+		Source:     source,
+		ReceivedAt: time.Now(),
+		Note:       note,
+	}
+}
+
+func NewTaskChange(source string, task *Task) *Change {
+	return &Change{
+		ID:         ChangeID(GenerateUniqueID()),
+		Source:     source,
+		ReceivedAt: time.Now(),
+		Task:       task,
+	}
+}
+
+func (c *Change) Title() string {
+	if c.Task != nil {
+		return c.Task.Title
+	}
+	if c.Note != nil {
+		return c.Note.Title
+	}
+	return ""
+}
+
+type FieldDiff struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// DiffNotes lists the user-visible fields that differ between two versions
+// of a note. A nil old note is treated as empty.
+func DiffNotes(old, new *Note) []FieldDiff {
+	if old == nil {
+		old = &Note{}
+	}
+	var diffs []FieldDiff
+	diffs = appendDiff(diffs, "Title", old.Title, new.Title)
+	diffs = appendDiff(diffs, "Content", old.Content, new.Content)
+	diffs = appendDiff(diffs, "Tags", strings.Join(old.Tags, ", "), strings.Join(new.Tags, ", "))
+	diffs = appendDiff(diffs, "Priority", old.Priority.String(), new.Priority.String())
+	diffs = appendDiff(diffs, "Completed", fmt.Sprint(old.IsCompleted), fmt.Sprint(new.IsCompleted))
+	diffs = appendDiff(diffs, "Due", formatDiffTime(old.DueDate), formatDiffTime(new.DueDate))
+	diffs = appendDiff(diffs, "Source", old.Source, new.Source)
+	diffs = appendDiff(diffs, "Project", string(old.ProjectID), string(new.ProjectID))
+	diffs = appendDiff(diffs, "Pinned", fmt.Sprint(old.Pinned), fmt.Sprint(new.Pinned))
+	diffs = appendDiff(diffs, "Archived", formatDiffTime(old.ArchivedAt), formatDiffTime(new.ArchivedAt))
+	return diffs
+}
+
+// DiffTasks lists the user-visible fields that differ between two versions
+// of a task. A nil old task is treated as empty.
+func DiffTasks(old, new *Task) []FieldDiff {
+	if old == nil {
+		old = &Task{}
+	}
+	var diffs []FieldDiff
+	diffs = appendDiff(diffs, "Title", old.Title, new.Title)
+	diffs = appendDiff(diffs, "Description", old.Description, new.Description)
+	diffs = appendDiff(diffs, "Due", formatDiffTime(old.DueDate), formatDiffTime(new.DueDate))
+	diffs = appendDiff(diffs, "Reminder", formatDiffTime(old.ReminderAt), formatDiffTime(new.ReminderAt))
+	diffs = appendDiff(diffs, "Status", DefaultWorkflow.Def(old).Name, DefaultWorkflow.Def(new).Name)
+	diffs = appendDiff(diffs, "Priority", old.Priority.String(), new.Priority.String())
+	diffs = appendDiff(diffs, "Tags", strings.Join(old.Tags, ", "), strings.Join(new.Tags, ", "))
+	diffs = appendDiff(diffs, "Subtasks", formatSubtasks(old.Subtasks), formatSubtasks(new.Subtasks))
+	diffs = appendDiff(diffs, "Depends on", formatTaskIDs(old.DependsOn), formatTaskIDs(new.DependsOn))
+	diffs = appendDiff(diffs, "Parent", string(old.ParentID), string(new.ParentID))
+	diffs = appendDiff(diffs, "Project", string(old.ProjectID), string(new.ProjectID))
+	diffs = appendDiff(diffs, "Context", old.Context, new.Context)
+	diffs = appendDiff(diffs, "Pinned", fmt.Sprint(old.Pinned), fmt.Sprint(new.Pinned))
+	diffs = appendDiff(diffs, "Fields", FormatFields(old.Fields), FormatFields(new.Fields))
+	diffs = appendDiff(diffs, "Subtask policy", string(old.SubtaskPolicy), string(new.SubtaskPolicy))
+	diffs = appendDiff(diffs, "Escalation", old.Escalation, new.Escalation)
+	diffs = appendDiff(diffs, "Cron", old.Cron, new.Cron)
+	diffs = appendDiff(diffs, "Timezone", old.Timezone, new.Timezone)
+	diffs = appendDiff(diffs, "Estimate", formatDiffEffort(old.Estimate), formatDiffEffort(new.Estimate))
+	diffs = appendDiff(diffs, "Snoozed until", formatDiffTime(old.SnoozedUntil), formatDiffTime(new.SnoozedUntil))
+	diffs = appendDiff(diffs, "Acknowledged", formatDiffTime(old.AcknowledgedAt), formatDiffTime(new.AcknowledgedAt))
+	diffs = appendDiff(diffs, "Acknowledged via", old.AcknowledgedVia, new.AcknowledgedVia)
+	diffs = appendDiff(diffs, "Archived", formatDiffTime(old.ArchivedAt), formatDiffTime(new.ArchivedAt))
+	diffs = appendDiff(diffs, "Source", old.Source, new.Source)
+	return diffs
+}
+
+func formatTaskIDs(ids []TaskID) string {
+	items := make([]string, len(ids))
+	for i, id := range ids {
+		items[i] = string(id)
+	}
+	return strings.Join(items, ", ")
+}
+
+func formatSubtasks(subtasks []Subtask) string {
+	items := make([]string, len(subtasks))
+	for i, s := range subtasks {
+		check := " "
+		if s.Done {
+			check = "x"
+		}
+		items[i] = "[" + check + "] " + s.Title
+	}
+	return strings.Join(items, ", ")
+}
+
+func appendDiff(diffs []FieldDiff, field, old, new string) []FieldDiff {
+	if old == new {
+		return diffs
+	}
+	return append(diffs, FieldDiff{Field: field, Old: old, New: new})
+}
+
+func formatDiffEffort(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return FormatEffort(d)
+}
+
+func formatDiffTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("Jan 2, 2006 15:04")
+}
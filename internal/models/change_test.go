@@ -0,0 +1,107 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffNotes(t *testing.T) {
+	archived := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		old  *Note
+		new  *Note
+		want []FieldDiff
+	}{
+		{
+			name: "unchanged",
+			old:  &Note{Title: "a", Content: "body"},
+			new:  &Note{Title: "a", Content: "body"},
+		},
+		{
+			name: "nil old is empty",
+			old:  nil,
+			new:  &Note{Title: "a"},
+			want: []FieldDiff{{Field: "Title", New: "a"}},
+		},
+		{
+			name: "content and tags",
+			old:  &Note{Content: "one", Tags: []string{"x"}},
+			new:  &Note{Content: "two", Tags: []string{"x", "y"}},
+			want: []FieldDiff{
+				{Field: "Content", Old: "one", New: "two"},
+				{Field: "Tags", Old: "x", New: "x, y"},
+			},
+		},
+		{
+			name: "archived",
+			old:  &Note{},
+			new:  &Note{ArchivedAt: archived},
+			want: []FieldDiff{{Field: "Archived", New: "Mar 1, 2024 09:30"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffNotes(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffNotes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffTasks(t *testing.T) {
+	when := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		old  *Task
+		new  *Task
+		want []FieldDiff
+	}{
+		{
+			name: "unchanged",
+			old:  &Task{Title: "a", Estimate: time.Hour},
+			new:  &Task{Title: "a", Estimate: time.Hour},
+		},
+		{
+			name: "subtasks",
+			old:  &Task{Subtasks: []Subtask{{Title: "s"}}},
+			new:  &Task{Subtasks: []Subtask{{Title: "s", Done: true}}},
+			want: []FieldDiff{{Field: "Subtasks", Old: "[ ] s", New: "[x] s"}},
+		},
+		{
+			name: "estimate",
+			old:  &Task{},
+			new:  &Task{Estimate: 90 * time.Minute},
+			want: []FieldDiff{{Field: "Estimate", New: "1h30m"}},
+		},
+		{
+			name: "snoozed until",
+			old:  &Task{SnoozedUntil: when},
+			new:  &Task{},
+			want: []FieldDiff{{Field: "Snoozed until", Old: "Mar 1, 2024 09:30"}},
+		},
+		{
+			name: "acknowledged",
+			old:  &Task{},
+			new:  &Task{AcknowledgedAt: when, AcknowledgedVia: "desktop"},
+			want: []FieldDiff{
+				{Field: "Acknowledged", New: "Mar 1, 2024 09:30"},
+				{Field: "Acknowledged via", New: "desktop"},
+			},
+		},
+		{
+			name: "archived",
+			old:  &Task{},
+			new:  &Task{ArchivedAt: when},
+			want: []FieldDiff{{Field: "Archived", New: "Mar 1, 2024 09:30"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DiffTasks(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DiffTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"sort"
+	"strings"
+)
+
+// NormalizeContext turns user input such as "home" or " @Home " into the
+// stored form "@Home". A blank context stays blank.
+func NormalizeContext(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimLeft(s, "@")
+	if s == "" {
+		return ""
+	}
+	return "@" + s
+}
+
+// SameContext compares contexts the way filters do, ignoring case and the
+// leading @.
+func SameContext(a, b string) bool {
+	return strings.EqualFold(NormalizeContext(a), NormalizeContext(b))
+}
+
+// Contexts lists the distinct contexts of the open tasks, sorted.
+func Contexts(tasks []*Task) []string {
+	seen := make(map[string]bool)
+	var contexts []string
+	for _, task := range tasks {
+		key := strings.ToLower(task.Context)
+		if task.Context == "" || !task.IsOpen() || seen[key] {
+			continue
+		}
+		seen[key] = true
+		contexts = append(contexts, task.Context)
+	}
+	sort.Slice(contexts, func(i, j int) bool {
+		return strings.ToLower(contexts[i]) < strings.ToLower(contexts[j])
+	})
+	return contexts
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// ToTask turns the note into a task due at due, keeping its tags,
+// priority, project and creation time. The task links back to the note
+// through NoteID. A zero due date leaves the task undated.
+func (n *Note) ToTask(due time.Time) *Task {
+	t := NewTask(n.Title, n.Content, due)
+	if due.IsZero() {
+		t.ReminderAt = time.Time{}
+	}
+	t.CreatedAt = n.CreatedAt
+	t.Tags = append([]string(nil), n.Tags...)
+	t.Priority = n.Priority
+	t.ProjectID = n.ProjectID
+	t.Pinned = n.Pinned
+	t.NoteID = n.ID
+	if n.IsCompleted {
+		t.Status = TaskStatusCompleted
+		t.CompletedAt = n.UpdatedAt
+	}
+	return t
+}
+
+// ToNote turns the task into a note, keeping its tags, priority, project,
+// due date and creation time. The description becomes the content,
+// followed by the checklist.
+func (t *Task) ToNote() *Note {
+	content := t.Description
+	if len(t.Subtasks) > 0 {
+		var b strings.Builder
+		b.WriteString(content)
+		if content != "" {
+			b.WriteString("\n\n")
+		}
+		for _, s := range t.Subtasks {
+			box := "[ ]"
+			if s.Done {
+				box = "[x]"
+			}
+			b.WriteString("- " + box + " " + s.Title + "\n")
+		}
+		content = b.String()
+	}
+
+	n := NewNote(t.Title, content)
+	n.CreatedAt = t.CreatedAt
+	n.Tags = append([]string(nil), t.Tags...)
+	n.Priority = t.Priority
+	n.ProjectID = t.ProjectID
+	n.Pinned = t.Pinned
+	n.DueDate = t.DueDate
+	n.IsCompleted = t.Status == TaskStatusCompleted
+	return n
+}
@@ -0,0 +1,243 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cron is a parsed five-field cron expression: minute, hour, day of
+// month, month and day of week, e.g. "0 9 * * MON".
+type Cron struct {
+	minute, hour, dom, month, dow uint64
+	// Cron matches either day field when both are restricted
+	domAny, dowAny bool
+}
+
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+var (
+	cronMonths = []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}
+	cronDays   = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+)
+
+// ParseCron reads a cron expression. Fields take *, numbers, ranges
+// (1-5), steps (*/15) and lists (1,15); months and days of week also take
+// names such as JAN or MON. @hourly, @daily, @weekly, @monthly and
+// @yearly are accepted too.
+func ParseCron(expr string) (Cron, error) {
+	var c Cron
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return c, fmt.Errorf("cron expression %q needs 5 fields: minute hour day month weekday", expr)
+	}
+
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return c, fmt.Errorf("cron minute: %w", err)
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return c, fmt.Errorf("cron hour: %w", err)
+	}
+	if c.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return c, fmt.Errorf("cron day of month: %w", err)
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12, cronMonths); err != nil {
+		return c, fmt.Errorf("cron month: %w", err)
+	}
+	// 7 is Sunday as well as 0
+	if c.dow, err = parseCronField(fields[4], 0, 7, cronDays); err != nil {
+		return c, fmt.Errorf("cron day of week: %w", err)
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	// A field covering every day, however it is written, doesn't restrict
+	c.domAny = c.dom&cronAllDom == cronAllDom
+	c.dowAny = c.dow&cronAllDow == cronAllDow
+	return c, nil
+}
+
+const (
+	cronAllDom uint64 = 1<<32 - 2 // 1-31
+	cronAllDow uint64 = 1<<7 - 1  // 0-6
+)
+
+// cronCache holds the schedules parsed for tasks, by expression.
+var cronCache sync.Map
+
+// cachedCron parses expr once and reuses the schedule after that.
+func cachedCron(expr string) (Cron, error) {
+	if c, ok := cronCache.Load(expr); ok {
+		return c.(Cron), nil
+	}
+	c, err := ParseCron(expr)
+	if err != nil {
+		return c, err
+	}
+	cronCache.Store(expr, c)
+	return c, nil
+}
+
+// IsCron reports whether s is a valid cron expression rather than a
+// duration, so form fields can accept either.
+func IsCron(s string) bool {
+	_, err := ParseCron(s)
+	return err == nil
+}
+
+func parseCronField(field string, min, max int, names []string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rng, step = part[:i], n
+		}
+
+		lo, hi := min, max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = cronValue(bounds[0], names); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = cronValue(bounds[1], names); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				// "5/15" means from 5 to the end in steps of 15
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func cronValue(s string, names []string) (int, error) {
+	for i, name := range names {
+		if name != "" && strings.EqualFold(s, name) {
+			return i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return n, nil
+}
+
+// matchesDay reports whether the schedule fires on t's day
+func (c Cron) matchesDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Prev returns the latest time at or before t the schedule fires, or the
+// zero time if it hasn't fired in the last five years.
+func (c Cron) Prev(t time.Time) time.Time {
+	t = t.Truncate(time.Minute)
+	limit := t.AddDate(-5, 0, 0)
+	for t.After(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			// Last minute of the previous month
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).Add(-time.Minute)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location()).Add(-time.Minute)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(-time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// Next returns the first time after t the schedule fires, or the zero
+// time if it doesn't within five years.
+func (c Cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// CronReminder is the latest time at or before now the task's cron
+// schedule fired, or the zero time for tasks without one. The schedule
+// runs in the task's timezone.
+func (t *Task) CronReminder(now time.Time) time.Time {
+	if t.Cron == "" {
+		return time.Time{}
+	}
+	c, err := cachedCron(t.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return c.Prev(now.In(t.Location()))
+}
+
+// NextCronReminder is when the task's cron schedule next fires after now,
+// or the zero time for tasks without one.
+func (t *Task) NextCronReminder(now time.Time) time.Time {
+	if t.Cron == "" {
+		return time.Time{}
+	}
+	c, err := cachedCron(t.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return c.Next(now.In(t.Location()))
+}
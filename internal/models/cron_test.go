@@ -0,0 +1,123 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"0 9 * *",
+		"0 9 * * * *",
+		"60 9 * * *",
+		"0 24 * * *",
+		"0 9 0 * *",
+		"0 9 * 13 *",
+		"0 9 * * 8",
+		"*/0 * * * *",
+		"0 9 * * XYZ",
+		"5-1 * * * *",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want an error", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2024, 5, 15, 10, 7, 0, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2024, 5, 15, 10, 15, 0, 0, time.UTC)},
+		{"0 9 * * MON", time.Date(2024, 5, 20, 9, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2024, 5, 16, 9, 0, 0, 0, time.UTC)},
+		{"30 8 1 * *", time.Date(2024, 6, 1, 8, 30, 0, 0, time.UTC)},
+		{"0 0 29 FEB *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either matches
+		{"0 9 1 * FRI", time.Date(2024, 5, 17, 9, 0, 0, 0, time.UTC)},
+		// A day of week written as a step over every day restricts nothing,
+		// so only the day of month counts
+		{"0 9 1 * */1", time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)},
+		{"0 9 */1 * FRI", time.Date(2024, 5, 17, 9, 0, 0, 0, time.UTC)},
+		{"0 9 1-31 * SAT", time.Date(2024, 5, 18, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			c, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron() = %v", err)
+			}
+			if got := c.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+			if got := c.Prev(tt.want); !got.Equal(tt.want) {
+				t.Errorf("Prev(%v) = %v, want the same time", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCronPrev(t *testing.T) {
+	at := time.Date(2024, 5, 15, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"7 10 * * *", time.Date(2024, 5, 15, 10, 7, 0, 0, time.UTC)},
+		{"0 9 * * MON", time.Date(2024, 5, 13, 9, 0, 0, 0, time.UTC)},
+		{"0 11 * * *", time.Date(2024, 5, 14, 11, 0, 0, 0, time.UTC)},
+		{"0 0 1 JAN *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			c, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron() = %v", err)
+			}
+			if got := c.Prev(at); !got.Equal(tt.want) {
+				t.Errorf("Prev() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskCronReminder(t *testing.T) {
+	now := time.Date(2024, 5, 15, 10, 7, 0, 0, time.UTC)
+	task := &Task{Cron: "0 9 * * *", Timezone: "America/New_York"}
+	// 9:00 in New York is 13:00 UTC while daylight saving time is on
+	if got, want := task.CronReminder(now), time.Date(2024, 5, 14, 13, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("CronReminder() = %v, want %v", got, want)
+	}
+	if got, want := task.NextCronReminder(now), time.Date(2024, 5, 15, 13, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("NextCronReminder() = %v, want %v", got, want)
+	}
+	if got := (&Task{Cron: "not cron"}).CronReminder(now); !got.IsZero() {
+		t.Errorf("CronReminder() of an invalid schedule = %v, want zero", got)
+	}
+}
+
+func TestIsCron(t *testing.T) {
+	tests := []struct {
+		s    string
+		want bool
+	}{
+		{"0 9 * * MON", true},
+		{" @weekly ", true},
+		{"30m", false},
+		{"1d", false},
+		{"call the bank on monday", false},
+		{"@sometimes", false},
+	}
+	for _, tt := range tests {
+		if got := IsCron(tt.s); got != tt.want {
+			t.Errorf("IsCron(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// IndexTasks maps tasks by ID for dependency lookups.
+func IndexTasks(tasks []*Task) map[TaskID]*Task {
+	index := make(map[TaskID]*Task, len(tasks))
+	for _, task := range tasks {
+		index[task.ID] = task
+	}
+	return index
+}
+
+// AddDependency makes the task wait on another one. A task can't depend
+// on itself and duplicates are ignored.
+func (t *Task) AddDependency(id TaskID) {
+	if id == t.ID {
+		return
+	}
+	for _, existing := range t.DependsOn {
+		if existing == id {
+			return
+		}
+	}
+	t.DependsOn = append(t.DependsOn, id)
+	t.UpdatedAt = time.Now()
+}
+
+func (t *Task) RemoveDependency(id TaskID) {
+	for i, existing := range t.DependsOn {
+		if existing == id {
+			t.DependsOn = append(t.DependsOn[:i], t.DependsOn[i+1:]...)
+			t.UpdatedAt = time.Now()
+			return
+		}
+	}
+}
+
+// Blockers returns the dependencies that are still open. Cancelled
+// dependencies and ones missing from the index, e.g. deleted tasks, don't
+// block.
+func (t *Task) Blockers(tasks map[TaskID]*Task) []*Task {
+	var blockers []*Task
+	for _, id := range t.DependsOn {
+		if dep, ok := tasks[id]; ok && dep.IsOpen() {
+			blockers = append(blockers, dep)
+		}
+	}
+	return blockers
+}
+
+func (t *Task) IsBlocked(tasks map[TaskID]*Task) bool {
+	return len(t.Blockers(tasks)) > 0
+}
+
+// DependsOnTask reports whether t waits on id, directly or through other
+// tasks. It is used to refuse dependencies that would form a cycle.
+func (t *Task) DependsOnTask(id TaskID, tasks map[TaskID]*Task) bool {
+	seen := make(map[TaskID]bool)
+	queue := append([]TaskID(nil), t.DependsOn...)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if next == id {
+			return true
+		}
+		if seen[next] {
+			continue
+		}
+		seen[next] = true
+		if dep, ok := tasks[next]; ok {
+			queue = append(queue, dep.DependsOn...)
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// SetEstimate records how long the task is expected to take; zero clears it.
+func (t *Task) SetEstimate(d time.Duration) {
+	t.Estimate = d
+	t.UpdatedAt = time.Now()
+}
+
+// Actual is the time spent between starting and completing the task, or
+// so far if it is still in progress. It is zero for tasks never started.
+func (t *Task) Actual(now time.Time) time.Duration {
+	if t.StartedAt.IsZero() {
+		return 0
+	}
+	end := now
+	if !t.CompletedAt.IsZero() {
+		end = t.CompletedAt
+	}
+	if end.Before(t.StartedAt) {
+		return 0
+	}
+	return end.Sub(t.StartedAt)
+}
+
+// FormatEffort renders a duration as hours and minutes, e.g. "1h30m".
+func FormatEffort(d time.Duration) string {
+	d = d.Round(time.Minute)
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%02dm", h, m)
+	}
+}
+
+// SumEstimates totals the estimates of open tasks due on the same calendar
+// day as day.
+func SumEstimates(tasks []*Task, day time.Time) time.Duration {
+	y, m, d := day.Date()
+	var total time.Duration
+	for _, task := range tasks {
+		if !task.IsOpen() || task.DueDate.IsZero() {
+			continue
+		}
+		if ty, tm, td := task.DueDate.In(day.Location()).Date(); ty == y && tm == m && td == d {
+			total += task.Estimate
+		}
+	}
+	return total
+}
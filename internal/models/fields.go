@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParseFields reads custom fields written as "name=value" pairs separated
+// by commas, e.g. "client=Acme, ticket=OPS-42".
+func ParseFields(s string) (map[string]string, error) {
+	fields := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("field %q should look like name=value", pair)
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			fields[name] = value
+		}
+	}
+	return fields, nil
+}
+
+// FormatFields writes fields back in the form ParseFields reads, sorted by
+// name.
+func FormatFields(fields map[string]string) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + fields[name]
+	}
+	return strings.Join(pairs, ", ")
+}
@@ -0,0 +1,265 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Filter is a parsed query such as "tag=work status=pending due<7d".
+// Every term must match; repeated status or priority terms match any of
+// the given values. Relative due bounds are resolved when matching, so a
+// saved filter keeps meaning "the next 7 days". Status terms naming custom
+// workflow statuses are kept in Labels.
+type Filter struct {
+	Tags       []string
+	Statuses   []TaskStatus
+	Labels     []string
+	Priorities []Priority
+	DueBefore  *TimeBound
+	DueAfter   *TimeBound
+	Sources    []string
+	Contexts   []string
+	// Project limits matches to one project. It is set by the project
+	// view rather than parsed from a query.
+	Project ProjectID
+}
+
+// TimeBound is either an absolute time or an offset from now.
+type TimeBound struct {
+	At     time.Time
+	Offset time.Duration
+}
+
+func (b *TimeBound) Resolve(now time.Time) time.Time {
+	if !b.At.IsZero() {
+		return b.At
+	}
+	return now.Add(b.Offset)
+}
+
+// ParseFilter parses a whitespace separated list of key=value, key<value
+// and key>value terms. Supported keys are tag, status, priority, due,
+// source and context. Status terms may name custom statuses of
+// DefaultWorkflow.
+func ParseFilter(query string) (Filter, error) {
+	return DefaultWorkflow.ParseFilter(query)
+}
+
+// ParseFilter parses a filter whose status terms may name the workflow's
+// custom statuses.
+func (w Workflow) ParseFilter(query string) (Filter, error) {
+	var f Filter
+	for _, term := range strings.Fields(query) {
+		i := strings.IndexAny(term, "=<>")
+		if i <= 0 || i == len(term)-1 {
+			return f, fmt.Errorf("invalid filter term %q", term)
+		}
+		key, op, value := strings.ToLower(term[:i]), term[i], term[i+1:]
+
+		switch key {
+		case "tag":
+			if op != '=' {
+				return f, fmt.Errorf("tag only supports =, got %q", term)
+			}
+			f.Tags = append(f.Tags, strings.TrimPrefix(value, "#"))
+		case "status":
+			if op != '=' {
+				return f, fmt.Errorf("status only supports =, got %q", term)
+			}
+			if def, ok := w.Find(value); ok {
+				f.Labels = append(f.Labels, def.Name)
+				break
+			}
+			status, err := ParseTaskStatus(value)
+			if err != nil {
+				return f, err
+			}
+			f.Statuses = append(f.Statuses, status)
+		case "priority":
+			if op != '=' {
+				return f, fmt.Errorf("priority only supports =, got %q", term)
+			}
+			priority, err := ParsePriority(value)
+			if err != nil {
+				return f, err
+			}
+			f.Priorities = append(f.Priorities, priority)
+		case "due":
+			bound, err := parseTimeBound(value)
+			if err != nil {
+				return f, fmt.Errorf("invalid due bound in %q: %w", term, err)
+			}
+			switch op {
+			case '<':
+				f.DueBefore = bound
+			case '>':
+				f.DueAfter = bound
+			default:
+				return f, fmt.Errorf("due only supports < and >, got %q", term)
+			}
+		case "source":
+			if op != '=' {
+				return f, fmt.Errorf("source only supports =, got %q", term)
+			}
+			f.Sources = append(f.Sources, value)
+		case "context":
+			if op != '=' {
+				return f, fmt.Errorf("context only supports =, got %q", term)
+			}
+			f.Contexts = append(f.Contexts, NormalizeContext(value))
+		default:
+			return f, fmt.Errorf("unknown filter key %q", key)
+		}
+	}
+	return f, nil
+}
+
+// parseTimeBound accepts a date (2006-01-02), a day/week offset (7d, 2w)
+// or a Go duration (36h), optionally negative.
+func parseTimeBound(value string) (*TimeBound, error) {
+	if at, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return &TimeBound{At: at}, nil
+	}
+
+	d, err := ParseQuickDuration(value)
+	if err != nil {
+		return nil, err
+	}
+	return &TimeBound{Offset: d}, nil
+}
+
+func (f Filter) IsEmpty() bool {
+	return len(f.Tags) == 0 && len(f.Statuses) == 0 && len(f.Labels) == 0 && len(f.Priorities) == 0 &&
+		f.DueBefore == nil && f.DueAfter == nil && len(f.Sources) == 0 &&
+		len(f.Contexts) == 0 && f.Project == ""
+}
+
+func (f Filter) MatchTask(t *Task, now time.Time) bool {
+	if f.Project != "" && t.ProjectID != f.Project {
+		return false
+	}
+	if !hasAllTags(t.Tags, f.Tags) {
+		return false
+	}
+	if len(f.Statuses) > 0 || len(f.Labels) > 0 {
+		matched := false
+		for _, status := range f.Statuses {
+			if t.storedStatus() == status || (status == TaskStatusOverdue && t.IsOverDue()) {
+				matched = true
+				break
+			}
+		}
+		for _, label := range f.Labels {
+			if strings.EqualFold(t.StatusLabel, label) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if !matchPriority(t.Priority, f.Priorities) || !matchSource(t.Source, f.Sources) || !matchContext(t.Context, f.Contexts) {
+		return false
+	}
+	return f.matchDue(t.DueDate, now)
+}
+
+// MatchNote applies the filter to a note. Notes only know completed and
+// pending, so any other status term excludes them, as does a context
+// term.
+func (f Filter) MatchNote(n *Note, now time.Time) bool {
+	if f.Project != "" && n.ProjectID != f.Project {
+		return false
+	}
+	if !hasAllTags(n.Tags, f.Tags) {
+		return false
+	}
+	if len(f.Statuses) > 0 || len(f.Labels) > 0 {
+		matched := false
+		for _, status := range f.Statuses {
+			if (status == TaskStatusCompleted && n.IsCompleted) || (status == TaskStatusPending && !n.IsCompleted) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if !matchPriority(n.Priority, f.Priorities) || !matchSource(n.Source, f.Sources) || len(f.Contexts) > 0 {
+		return false
+	}
+	return f.matchDue(n.DueDate, now)
+}
+
+func (f Filter) matchDue(due, now time.Time) bool {
+	if f.DueBefore == nil && f.DueAfter == nil {
+		return true
+	}
+	if due.IsZero() {
+		return false
+	}
+	if f.DueBefore != nil && !due.Before(f.DueBefore.Resolve(now)) {
+		return false
+	}
+	if f.DueAfter != nil && !due.After(f.DueAfter.Resolve(now)) {
+		return false
+	}
+	return true
+}
+
+func hasAllTags(tags, required []string) bool {
+	for _, want := range required {
+		found := false
+		for _, tag := range tags {
+			if tag == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matchSource matches any of the allowed sources; repeated source terms
+// widen the match like status and priority do.
+func matchSource(source string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, s := range allowed {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+func matchContext(context string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, c := range allowed {
+		if SameContext(c, context) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPriority(p Priority, allowed []Priority) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if p == a {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseFilterErrors(t *testing.T) {
+	for _, query := range []string{
+		"work",
+		"tag=",
+		"=work",
+		"tag<work",
+		"status>pending",
+		"status=someday",
+		"priority=urgent",
+		"due=7d",
+		"due<soon",
+		"color=red",
+	} {
+		if _, err := ParseFilter(query); err == nil {
+			t.Errorf("ParseFilter(%q) succeeded, want an error", query)
+		}
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	f, err := ParseFilter("tag=#work TAG=home status=pending status=in-progress priority=high due<1d due>-2w source=email context=home")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Tags) != 2 || f.Tags[0] != "work" || f.Tags[1] != "home" {
+		t.Errorf("tags = %q, want [work home]", f.Tags)
+	}
+	if len(f.Statuses) != 2 || f.Statuses[0] != TaskStatusPending || f.Statuses[1] != TaskStatusInProgress {
+		t.Errorf("statuses = %v, want [Pending In Progress]", f.Statuses)
+	}
+	if len(f.Priorities) != 1 || f.Priorities[0] != HighPriority {
+		t.Errorf("priorities = %v, want [High]", f.Priorities)
+	}
+	if f.DueBefore == nil || f.DueBefore.Offset != 24*time.Hour {
+		t.Errorf("due before = %+v, want an offset of 1d", f.DueBefore)
+	}
+	if f.DueAfter == nil || f.DueAfter.Offset != -14*24*time.Hour {
+		t.Errorf("due after = %+v, want an offset of -2w", f.DueAfter)
+	}
+	if len(f.Sources) != 1 || f.Sources[0] != "email" {
+		t.Errorf("sources = %q, want [email]", f.Sources)
+	}
+	if len(f.Contexts) != 1 || f.Contexts[0] != NormalizeContext("home") {
+		t.Errorf("contexts = %q, want [%s]", f.Contexts, NormalizeContext("home"))
+	}
+
+	if f, err := ParseFilter("   "); err != nil || !f.IsEmpty() {
+		t.Errorf("ParseFilter of blank = %+v, %v, want an empty filter", f, err)
+	}
+}
+
+func TestParseFilterDate(t *testing.T) {
+	f, err := ParseFilter("due<2024-06-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+	if f.DueBefore == nil || !f.DueBefore.At.Equal(want) {
+		t.Errorf("due before = %+v, want %v", f.DueBefore, want)
+	}
+}
+
+func TestParseFilterCustomStatus(t *testing.T) {
+	review, err := NewStatusDef("Review", "", "", "in progress")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewWorkflow(append(BuiltinWorkflow(), review))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := w.ParseFilter("status=review")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Labels) != 1 || f.Labels[0] != "Review" || len(f.Statuses) != 0 {
+		t.Errorf("labels = %q, statuses = %v, want [Review] and none", f.Labels, f.Statuses)
+	}
+
+	task := NewTask("Check the PR", "", time.Time{})
+	if f.MatchTask(task, time.Now()) {
+		t.Error("task without the custom status matched")
+	}
+	task.StatusLabel = "review"
+	if !f.MatchTask(task, time.Now()) {
+		t.Error("task with the custom status didn't match")
+	}
+}
+
+func TestFilterMatchTask(t *testing.T) {
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	task := NewTask("Pay rent", "", now.Add(36*time.Hour))
+	task.Tags = []string{"home", "money"}
+	task.Priority = HighPriority
+	task.Source = "email"
+	task.Context = NormalizeContext("home")
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"tag=home tag=money", true},
+		{"tag=home tag=work", false},
+		{"priority=low priority=high", true},
+		{"priority=low", false},
+		{"status=pending", true},
+		{"status=completed", false},
+		{"due<2d", true},
+		{"due<1d", false},
+		{"due>1d", true},
+		{"due>2d", false},
+		{"source=cli source=email", true},
+		{"source=cli", false},
+		{"context=home", true},
+		{"context=office", false},
+	}
+	for _, tt := range tests {
+		f, err := ParseFilter(tt.query)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q): %v", tt.query, err)
+		}
+		if got := f.MatchTask(task, now); got != tt.want {
+			t.Errorf("%q matched = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	task.DueDate = time.Time{}
+	f, _ := ParseFilter("due<7d")
+	if f.MatchTask(task, now) {
+		t.Error("task without a due date matched a due bound")
+	}
+}
+
+func TestFilterMatchNote(t *testing.T) {
+	now := time.Now()
+	note := NewNote("Groceries", "")
+	note.Tags = []string{"home"}
+	for query, want := range map[string]bool{
+		"tag=home":           true,
+		"status=pending":     true,
+		"status=completed":   false,
+		"status=in-progress": false,
+		"context=home":       false,
+	} {
+		f, err := ParseFilter(query)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q): %v", query, err)
+		}
+		if got := f.MatchNote(note, now); got != want {
+			t.Errorf("%q matched = %v, want %v", query, got, want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+)
+
+// GenerateUniqueID returns a UUIDv7: a millisecond timestamp followed by
+// random bits, so IDs still sort by creation time.
+func GenerateUniqueID() string {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+	if _, err := rand.Read(b[6:]); err != nil {
+		panic("crypto/rand failed: " + err.Error())
+	}
+	b[6] = b[6]&0x0f | 0x70 // version 7
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+
+	var out [36]byte
+	hex.Encode(out[0:8], b[0:4])
+	out[8] = '-'
+	hex.Encode(out[9:13], b[4:6])
+	out[13] = '-'
+	hex.Encode(out[14:18], b[6:8])
+	out[18] = '-'
+	hex.Encode(out[19:23], b[8:10])
+	out[23] = '-'
+	hex.Encode(out[24:], b[10:])
+	return string(out[:])
+}
+
+// IsUUID reports whether id has the canonical UUID form. IDs created
+// before the switch to UUIDs don't.
+func IsUUID(id string) bool {
+	if len(id) != 36 {
+		return false
+	}
+	for i, c := range id {
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// RandomString returns length random alphanumeric characters.
+func RandomString(length int) string {
+	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// Bytes at or above this are rejected so every character is equally
+	// likely
+	const limit = 256 - 256%len(charset)
+
+	result := make([]byte, 0, length)
+	buf := make([]byte, length)
+	for len(result) < length {
+		if _, err := rand.Read(buf); err != nil {
+			panic("crypto/rand failed: " + err.Error())
+		}
+		for _, c := range buf {
+			if int(c) < limit && len(result) < length {
+				result = append(result, charset[int(c)%len(charset)])
+			}
+		}
+	}
+	return string(result)
+}
+
+// IDRewrite maps legacy note and task IDs to new ones and updates every
+// reference between items to match.
+type IDRewrite struct {
+	Notes map[NoteID]NoteID `json:"notes"`
+	Tasks map[TaskID]TaskID `json:"tasks"`
+}
+
+// NewIDRewrite assigns a UUID to every note and task whose ID isn't one.
+func NewIDRewrite(notes []*Note, tasks []*Task) *IDRewrite {
+	r := &IDRewrite{}
+	r.Add(notes, tasks)
+	return r
+}
+
+// Add assigns a UUID to every note and task whose ID isn't one and isn't
+// mapped yet, keeping the IDs already assigned.
+func (r *IDRewrite) Add(notes []*Note, tasks []*Task) {
+	if r.Notes == nil {
+		r.Notes = make(map[NoteID]NoteID)
+	}
+	if r.Tasks == nil {
+		r.Tasks = make(map[TaskID]TaskID)
+	}
+	for _, note := range notes {
+		if _, ok := r.Notes[note.ID]; !ok && !IsUUID(string(note.ID)) {
+			r.Notes[note.ID] = NoteID(GenerateUniqueID())
+		}
+	}
+	for _, task := range tasks {
+		if _, ok := r.Tasks[task.ID]; !ok && !IsUUID(string(task.ID)) {
+			r.Tasks[task.ID] = TaskID(GenerateUniqueID())
+		}
+	}
+}
+
+func (r *IDRewrite) Empty() bool {
+	return len(r.Notes) == 0 && len(r.Tasks) == 0
+}
+
+// Note returns a copy of the note with its own ID and links rewritten, and
+// reports whether anything changed.
+func (r *IDRewrite) Note(n *Note) (*Note, bool) {
+	c := n.Clone()
+	changed := false
+	if id, ok := r.Notes[c.ID]; ok {
+		c.ID, changed = id, true
+	}
+	for i, link := range c.Links {
+		if id, ok := r.Notes[link]; ok {
+			c.Links[i], changed = id, true
+		}
+	}
+	return c, changed
+}
+
+// Task returns a copy of the task with its own ID and its references to
+// notes and other tasks rewritten, and reports whether anything changed.
+func (r *IDRewrite) Task(t *Task) (*Task, bool) {
+	c := t.Clone()
+	changed := false
+	if id, ok := r.Tasks[c.ID]; ok {
+		c.ID, changed = id, true
+	}
+	if id, ok := r.Notes[c.NoteID]; ok {
+		c.NoteID, changed = id, true
+	}
+	if id, ok := r.Tasks[c.ParentID]; ok {
+		c.ParentID, changed = id, true
+	}
+	for i, dep := range c.DependsOn {
+		if id, ok := r.Tasks[dep]; ok {
+			c.DependsOn[i], changed = id, true
+		}
+	}
+	return c, changed
+}
+
+// TaskIDs rewrites a list of task IDs in place.
+func (r *IDRewrite) TaskIDs(ids []TaskID) {
+	for i, old := range ids {
+		if id, ok := r.Tasks[old]; ok {
+			ids[i] = id
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIDRewriteAdd(t *testing.T) {
+	uuid := NoteID(GenerateUniqueID())
+	notes := []*Note{{ID: "n1"}, {ID: uuid}}
+	tasks := []*Task{{ID: "t1"}}
+
+	r := NewIDRewrite(notes, tasks)
+	if _, ok := r.Notes[uuid]; ok {
+		t.Errorf("note %s already has a UUID but was mapped", uuid)
+	}
+	if !IsUUID(string(r.Notes["n1"])) || !IsUUID(string(r.Tasks["t1"])) {
+		t.Fatalf("legacy IDs not mapped to UUIDs: %v %v", r.Notes, r.Tasks)
+	}
+
+	// A resumed migration keeps the IDs handed out before
+	first := r.Notes["n1"]
+	r.Add(append(notes, &Note{ID: "n2"}), tasks)
+	if r.Notes["n1"] != first {
+		t.Errorf("Add() remapped n1 to %s, want %s", r.Notes["n1"], first)
+	}
+	if _, ok := r.Notes["n2"]; !ok {
+		t.Error("Add() didn't map n2")
+	}
+
+	var empty IDRewrite
+	empty.Add(nil, nil)
+	if !empty.Empty() {
+		t.Error("Empty() = false for a rewrite with nothing to map")
+	}
+}
+
+func TestIDRewriteReferences(t *testing.T) {
+	r := &IDRewrite{
+		Notes: map[NoteID]NoteID{"n1": "N1", "n2": "N2"},
+		Tasks: map[TaskID]TaskID{"t1": "T1", "t2": "T2"},
+	}
+	tests := []struct {
+		name        string
+		task        *Task
+		want        *Task
+		wantChanged bool
+	}{
+		{
+			name:        "every reference",
+			task:        &Task{ID: "t1", NoteID: "n1", ParentID: "t2", DependsOn: []TaskID{"t2", "other"}},
+			want:        &Task{ID: "T1", NoteID: "N1", ParentID: "T2", DependsOn: []TaskID{"T2", "other"}},
+			wantChanged: true,
+		},
+		{
+			name: "nothing to rewrite",
+			task: &Task{ID: "other", DependsOn: []TaskID{"x"}},
+			want: &Task{ID: "other", DependsOn: []TaskID{"x"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := tt.task.Clone()
+			got, changed := r.Task(tt.task)
+			if changed != tt.wantChanged {
+				t.Errorf("Task() changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if got.ID != tt.want.ID || got.NoteID != tt.want.NoteID || got.ParentID != tt.want.ParentID || !reflect.DeepEqual(got.DependsOn, tt.want.DependsOn) {
+				t.Errorf("Task() = %+v, want %+v", got, tt.want)
+			}
+			if !reflect.DeepEqual(tt.task, orig) {
+				t.Errorf("Task() modified its argument: %+v", tt.task)
+			}
+		})
+	}
+
+	note, changed := r.Note(&Note{ID: "n1", Links: []NoteID{"n2", "other"}})
+	if !changed || note.ID != "N1" || !reflect.DeepEqual(note.Links, []NoteID{"N2", "other"}) {
+		t.Errorf("Note() = %s %v, %v", note.ID, note.Links, changed)
+	}
+
+	ids := []TaskID{"t1", "other"}
+	r.TaskIDs(ids)
+	if !reflect.DeepEqual(ids, []TaskID{"T1", "other"}) {
+		t.Errorf("TaskIDs() = %v", ids)
+	}
+}
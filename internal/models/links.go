@@ -0,0 +1,60 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// linkPattern matches wiki-style links such as [[Meeting notes]].
+var linkPattern = regexp.MustCompile(`\[\[([^\[\]\n]+)\]\]`)
+
+// ParseLinks returns the titles linked from content, each once, in the
+// order they first appear.
+func ParseLinks(content string) []string {
+	var titles []string
+	seen := make(map[string]bool)
+	for _, match := range linkPattern.FindAllStringSubmatch(content, -1) {
+		title := strings.TrimSpace(match[1])
+		key := strings.ToLower(title)
+		if title == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		titles = append(titles, title)
+	}
+	return titles
+}
+
+// ResolveLinks points Links at the notes the content links to by title,
+// ignoring case. Links to titles that don't exist yet are dropped and
+// picked up the next time the note is saved.
+func (n *Note) ResolveLinks(notes []*Note) {
+	byTitle := make(map[string]NoteID, len(notes))
+	for _, other := range notes {
+		key := strings.ToLower(strings.TrimSpace(other.Title))
+		if _, taken := byTitle[key]; !taken {
+			byTitle[key] = other.ID
+		}
+	}
+
+	n.Links = nil
+	for _, title := range ParseLinks(n.Content) {
+		if id, ok := byTitle[strings.ToLower(title)]; ok && id != n.ID {
+			n.Links = append(n.Links, id)
+		}
+	}
+}
+
+// Backlinks returns the notes that link to the note with the given ID.
+func Backlinks(id NoteID, notes []*Note) []*Note {
+	var backlinks []*Note
+	for _, note := range notes {
+		for _, link := range note.Links {
+			if link == id {
+				backlinks = append(backlinks, note)
+				break
+			}
+		}
+	}
+	return backlinks
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type NoteID string
 
@@ -12,16 +16,71 @@ const (
 	HighPriority
 )
 
+func (p Priority) String() string {
+	switch p {
+	case LowPriority:
+		return "Low"
+	case MediumPriority:
+		return "Medium"
+	case HighPriority:
+		return "High"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParsePriority accepts "low", "medium" or "high" in any case.
+func ParsePriority(s string) (Priority, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "low":
+		return LowPriority, nil
+	case "medium":
+		return MediumPriority, nil
+	case "high":
+		return HighPriority, nil
+	default:
+		return 0, fmt.Errorf("unknown priority %q (want low, medium or high)", s)
+	}
+}
+
 type Note struct {
-	ID          NoteID    `json:"id"`
-	Title       string    `json:"title"`
-	Content     string    `json:"content"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	Tags        []string  `json:"tags,omitempty"`
-	Priority    Priority  `json:"priority"`
-	IsCompleted bool      `json:"is_completed"`
-	DueDate     time.Time `json:"due_date,omitempty"`
+	ID          NoteID       `json:"id"`
+	Title       string       `json:"title"`
+	Content     string       `json:"content"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	Tags        []string     `json:"tags,omitempty"`
+	Priority    Priority     `json:"priority"`
+	IsCompleted bool         `json:"is_completed"`
+	DueDate     time.Time    `json:"due_date,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	// Source names the integration that captured the note, e.g. "cli" or
+	// "email"; empty for notes created in the app.
+	Source string `json:"source,omitempty"`
+	// Links holds the notes this one links to with [[Title]], resolved
+	// when the note is saved.
+	Links []NoteID `json:"links,omitempty"`
+	// ProjectID is the project the note belongs to, if any.
+	ProjectID ProjectID `json:"project_id,omitempty"`
+	// Pinned notes are listed first.
+	Pinned bool `json:"pinned,omitempty"`
+	// ArchivedAt is when the note was archived, which keeps it out of
+	// the note list.
+	ArchivedAt time.Time `json:"archived_at,omitempty"`
+	// ContentOmitted marks a note listed without its body, which must be
+	// fetched before the note can be saved.
+	ContentOmitted bool `json:"-"`
+}
+
+func (n *Note) SetPinned(pinned bool) {
+	n.Pinned = pinned
+	n.UpdatedAt = time.Now()
+}
+
+type Attachment struct {
+	Path        string    `json:"path"`
+	AddedAt     time.Time `json:"added_at"`
+	Transcribed bool      `json:"transcribed,omitempty"`
 }
 
 func NewNote(title, content string) *Note {
@@ -42,6 +101,15 @@ func (n *Note) SetDueDate(dueDate time.Time) {
 	n.UpdatedAt = time.Now()
 }
 
+// Clone returns a deep copy of the note.
+func (n *Note) Clone() *Note {
+	c := *n
+	c.Tags = append([]string(nil), n.Tags...)
+	c.Attachments = append([]Attachment(nil), n.Attachments...)
+	c.Links = append([]NoteID(nil), n.Links...)
+	return &c
+}
+
 func (n *Note) Complete() {
 	n.IsCompleted = true
 	n.UpdatedAt = time.Now()
@@ -73,23 +141,51 @@ func (n *Note) RemoveTag(tag string) {
 	}
 }
 
-func (n *Note) SetPriority(priority Priority) {
-	n.Priority = priority
+func (n *Note) Attach(path string) {
+	for _, a := range n.Attachments {
+		if a.Path == path {
+			return
+		}
+	}
+	n.Attachments = append(n.Attachments, Attachment{Path: path, AddedAt: time.Now()})
 	n.UpdatedAt = time.Now()
 }
 
-func GenerateUniqueID() string {
-	return time.Now().Format("20060102150405") + RandomString(8)
+// AppendTranscript adds the transcript of an attached file to the note
+// content and marks the attachment as transcribed.
+func (n *Note) AppendTranscript(path, transcript string) {
+	if n.Content != "" {
+		n.Content += "\n\n"
+	}
+	n.Content += transcript
+	for i := range n.Attachments {
+		if n.Attachments[i].Path == path {
+			n.Attachments[i].Transcribed = true
+		}
+	}
+	n.UpdatedAt = time.Now()
 }
 
-func RandomString(length int) string {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
-	result := make([]byte, length)
+// wordsPerMinute is the reading speed used for ReadingTime.
+const wordsPerMinute = 200
+
+// WordCount counts the whitespace-separated words in the content.
+func (n *Note) WordCount() int {
+	return len(strings.Fields(n.Content))
+}
 
-	for i := range result {
-		result[i] = charset[time.Now().UnixNano()%int64(len(charset))]
-		time.Sleep(1 * time.Nanosecond)
+// ReadingTime estimates how long the content takes to read, rounded up to
+// whole minutes.
+func (n *Note) ReadingTime() time.Duration {
+	words := n.WordCount()
+	if words == 0 {
+		return 0
 	}
+	minutes := (words + wordsPerMinute - 1) / wordsPerMinute
+	return time.Duration(minutes) * time.Minute
+}
 
-	return string(result)
+func (n *Note) SetPriority(priority Priority) {
+	n.Priority = priority
+	n.UpdatedAt = time.Now()
 }
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SubtaskPolicy decides how a task's completion follows its checklist.
+type SubtaskPolicy string
+
+const (
+	// SubtaskPolicyDefault defers to DefaultSubtaskPolicy.
+	SubtaskPolicyDefault SubtaskPolicy = ""
+	// SubtaskPolicyManual leaves completion entirely to the user.
+	SubtaskPolicyManual SubtaskPolicy = "manual"
+	// SubtaskPolicyAuto completes the task when its last subtask is
+	// checked, and reopens it when one is unchecked again.
+	SubtaskPolicyAuto SubtaskPolicy = "auto"
+	// SubtaskPolicyBlock refuses to complete the task while subtasks are
+	// open.
+	SubtaskPolicyBlock SubtaskPolicy = "block"
+)
+
+// DefaultSubtaskPolicy applies to tasks without a policy of their own. It
+// is set from the config at startup.
+var DefaultSubtaskPolicy = SubtaskPolicyManual
+
+func ParseSubtaskPolicy(s string) (SubtaskPolicy, error) {
+	switch p := SubtaskPolicy(strings.ToLower(strings.TrimSpace(s))); p {
+	case SubtaskPolicyDefault, SubtaskPolicyManual, SubtaskPolicyAuto, SubtaskPolicyBlock:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown subtask policy %q (want manual, auto or block)", s)
+	}
+}
+
+// EffectiveSubtaskPolicy is the task's own policy, or the default when it
+// has none.
+func (t *Task) EffectiveSubtaskPolicy() SubtaskPolicy {
+	if t.SubtaskPolicy != SubtaskPolicyDefault {
+		return t.SubtaskPolicy
+	}
+	if DefaultSubtaskPolicy == SubtaskPolicyDefault {
+		return SubtaskPolicyManual
+	}
+	return DefaultSubtaskPolicy
+}
+
+// NextSubtaskPolicy cycles the task's own policy through default, manual,
+// auto and block.
+func (t *Task) NextSubtaskPolicy() SubtaskPolicy {
+	switch t.SubtaskPolicy {
+	case SubtaskPolicyDefault:
+		return SubtaskPolicyManual
+	case SubtaskPolicyManual:
+		return SubtaskPolicyAuto
+	case SubtaskPolicyAuto:
+		return SubtaskPolicyBlock
+	default:
+		return SubtaskPolicyDefault
+	}
+}
+
+// applySubtaskPolicy keeps the status in line with the checklist after an
+// item changes under the auto policy.
+func (t *Task) applySubtaskPolicy() {
+	if t.EffectiveSubtaskPolicy() != SubtaskPolicyAuto || len(t.Subtasks) == 0 {
+		return
+	}
+	done, total := t.SubtaskProgress()
+	switch {
+	case done == total && t.IsOpen():
+		t.Transition(TaskStatusCompleted)
+	case done < total && t.Status == TaskStatusCompleted:
+		t.Transition(TaskStatusPending)
+	}
+}
+
+// checkSubtaskPolicy returns an error when the block policy forbids
+// completing the task.
+func (t *Task) checkSubtaskPolicy() error {
+	if t.EffectiveSubtaskPolicy() != SubtaskPolicyBlock {
+		return nil
+	}
+	if done, total := t.SubtaskProgress(); done < total {
+		return fmt.Errorf("%d of %d subtask(s) still open", total-done, total)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+type ProjectID string
+
+// Project groups related notes and tasks, e.g. "Work" or "Home".
+type Project struct {
+	ID        ProjectID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewProject(name string) *Project {
+	return &Project{
+		ID:        ProjectID(GenerateUniqueID()),
+		Name:      strings.TrimSpace(name),
+		CreatedAt: time.Now(),
+	}
+}
+
+// FindProject looks a project up by name, ignoring case.
+func FindProject(projects []*Project, name string) *Project {
+	name = strings.TrimSpace(name)
+	for _, p := range projects {
+		if strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// ProjectName returns the name of the project with the given ID, or ""
+// when it has none or the project no longer exists.
+func ProjectName(projects []*Project, id ProjectID) string {
+	if id == "" {
+		return ""
+	}
+	for _, p := range projects {
+		if p.ID == id {
+			return p.Name
+		}
+	}
+	return ""
+}
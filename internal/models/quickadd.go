@@ -0,0 +1,159 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuickAdd is a task written on one line, e.g.
+// "pay rent tomorrow 9am #finance !high remind 1d". Words that aren't a
+// date, time, tag, priority or reminder make up the title.
+type QuickAdd struct {
+	Title string
+	// Due is zero when no date or time was given
+	Due time.Time
+	// Timed is set when a time of day was given; otherwise Due is at
+	// the start of its day
+	Timed bool
+	Tags  []string
+	// Priority is zero when none was given
+	Priority Priority
+	// Remind is how long before the due time to remind, zero when not
+	// given
+	Remind time.Duration
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+var shortPriorities = map[string]Priority{
+	"l": LowPriority,
+	"m": MediumPriority,
+	"h": HighPriority,
+}
+
+// ParseQuickAdd reads a quick-add line. A time without a date is today's,
+// or tomorrow's if it has passed; a date without a time is due at the
+// start of the day. Weekdays mean the next one after today.
+func ParseQuickAdd(s string, now time.Time) (QuickAdd, error) {
+	var q QuickAdd
+	var title []string
+	var day time.Time
+	hour, minute := -1, 0
+
+	words := strings.Fields(s)
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		lower := strings.ToLower(word)
+
+		switch {
+		case len(word) > 1 && word[0] == '#':
+			q.Tags = append(q.Tags, word[1:])
+		case len(word) > 1 && word[0] == '!':
+			p, ok := shortPriorities[lower[1:]]
+			if !ok {
+				var err error
+				if p, err = ParsePriority(lower[1:]); err != nil {
+					return QuickAdd{}, err
+				}
+			}
+			q.Priority = p
+		case lower == "remind":
+			if i+1 == len(words) {
+				return QuickAdd{}, errors.New(`"remind" needs a duration, e.g. remind 1d`)
+			}
+			d, err := ParseQuickDuration(words[i+1])
+			if err != nil || d <= 0 {
+				return QuickAdd{}, fmt.Errorf("invalid duration %q", words[i+1])
+			}
+			q.Remind = d
+			i++
+		case lower == "today":
+			day = now
+		case lower == "tomorrow" || lower == "tmr":
+			day = now.AddDate(0, 0, 1)
+		case lower == "at" && i+1 < len(words) && isClock(words[i+1]):
+			// "at 9am" reads the same as "9am"
+		default:
+			if wd, ok := weekdays[lower]; ok {
+				ahead := (int(wd) - int(now.Weekday()) + 7) % 7
+				if ahead == 0 {
+					ahead = 7
+				}
+				day = now.AddDate(0, 0, ahead)
+			} else if d, err := time.ParseInLocation("2006-01-02", word, now.Location()); err == nil {
+				day = d
+			} else if h, m, ok := parseClock(lower); ok {
+				hour, minute = h, m
+			} else {
+				title = append(title, word)
+			}
+		}
+	}
+
+	q.Title = strings.Join(title, " ")
+	if q.Title == "" {
+		return QuickAdd{}, errors.New("the task needs a title")
+	}
+
+	q.Timed = hour >= 0
+	switch {
+	case !day.IsZero() && hour >= 0:
+		q.Due = time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, now.Location())
+	case !day.IsZero():
+		q.Due = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, now.Location())
+	case hour >= 0:
+		q.Due = time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+		if !q.Due.After(now) {
+			q.Due = q.Due.AddDate(0, 0, 1)
+		}
+	}
+	return q, nil
+}
+
+// ParseQuickDuration reads a duration that may also be given in days or
+// weeks, e.g. "30m", "2h", "1d", "1w" or "-2d". It is the one parser for
+// durations typed by hand; callers decide whether zero or negative values
+// make sense.
+func ParseQuickDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+func isClock(s string) bool {
+	_, _, ok := parseClock(strings.ToLower(s))
+	return ok
+}
+
+// parseClock reads a time of day such as "9am", "9:30pm" or "21:00"
+func parseClock(s string) (hour, minute int, ok bool) {
+	for _, layout := range []string{"3pm", "3:04pm", "15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.Hour(), t.Minute(), true
+		}
+	}
+	return 0, 0, false
+}
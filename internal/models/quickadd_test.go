@@ -0,0 +1,112 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	// A Wednesday afternoon
+	now := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	day := func(d, h, m int) time.Time {
+		return time.Date(2024, 5, d, h, m, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		line     string
+		title    string
+		due      time.Time
+		timed    bool
+		tags     []string
+		priority Priority
+		remind   time.Duration
+	}{
+		{line: "call mum", title: "call mum"},
+		{line: "pay rent tomorrow 9am #finance !high remind 1d", title: "pay rent", due: day(16, 9, 0), timed: true, tags: []string{"finance"}, priority: HighPriority, remind: 24 * time.Hour},
+		{line: "standup at 10:15am", title: "standup", due: day(16, 10, 15), timed: true},
+		{line: "dinner 19:00", title: "dinner", due: day(15, 19, 0), timed: true},
+		{line: "water plants today", title: "water plants", due: day(15, 0, 0)},
+		{line: "gym fri", title: "gym", due: day(17, 0, 0)},
+		{line: "review wed 3pm", title: "review", due: day(22, 15, 0), timed: true},
+		{line: "taxes 2024-06-01 !l remind 2h #home #money", title: "taxes", due: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), tags: []string{"home", "money"}, priority: LowPriority, remind: 2 * time.Hour},
+		{line: "meet at noon", title: "meet at noon"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			q, err := ParseQuickAdd(tt.line, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if q.Title != tt.title {
+				t.Errorf("title = %q, want %q", q.Title, tt.title)
+			}
+			if !q.Due.Equal(tt.due) || q.Timed != tt.timed {
+				t.Errorf("due = %v (timed %v), want %v (timed %v)", q.Due, q.Timed, tt.due, tt.timed)
+			}
+			if len(q.Tags) != len(tt.tags) {
+				t.Errorf("tags = %q, want %q", q.Tags, tt.tags)
+			} else {
+				for i := range q.Tags {
+					if q.Tags[i] != tt.tags[i] {
+						t.Errorf("tags = %q, want %q", q.Tags, tt.tags)
+						break
+					}
+				}
+			}
+			if q.Priority != tt.priority || q.Remind != tt.remind {
+				t.Errorf("priority, remind = %v, %v, want %v, %v", q.Priority, q.Remind, tt.priority, tt.remind)
+			}
+		})
+	}
+}
+
+func TestParseQuickAddTimeRollsOver(t *testing.T) {
+	now := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	q, err := ParseQuickAdd("coffee 9am", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, 5, 16, 9, 0, 0, 0, time.UTC); !q.Due.Equal(want) {
+		t.Errorf("due = %v, want %v, as 9am today has passed", q.Due, want)
+	}
+}
+
+func TestParseQuickAddErrors(t *testing.T) {
+	now := time.Date(2024, 5, 15, 14, 30, 0, 0, time.UTC)
+	for _, line := range []string{
+		"",
+		"tomorrow 9am #home",
+		"pay rent !urgent",
+		"pay rent remind",
+		"pay rent remind soon",
+		"pay rent remind 0m",
+		"pay rent remind -1d",
+	} {
+		if _, err := ParseQuickAdd(line, now); err == nil {
+			t.Errorf("ParseQuickAdd(%q) succeeded, want an error", line)
+		}
+	}
+}
+
+func TestParseQuickDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"30m", 30 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"1d", 24 * time.Hour},
+		{" 2w ", 14 * 24 * time.Hour},
+		{"-2d", -48 * time.Hour},
+		{"0d", 0},
+	}
+	for _, tt := range tests {
+		if got, err := ParseQuickDuration(tt.in); err != nil || got != tt.want {
+			t.Errorf("ParseQuickDuration(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	for _, in := range []string{"", "d", "1.5d", "soon", "1y"} {
+		if _, err := ParseQuickDuration(in); err == nil {
+			t.Errorf("ParseQuickDuration(%q) succeeded, want an error", in)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// Scope is one of the built-in task views that split the list by when
+// tasks are due. Only open tasks fall in the Today, Upcoming and Someday
+// scopes.
+type Scope int
+
+const (
+	ScopeAll Scope = iota
+	// ScopeToday holds tasks due today or already overdue, and tasks
+	// whose reminder is set for today.
+	ScopeToday
+	// ScopeUpcoming holds tasks due after today within the upcoming days.
+	ScopeUpcoming
+	// ScopeSomeday holds tasks without a due date.
+	ScopeSomeday
+)
+
+// DefaultUpcomingDays is how far ahead ScopeUpcoming looks when no span
+// is configured.
+const DefaultUpcomingDays = 7
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeToday:
+		return "Today"
+	case ScopeUpcoming:
+		return "Upcoming"
+	case ScopeSomeday:
+		return "Someday"
+	default:
+		return "All"
+	}
+}
+
+// Match reports whether the task belongs in the scope. Upcoming looks
+// upcomingDays days past today.
+func (s Scope) Match(t *Task, now time.Time, upcomingDays int) bool {
+	if s == ScopeAll {
+		return true
+	}
+	if !t.IsOpen() {
+		return false
+	}
+
+	y, m, d := now.Date()
+	tomorrow := time.Date(y, m, d+1, 0, 0, 0, 0, now.Location())
+	switch s {
+	case ScopeToday:
+		if !t.DueDate.IsZero() && t.DueDate.Before(tomorrow) {
+			return true
+		}
+		startOfDay := tomorrow.AddDate(0, 0, -1)
+		for _, at := range []time.Time{t.ReminderAt, t.CronReminder(now), t.NextCronReminder(now)} {
+			if !at.IsZero() && !at.Before(startOfDay) && at.Before(tomorrow) {
+				return true
+			}
+		}
+		return false
+	case ScopeUpcoming:
+		if upcomingDays <= 0 {
+			upcomingDays = DefaultUpcomingDays
+		}
+		end := tomorrow.AddDate(0, 0, upcomingDays)
+		return !t.DueDate.IsZero() && !t.DueDate.Before(tomorrow) && t.DueDate.Before(end)
+	case ScopeSomeday:
+		return t.DueDate.IsZero()
+	}
+	return true
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+type SmartListID string
+
+// SmartList is a named, saved filter offered as a quick view.
+type SmartList struct {
+	ID        SmartListID `json:"id"`
+	Name      string      `json:"name"`
+	Query     string      `json:"query"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// NewSmartList validates the query and returns a new smart list.
+func NewSmartList(name, query string) (*SmartList, error) {
+	if _, err := ParseFilter(query); err != nil {
+		return nil, err
+	}
+	return &SmartList{
+		ID:        SmartListID(GenerateUniqueID()),
+		Name:      name,
+		Query:     query,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (l *SmartList) Filter() (Filter, error) {
+	return ParseFilter(l.Query)
+}
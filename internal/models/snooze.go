@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// Snooze silences the task's reminders for the given duration.
+func (t *Task) Snooze(d time.Duration) {
+	t.SnoozeUntil(time.Now().Add(d))
+}
+
+// SnoozeUntil silences the task's reminders until the given time. A zero
+// time clears the snooze.
+func (t *Task) SnoozeUntil(until time.Time) {
+	t.SnoozedUntil = until
+	t.UpdatedAt = time.Now()
+}
+
+func (t *Task) IsSnoozed(now time.Time) bool {
+	return now.Before(t.SnoozedUntil)
+}
+
+// Acknowledge records that the task's reminder was seen through the named
+// channel, which stops it repeating until a new reminder time or a snooze
+// re-arms it.
+func (t *Task) Acknowledge(channel string) {
+	t.AcknowledgedAt = time.Now()
+	t.AcknowledgedVia = channel
+	t.UpdatedAt = t.AcknowledgedAt
+}
+
+// ReminderAcknowledged reports whether the current reminder has been
+// acknowledged since it was last armed.
+func (t *Task) ReminderAcknowledged() bool {
+	return t.AcknowledgedAt.After(t.ReminderAt) && t.AcknowledgedAt.After(t.SnoozedUntil)
+}
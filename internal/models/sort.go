@@ -0,0 +1,135 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TaskSort is the order of the task list.
+type TaskSort string
+
+const (
+	// TaskSortCreated keeps the oldest tasks first.
+	TaskSortCreated TaskSort = "created"
+	// TaskSortDue puts the soonest due first, undated tasks last.
+	TaskSortDue TaskSort = "due"
+	// TaskSortPriority puts high priority first, then by due date.
+	TaskSortPriority TaskSort = "priority"
+	// TaskSortUrgency puts the most urgent first.
+	TaskSortUrgency TaskSort = "urgency"
+	// TaskSortTitle is alphabetical.
+	TaskSortTitle TaskSort = "title"
+)
+
+// TaskSorts lists the task orders in the order they are cycled through.
+var TaskSorts = []TaskSort{TaskSortCreated, TaskSortDue, TaskSortPriority, TaskSortUrgency, TaskSortTitle}
+
+// NoteSort is the order of the note list.
+type NoteSort string
+
+const (
+	// NoteSortUpdated puts the most recently changed first.
+	NoteSortUpdated NoteSort = "updated"
+	// NoteSortCreated keeps the oldest notes first.
+	NoteSortCreated NoteSort = "created"
+	// NoteSortTitle is alphabetical.
+	NoteSortTitle NoteSort = "title"
+)
+
+// NoteSorts lists the note orders in the order they are cycled through.
+var NoteSorts = []NoteSort{NoteSortCreated, NoteSortUpdated, NoteSortTitle}
+
+// ParseTaskSort reads a task order; blank means created.
+func ParseTaskSort(s string) (TaskSort, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return TaskSortCreated, nil
+	}
+	for _, by := range TaskSorts {
+		if TaskSort(s) == by {
+			return by, nil
+		}
+	}
+	return "", fmt.Errorf("unknown task sort %q (want created, due, priority, urgency or title)", s)
+}
+
+// ParseNoteSort reads a note order; blank means created.
+func ParseNoteSort(s string) (NoteSort, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return NoteSortCreated, nil
+	}
+	for _, by := range NoteSorts {
+		if NoteSort(s) == by {
+			return by, nil
+		}
+	}
+	return "", fmt.Errorf("unknown note sort %q (want created, updated or title)", s)
+}
+
+// Next is the order after s in TaskSorts.
+func (s TaskSort) Next() TaskSort {
+	for i, by := range TaskSorts {
+		if by == s {
+			return TaskSorts[(i+1)%len(TaskSorts)]
+		}
+	}
+	return TaskSorts[0]
+}
+
+// Next is the order after s in NoteSorts.
+func (s NoteSort) Next() NoteSort {
+	for i, by := range NoteSorts {
+		if by == s {
+			return NoteSorts[(i+1)%len(NoteSorts)]
+		}
+	}
+	return NoteSorts[0]
+}
+
+// SortTasks orders tasks in place. Ties keep their existing order.
+func SortTasks(tasks []*Task, by TaskSort, now time.Time) {
+	var less func(a, b *Task) bool
+	switch by {
+	case TaskSortDue:
+		less = dueFirst
+	case TaskSortPriority:
+		less = func(a, b *Task) bool {
+			if a.Priority != b.Priority {
+				return a.Priority > b.Priority
+			}
+			return dueFirst(a, b)
+		}
+	case TaskSortUrgency:
+		less = func(a, b *Task) bool { return a.Urgency(now) > b.Urgency(now) }
+	case TaskSortTitle:
+		less = func(a, b *Task) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }
+	default:
+		less = func(a, b *Task) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	}
+	sort.SliceStable(tasks, func(i, j int) bool { return less(tasks[i], tasks[j]) })
+}
+
+// dueFirst orders by due date, with undated tasks last
+func dueFirst(a, b *Task) bool {
+	if a.DueDate.IsZero() || b.DueDate.IsZero() {
+		return !a.DueDate.IsZero() && b.DueDate.IsZero()
+	}
+	return a.DueDate.Before(b.DueDate)
+}
+
+// SortNotes orders notes in place. Ties keep their existing order.
+func SortNotes(notes []*Note, by NoteSort) {
+	var less func(a, b *Note) bool
+	switch by {
+	case NoteSortUpdated:
+		less = func(a, b *Note) bool { return a.UpdatedAt.After(b.UpdatedAt) }
+	case NoteSortTitle:
+		less = func(a, b *Note) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }
+	default:
+		less = func(a, b *Note) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	}
+	sort.SliceStable(notes, func(i, j int) bool { return less(notes[i], notes[j]) })
+}
@@ -0,0 +1,75 @@
+package models
+
+import "fmt"
+
+// PromoteSubtask turns a checklist item into a task of its own. The new
+// task inherits the parent's project, tags, priority and dates, links back through
+// ParentID, and the parent waits on it so it can't close before its
+// children do.
+func (t *Task) PromoteSubtask(id string) (*Task, error) {
+	for i, s := range t.Subtasks {
+		if s.ID != id {
+			continue
+		}
+		child := t.newChild(s.Title)
+		if s.Done {
+			if err := child.Complete(); err != nil {
+				return nil, err
+			}
+		}
+		t.Subtasks = append(t.Subtasks[:i], t.Subtasks[i+1:]...)
+		t.AddDependency(child.ID)
+		return child, nil
+	}
+	return nil, fmt.Errorf("no subtask %q", id)
+}
+
+// Split promotes every unchecked subtask into its own task. Checked items
+// stay on the parent's checklist as a record of what was done.
+func (t *Task) Split() []*Task {
+	var children []*Task
+	for i := 0; i < len(t.Subtasks); {
+		if t.Subtasks[i].Done {
+			i++
+			continue
+		}
+		child, _ := t.PromoteSubtask(t.Subtasks[i].ID)
+		children = append(children, child)
+	}
+	return children
+}
+
+func (t *Task) newChild(title string) *Task {
+	child := NewTask(title, "", t.DueDate)
+	child.ReminderAt = t.ReminderAt
+	child.Priority = t.Priority
+	child.Tags = append([]string(nil), t.Tags...)
+	child.ParentID = t.ID
+	child.ProjectID = t.ProjectID
+	return child
+}
+
+// ParentToComplete returns the parent of t once t was the last open piece
+// of it: every dependency is closed and the checklist is done. It returns
+// nil when the parent should stay open.
+func (t *Task) ParentToComplete(tasks map[TaskID]*Task) *Task {
+	if t.ParentID == "" || t.IsOpen() {
+		return nil
+	}
+	parent, ok := tasks[t.ParentID]
+	if !ok || !parent.IsOpen() {
+		return nil
+	}
+	for _, id := range parent.DependsOn {
+		if id == t.ID {
+			continue
+		}
+		if dep, ok := tasks[id]; ok && dep.IsOpen() {
+			return nil
+		}
+	}
+	if done, total := parent.SubtaskProgress(); done < total {
+		return nil
+	}
+	return parent
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// transitions lists where each stored status may move. Overdue never
+// appears: it is derived from the due date.
+var transitions = map[TaskStatus][]TaskStatus{
+	TaskStatusPending:    {TaskStatusInProgress, TaskStatusCompleted, TaskStatusCancelled},
+	TaskStatusInProgress: {TaskStatusPending, TaskStatusCompleted, TaskStatusCancelled},
+	TaskStatusCompleted:  {TaskStatusPending},
+	TaskStatusCancelled:  {TaskStatusPending},
+}
+
+// storedStatus is the status the transition rules apply to, reading the
+// legacy stored Overdue as Pending.
+func (t *Task) storedStatus() TaskStatus {
+	if t.Status == TaskStatusOverdue {
+		return TaskStatusPending
+	}
+	return t.Status
+}
+
+func (t *Task) CanTransition(to TaskStatus) bool {
+	for _, allowed := range transitions[t.storedStatus()] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Transition moves the task to a new status, recording when work started
+// and finished. Moving to the current status is a no-op; moving anywhere
+// else drops any custom status.
+func (t *Task) Transition(to TaskStatus) error {
+	if to == TaskStatusOverdue {
+		return errors.New("overdue follows from the due date and can't be set")
+	}
+	from := t.storedStatus()
+	if from == to {
+		t.Status = to
+		return nil
+	}
+	if !t.CanTransition(to) {
+		return fmt.Errorf("can't move a %s task to %s", from, to)
+	}
+	if to == TaskStatusCompleted {
+		if err := t.checkSubtaskPolicy(); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	switch to {
+	case TaskStatusInProgress:
+		if t.StartedAt.IsZero() {
+			t.StartedAt = now
+		}
+	case TaskStatusCompleted:
+		t.CompletedAt = now
+	case TaskStatusPending, TaskStatusCancelled:
+		t.CompletedAt = time.Time{}
+	}
+	t.Status = to
+	t.StatusLabel = ""
+	t.UpdatedAt = now
+	return nil
+}
+
+// OpenStatuses are the stored statuses of tasks that still need doing, and
+// ClosedStatuses those of tasks that don't.
+var (
+	OpenStatuses   = []TaskStatus{TaskStatusPending, TaskStatusInProgress, TaskStatusOverdue}
+	ClosedStatuses = []TaskStatus{TaskStatusCompleted, TaskStatusCancelled}
+)
+
+// IsOpen reports whether the task still needs doing.
+func (t *Task) IsOpen() bool {
+	return t.Status != TaskStatusCompleted && t.Status != TaskStatusCancelled
+}
+
+// EffectiveStatus is the status shown to users: open tasks that are past
+// due read as Overdue.
+func (t *Task) EffectiveStatus(now time.Time) TaskStatus {
+	if t.IsOpen() && !t.DueDate.IsZero() && now.After(t.DueDate) {
+		return TaskStatusOverdue
+	}
+	return t.storedStatus()
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Subtask is a checklist item inside a task.
+type Subtask struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+// AddSubtask appends a new unchecked item to the checklist.
+func (t *Task) AddSubtask(title string) *Subtask {
+	t.Subtasks = append(t.Subtasks, Subtask{ID: RandomString(8), Title: title})
+	t.UpdatedAt = time.Now()
+	return &t.Subtasks[len(t.Subtasks)-1]
+}
+
+// ToggleSubtask flips the done state of the item with the given ID and
+// reports whether it was found. Under the auto policy the task completes
+// with its last item.
+func (t *Task) ToggleSubtask(id string) bool {
+	for i := range t.Subtasks {
+		if t.Subtasks[i].ID == id {
+			t.Subtasks[i].Done = !t.Subtasks[i].Done
+			t.UpdatedAt = time.Now()
+			t.applySubtaskPolicy()
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveSubtask deletes the item with the given ID and reports whether it
+// was found.
+func (t *Task) RemoveSubtask(id string) bool {
+	for i := range t.Subtasks {
+		if t.Subtasks[i].ID == id {
+			t.Subtasks = append(t.Subtasks[:i], t.Subtasks[i+1:]...)
+			t.UpdatedAt = time.Now()
+			t.applySubtaskPolicy()
+			return true
+		}
+	}
+	return false
+}
+
+// SubtaskProgress returns how many checklist items are done out of the total.
+func (t *Task) SubtaskProgress() (done, total int) {
+	for _, s := range t.Subtasks {
+		if s.Done {
+			done++
+		}
+	}
+	return done, len(t.Subtasks)
+}
@@ -0,0 +1,24 @@
+package models
+
+import "strings"
+
+// ParseTags reads tags separated by commas, e.g. "work, #urgent". A
+// leading # is dropped, blanks are skipped and repeats kept once.
+func ParseTags(s string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// FormatTags writes tags back in the form ParseTags reads.
+func FormatTags(tags []string) string {
+	return strings.Join(tags, ", ")
+}
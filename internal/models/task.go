@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -12,9 +14,47 @@ const (
 	TaskStatusPending TaskStatus = iota
 	TaskStatusInProgress
 	TaskStatusCompleted
+	// TaskStatusOverdue is derived from the due date by EffectiveStatus.
+	// Older data files may still store it; it is treated as pending.
 	TaskStatusOverdue
+	TaskStatusCancelled
 )
 
+func (s TaskStatus) String() string {
+	switch s {
+	case TaskStatusCompleted:
+		return "Completed"
+	case TaskStatusInProgress:
+		return "In Progress"
+	case TaskStatusOverdue:
+		return "Overdue"
+	case TaskStatusCancelled:
+		return "Cancelled"
+	default:
+		return "Pending"
+	}
+}
+
+// ParseTaskStatus accepts status names as shown in the UI, case and
+// space insensitive ("in progress", "inprogress", "in-progress").
+func ParseTaskStatus(s string) (TaskStatus, error) {
+	normalized := strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(s))
+	switch normalized {
+	case "pending":
+		return TaskStatusPending, nil
+	case "inprogress":
+		return TaskStatusInProgress, nil
+	case "completed", "done":
+		return TaskStatusCompleted, nil
+	case "overdue":
+		return TaskStatusOverdue, nil
+	case "cancelled", "canceled":
+		return TaskStatusCancelled, nil
+	default:
+		return 0, fmt.Errorf("unknown status %q", s)
+	}
+}
+
 type Task struct {
 	ID          TaskID     `json:"id"`
 	Title       string     `json:"title"`
@@ -25,8 +65,54 @@ type Task struct {
 	ReminderAt  time.Time  `json:"reminder_at"`
 	Priority    Priority   `json:"priority"`
 	Status      TaskStatus `json:"status"`
-	Tags        []string   `json:"tags,omitempty"`
-	NoteID      NoteID     `json:"note_id,omitempty"`
+	// StatusLabel names the custom workflow status the task is in, if
+	// any; Status holds the built-in status it behaves as.
+	StatusLabel string    `json:"status_label,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	NoteID      NoteID    `json:"note_id,omitempty"`
+	Subtasks    []Subtask `json:"subtasks,omitempty"`
+	DependsOn   []TaskID  `json:"depends_on,omitempty"`
+	// ParentID links a task split off from a larger one back to it.
+	ParentID TaskID `json:"parent_id,omitempty"`
+	// ProjectID is the project the task belongs to, if any.
+	ProjectID ProjectID `json:"project_id,omitempty"`
+	// Context is where the task can be done, e.g. "@home" or "@errands".
+	Context string `json:"context,omitempty"`
+	// Pinned tasks are listed first.
+	Pinned bool `json:"pinned,omitempty"`
+	// Source names the integration that captured the task; empty for
+	// tasks created in the app.
+	Source string `json:"source,omitempty"`
+	// SnoozedUntil holds reminders back until it passes.
+	SnoozedUntil time.Time `json:"snoozed_until,omitempty"`
+	// AcknowledgedAt is when the reminder was last acknowledged, which
+	// stops it repeating.
+	AcknowledgedAt time.Time `json:"acknowledged_at,omitempty"`
+	// AcknowledgedVia names the channel the reminder was acknowledged
+	// through, e.g. "desktop" or "tui".
+	AcknowledgedVia string `json:"acknowledged_via,omitempty"`
+	// Escalation names the policy deciding how often the reminder
+	// repeats; empty uses the default policy.
+	Escalation string `json:"escalation,omitempty"`
+	// Cron fires the reminder on a schedule such as "0 9 * * MON"
+	// instead of at ReminderAt, for tasks without a real due date.
+	Cron string `json:"cron,omitempty"`
+	// Timezone is the IANA zone the task's times are meant in, e.g.
+	// "Europe/Berlin" for a meeting there. Empty means wherever the user
+	// is.
+	Timezone string `json:"timezone,omitempty"`
+	// Estimate is the expected effort; the actual effort is measured from
+	// StartedAt to CompletedAt.
+	Estimate    time.Duration `json:"estimate,omitempty"`
+	StartedAt   time.Time     `json:"started_at,omitempty"`
+	CompletedAt time.Time     `json:"completed_at,omitempty"`
+	// SubtaskPolicy overrides DefaultSubtaskPolicy for this task.
+	SubtaskPolicy SubtaskPolicy `json:"subtask_policy,omitempty"`
+	// Fields holds user-defined values such as a client or ticket number.
+	Fields map[string]string `json:"fields,omitempty"`
+	// ArchivedAt is when the finished task was archived, which keeps it
+	// out of the task list.
+	ArchivedAt time.Time `json:"archived_at,omitempty"`
 }
 
 func NewTask(title, description string, dueDate time.Time) *Task {
@@ -57,14 +143,22 @@ func (t *Task) SetReminderPeriod(period time.Duration) {
 	t.UpdatedAt = time.Now()
 }
 
-func (t *Task) MarkInProgress() {
-	t.Status = TaskStatusCompleted
-	t.UpdatedAt = time.Now()
+func (t *Task) MarkInProgress() error {
+	return t.Transition(TaskStatusInProgress)
 }
 
-func (t *Task) Complete() {
-	t.Status = TaskStatusCompleted
-	t.UpdatedAt = time.Now()
+func (t *Task) Complete() error {
+	return t.Transition(TaskStatusCompleted)
+}
+
+func (t *Task) Cancel() error {
+	return t.Transition(TaskStatusCancelled)
+}
+
+// Reopen moves a completed or cancelled task back to pending, keeping when
+// it was started so the actual effort keeps accumulating.
+func (t *Task) Reopen() error {
+	return t.Transition(TaskStatusPending)
 }
 
 func (t *Task) Update(title, description string, dueDate time.Time) {
@@ -77,52 +171,127 @@ func (t *Task) Update(title, description string, dueDate time.Time) {
 	t.ReminderAt = dueDate.Add(-offset)
 }
 
+// Reschedule moves the due date and keeps the reminder the same distance
+// before it. A zero due date clears both.
+func (t *Task) Reschedule(dueDate time.Time) {
+	if dueDate.IsZero() {
+		t.DueDate = time.Time{}
+		t.ReminderAt = time.Time{}
+	} else {
+		offset := t.DueDate.Sub(t.ReminderAt)
+		if t.DueDate.IsZero() || t.ReminderAt.IsZero() {
+			offset = time.Hour
+		}
+		t.DueDate = dueDate
+		t.ReminderAt = dueDate.Add(-offset)
+		if t.Status == TaskStatusOverdue && dueDate.After(time.Now()) {
+			t.Status = TaskStatusPending
+		}
+	}
+	t.UpdatedAt = time.Now()
+}
+
+// Clone returns a deep copy of the task.
+func (t *Task) Clone() *Task {
+	c := *t
+	c.Tags = append([]string(nil), t.Tags...)
+	c.Subtasks = append([]Subtask(nil), t.Subtasks...)
+	c.DependsOn = append([]TaskID(nil), t.DependsOn...)
+	if t.Fields != nil {
+		c.Fields = make(map[string]string, len(t.Fields))
+		for name, value := range t.Fields {
+			c.Fields[name] = value
+		}
+	}
+	return &c
+}
+
 func (t *Task) IsOverDue() bool {
-	return time.Now().After(t.DueDate) && t.Status != TaskStatusCompleted
+	return !t.DueDate.IsZero() && time.Now().After(t.DueDate) && t.IsOpen()
 }
 
+// UpdateStatus replaces a stored Overdue status from older data files with
+// Pending, since overdue is now derived from the due date.
 func (t *Task) UpdateStatus() {
-	if t.Status == TaskStatusCompleted {
-		return
+	if t.Status == TaskStatusOverdue {
+		t.Status = TaskStatusPending
 	}
+}
 
-	if t.IsOverDue() {
-		t.Status = TaskStatusOverdue
+func (t *Task) AddTag(tag string) {
+	for _, existingTag := range t.Tags {
+		if existingTag == tag {
+			return
+		}
 	}
+	t.Tags = append(t.Tags, tag)
+	t.UpdatedAt = time.Now()
 }
 
-
-func (t *Task) AddTag(tag string){
-  for _, existingTag := range t.Tags{
-    if existingTag == tag {
-      return
-    }
-  }
-  t.Tags = append(t.Tags, tag)
-  t.UpdatedAt = time.Now()
+func (t *Task) RemoveTag(tag string) {
+	for i, existingTag := range t.Tags {
+		if existingTag == tag {
+			t.Tags = append(t.Tags[:i], t.Tags[i+1:]...)
+			t.UpdatedAt = time.Now()
+			return
+		}
+	}
 }
 
-func (t* Task) RemoveTag(tag string){
-  for i, existingTag := range t.Tags{
-    if existingTag == tag {
-      t.Tags = append(t.Tags, t.Tags[i+1:]...)
-      t.UpdatedAt = time.Now()
-      return
-    }
-  }
+func (t *Task) SetPriority(priority Priority) {
+	t.Priority = priority
+	t.UpdatedAt = time.Now()
 }
 
+func (t *Task) SetPinned(pinned bool) {
+	t.Pinned = pinned
+	t.UpdatedAt = time.Now()
+}
 
-
-func (t *Task) SetPriority(priority Priority){
-  t.Priority = priority
-  t.UpdatedAt = time.Now()
+func (t *Task) LinkToNote(noteID NoteID) {
+	t.NoteID = noteID
+	t.UpdatedAt = time.Now()
 }
 
-func (t *Task) LinkToNote(noteID NoteID){
-  t.NoteID = noteID
-  t.UpdatedAt = time.Now()
+// ShiftTimezone keeps the wall-clock due and reminder times but re-anchors
+// them from one zone to another, e.g. a 9:00 reminder at home stays a 9:00
+// reminder after travelling.
+func (t *Task) ShiftTimezone(from, to *time.Location) {
+	t.DueDate = shiftWallClock(t.DueDate, from, to)
+	t.ReminderAt = shiftWallClock(t.ReminderAt, from, to)
+	t.UpdatedAt = time.Now()
 }
 
+// SetTimezone anchors the task's times to the named IANA zone; an empty
+// name unanchors them.
+func (t *Task) SetTimezone(name string) error {
+	if name != "" {
+		if _, err := time.LoadLocation(name); err != nil {
+			return fmt.Errorf("unknown timezone %q: %w", name, err)
+		}
+	}
+	t.Timezone = name
+	t.UpdatedAt = time.Now()
+	return nil
+}
 
+// Location is the zone the task's times are meant in: its own, or
+// time.Local when it has none.
+func (t *Task) Location() *time.Location {
+	if t.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
 
+func shiftWallClock(ts time.Time, from, to *time.Location) time.Time {
+	if ts.IsZero() {
+		return ts
+	}
+	w := ts.In(from)
+	return time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), to)
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeOfDay is a clock time without a date, such as when a task given
+// only a due date falls due.
+type TimeOfDay struct {
+	Hour, Minute int
+}
+
+// ParseTimeOfDay reads a time written "HH:MM". A blank string is
+// midnight.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return TimeOfDay{}, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("invalid time of day %q (want HH:MM)", s)
+	}
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute()}, nil
+}
+
+// On is the time on day's date, in day's location.
+func (c TimeOfDay) On(day time.Time) time.Time {
+	y, m, d := day.Date()
+	return time.Date(y, m, d, c.Hour, c.Minute, 0, 0, day.Location())
+}
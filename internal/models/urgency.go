@@ -0,0 +1,71 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Urgency coefficients, modelled on taskwarrior's defaults.
+const (
+	urgencyHigh       = 6.0
+	urgencyMedium     = 3.9
+	urgencyLow        = 1.8
+	urgencyDue        = 12.0
+	urgencyInProgress = 4.0
+	urgencyTags       = 1.0
+	urgencyAge        = 2.0
+	// urgencyMaxAge is the age at which the age term stops growing.
+	urgencyMaxAge = 365 * 24 * time.Hour
+)
+
+// Urgency scores how pressing the task is; higher is more urgent. It adds
+// up terms for priority, how close the due date is, whether work has
+// started, tags and age. Closed tasks score 0.
+func (t *Task) Urgency(now time.Time) float64 {
+	if !t.IsOpen() {
+		return 0
+	}
+	var score float64
+	switch t.Priority {
+	case HighPriority:
+		score += urgencyHigh
+	case MediumPriority:
+		score += urgencyMedium
+	case LowPriority:
+		score += urgencyLow
+	}
+	score += urgencyDue * dueFactor(t.DueDate, now)
+	if t.Status == TaskStatusInProgress {
+		score += urgencyInProgress
+	}
+	switch n := len(t.Tags); {
+	case n == 1:
+		score += 0.8 * urgencyTags
+	case n == 2:
+		score += 0.9 * urgencyTags
+	case n > 2:
+		score += urgencyTags
+	}
+	if !t.CreatedAt.IsZero() {
+		age := now.Sub(t.CreatedAt)
+		score += urgencyAge * math.Min(1, math.Max(0, float64(age)/float64(urgencyMaxAge)))
+	}
+	return math.Round(score*100) / 100
+}
+
+// dueFactor ramps from 0.2 two weeks or more before the due date to 1 a
+// week after it. Undated tasks get 0.
+func dueFactor(due, now time.Time) float64 {
+	if due.IsZero() {
+		return 0
+	}
+	days := now.Sub(due).Hours() / 24
+	switch {
+	case days >= 7:
+		return 1
+	case days >= -14:
+		return (days+14)*0.8/21 + 0.2
+	default:
+		return 0.2
+	}
+}
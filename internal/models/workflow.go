@@ -0,0 +1,193 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StatusDef is one step of the task workflow. Custom statuses such as
+// "Blocked" or "In Review" behave as one of the built-in Base statuses for
+// reminders, urgency and transitions, and are recorded on the task as its
+// StatusLabel.
+type StatusDef struct {
+	Name  string
+	Glyph string
+	// Color is a terminal color for the glyph, e.g. "196" or "#ff8800".
+	Color string
+	Base  TaskStatus
+}
+
+// Custom reports whether the status is user-defined rather than one of
+// the built-in ones.
+func (d StatusDef) Custom() bool {
+	return !strings.EqualFold(d.Name, d.Base.String())
+}
+
+// Workflow is the ordered list of statuses the TUI cycles through.
+type Workflow []StatusDef
+
+// builtinStatuses are the built-in statuses with their default glyphs.
+var builtinStatuses = []StatusDef{
+	{Name: "Pending", Glyph: " ", Base: TaskStatusPending},
+	{Name: "In Progress", Glyph: "►", Base: TaskStatusInProgress},
+	{Name: "Completed", Glyph: "✓", Base: TaskStatusCompleted},
+	{Name: "Cancelled", Glyph: "✗", Base: TaskStatusCancelled},
+}
+
+// BuiltinStatus describes a built-in status, with the glyph and color
+// the configured workflow gives it.
+func BuiltinStatus(status TaskStatus) StatusDef {
+	for _, def := range DefaultWorkflow {
+		if !def.Custom() && def.Base == status {
+			return def
+		}
+	}
+	return builtinStatuses[builtinIndex(status)]
+}
+
+// BuiltinWorkflow cycles pending, in progress and completed.
+func BuiltinWorkflow() Workflow {
+	return Workflow(builtinStatuses[:3]).clone()
+}
+
+// DefaultWorkflow is the workflow in use; the tasks.statuses config
+// setting replaces it at startup.
+var DefaultWorkflow = BuiltinWorkflow()
+
+func (w Workflow) clone() Workflow {
+	return append(Workflow(nil), w...)
+}
+
+// NewStatusDef describes a status from config. A built-in name needs no
+// base and keeps its default glyph unless one is given; a custom name
+// must say which built-in status it behaves as.
+func NewStatusDef(name, glyph, color, base string) (StatusDef, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return StatusDef{}, errors.New("status name is empty")
+	}
+
+	def := StatusDef{Name: name, Glyph: "•", Color: color}
+	if status, err := ParseTaskStatus(name); err == nil {
+		if status == TaskStatusOverdue {
+			return StatusDef{}, errors.New("overdue follows from the due date and can't be a workflow step")
+		}
+		def = builtinStatuses[builtinIndex(status)]
+		def.Color = color
+	} else {
+		if base == "" {
+			return StatusDef{}, fmt.Errorf("custom status %q needs a base status", name)
+		}
+		status, err := ParseTaskStatus(base)
+		if err != nil {
+			return StatusDef{}, err
+		}
+		if status == TaskStatusOverdue {
+			return StatusDef{}, errors.New("overdue can't be a base status")
+		}
+		def.Base = status
+	}
+	if glyph != "" {
+		def.Glyph = glyph
+	}
+	return def, nil
+}
+
+func builtinIndex(status TaskStatus) int {
+	for i, def := range builtinStatuses {
+		if def.Base == status {
+			return i
+		}
+	}
+	return 0
+}
+
+// NewWorkflow checks that no status is listed twice.
+func NewWorkflow(defs []StatusDef) (Workflow, error) {
+	if len(defs) == 0 {
+		return BuiltinWorkflow(), nil
+	}
+	seen := make(map[string]bool)
+	for _, def := range defs {
+		key := strings.ToLower(def.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("status %q is listed twice", def.Name)
+		}
+		seen[key] = true
+	}
+	return Workflow(defs).clone(), nil
+}
+
+// Find looks up a custom status by name, ignoring case.
+func (w Workflow) Find(name string) (StatusDef, bool) {
+	name = strings.TrimSpace(name)
+	for _, def := range w {
+		if def.Custom() && strings.EqualFold(def.Name, name) {
+			return def, true
+		}
+	}
+	return StatusDef{}, false
+}
+
+// Def returns how the task's status is displayed: its custom status if it
+// has one that is still configured, else its built-in status.
+func (w Workflow) Def(t *Task) StatusDef {
+	if t.StatusLabel != "" {
+		if def, ok := w.Find(t.StatusLabel); ok && def.Base == t.storedStatus() {
+			return def
+		}
+	}
+	status := t.storedStatus()
+	for _, def := range w {
+		if !def.Custom() && def.Base == status {
+			return def
+		}
+	}
+	return builtinStatuses[builtinIndex(status)]
+}
+
+// Next is the status cycled to from the task's current one, skipping
+// statuses the task can't move to directly.
+func (w Workflow) Next(t *Task) StatusDef {
+	current := w.Def(t)
+	start := -1
+	for i, def := range w {
+		if strings.EqualFold(def.Name, current.Name) {
+			start = i
+			break
+		}
+	}
+	for step := 1; step <= len(w); step++ {
+		next := w[(start+step+len(w))%len(w)]
+		if next.Base == t.storedStatus() || t.CanTransition(next.Base) {
+			return next
+		}
+	}
+	return builtinStatuses[0]
+}
+
+// SetStatus moves the task to a workflow status, recording the custom
+// status name when it has one.
+func (t *Task) SetStatus(def StatusDef) error {
+	if err := t.Transition(def.Base); err != nil {
+		return err
+	}
+	label := ""
+	if def.Custom() {
+		label = def.Name
+	}
+	t.StatusLabel = label
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// StatusName is the status shown for the task: Overdue for open tasks
+// past due, else its workflow status.
+func (t *Task) StatusName(now time.Time) string {
+	if t.EffectiveStatus(now) == TaskStatusOverdue {
+		return TaskStatusOverdue.String()
+	}
+	return DefaultWorkflow.Def(t).Name
+}
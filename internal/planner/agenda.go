@@ -0,0 +1,63 @@
+package planner
+
+import (
+	"sort"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// AgendaDay is a day of the agenda and the open tasks due on it, soonest
+// first. The overdue section has a zero Day.
+type AgendaDay struct {
+	Day   time.Time
+	Tasks []*models.Task
+}
+
+// Overdue reports whether this is the section of tasks due before the
+// agenda starts.
+func (d AgendaDay) Overdue() bool {
+	return d.Day.IsZero()
+}
+
+// Agenda lists the open tasks due on each of the next days days, starting
+// today, after a section of those due before today. Days with nothing due
+// are kept so the week reads as a whole; an empty overdue section is left
+// out.
+func Agenda(tasks []*models.Task, now time.Time, days int) []AgendaDay {
+	start := startOfDay(now)
+	overdue := AgendaDay{}
+	week := make([]AgendaDay, days)
+	for i := range week {
+		week[i].Day = start.AddDate(0, 0, i)
+	}
+
+	for _, task := range tasks {
+		if !task.IsOpen() || task.DueDate.IsZero() {
+			continue
+		}
+		due := startOfDay(task.DueDate.In(now.Location()))
+		if due.Before(start) {
+			overdue.Tasks = append(overdue.Tasks, task)
+			continue
+		}
+		for i := range week {
+			if week[i].Day.Equal(due) {
+				week[i].Tasks = append(week[i].Tasks, task)
+				break
+			}
+		}
+	}
+
+	var agenda []AgendaDay
+	if len(overdue.Tasks) > 0 {
+		agenda = append(agenda, overdue)
+	}
+	agenda = append(agenda, week...)
+	for _, day := range agenda {
+		sort.SliceStable(day.Tasks, func(i, j int) bool {
+			return day.Tasks[i].DueDate.Before(day.Tasks[j].DueDate)
+		})
+	}
+	return agenda
+}
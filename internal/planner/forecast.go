@@ -0,0 +1,45 @@
+package planner
+
+import (
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// DayLoad is the number of open tasks due on a given day and the sum of
+// their estimates.
+type DayLoad struct {
+	Day      time.Time
+	Count    int
+	Estimate time.Duration
+}
+
+// Over reports whether the day holds more tasks than capacity allows.
+func (d DayLoad) Over(capacity int) bool {
+	return d.Count > capacity
+}
+
+// Forecast counts the open tasks due on each of the next days days,
+// starting today. Closed and undated tasks are not counted.
+func Forecast(tasks []*models.Task, now time.Time, days int) []DayLoad {
+	start := startOfDay(now)
+	loads := make([]DayLoad, days)
+	for i := range loads {
+		loads[i].Day = start.AddDate(0, 0, i)
+	}
+
+	for _, task := range tasks {
+		if !task.IsOpen() || task.DueDate.IsZero() {
+			continue
+		}
+		due := startOfDay(task.DueDate.In(now.Location()))
+		for i := range loads {
+			if loads[i].Day.Equal(due) {
+				loads[i].Count++
+				loads[i].Estimate += task.Estimate
+				break
+			}
+		}
+	}
+	return loads
+}
@@ -0,0 +1,117 @@
+package planner
+
+import (
+	"sort"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// UrgentWithin is how close the due date must be for a task to count as
+// urgent; overdue tasks are always urgent.
+const UrgentWithin = 48 * time.Hour
+
+// Quadrant is a cell of the Eisenhower matrix.
+type Quadrant int
+
+const (
+	// QuadrantDo is urgent and important.
+	QuadrantDo Quadrant = iota
+	// QuadrantSchedule is important but not urgent.
+	QuadrantSchedule
+	// QuadrantDelegate is urgent but not important.
+	QuadrantDelegate
+	// QuadrantDrop is neither urgent nor important.
+	QuadrantDrop
+)
+
+func (q Quadrant) String() string {
+	switch q {
+	case QuadrantDo:
+		return "Do"
+	case QuadrantSchedule:
+		return "Schedule"
+	case QuadrantDelegate:
+		return "Delegate"
+	default:
+		return "Drop"
+	}
+}
+
+// Urgent reports whether the quadrant holds urgent tasks.
+func (q Quadrant) Urgent() bool {
+	return q == QuadrantDo || q == QuadrantDelegate
+}
+
+// Important reports whether the quadrant holds important tasks.
+func (q Quadrant) Important() bool {
+	return q == QuadrantDo || q == QuadrantSchedule
+}
+
+// QuadrantOf places a task by its priority, high being important, and
+// how soon it is due.
+func QuadrantOf(task *models.Task, now time.Time) Quadrant {
+	important := task.Priority == models.HighPriority
+	urgent := !task.DueDate.IsZero() && task.DueDate.Before(now.Add(UrgentWithin))
+	switch {
+	case urgent && important:
+		return QuadrantDo
+	case important:
+		return QuadrantSchedule
+	case urgent:
+		return QuadrantDelegate
+	default:
+		return QuadrantDrop
+	}
+}
+
+// Matrix sorts the open tasks into the four quadrants, each soonest due
+// first with undated tasks last.
+func Matrix(tasks []*models.Task, now time.Time) [4][]*models.Task {
+	var matrix [4][]*models.Task
+	for _, task := range tasks {
+		if task.IsOpen() {
+			q := QuadrantOf(task, now)
+			matrix[q] = append(matrix[q], task)
+		}
+	}
+	for _, cell := range matrix {
+		sort.SliceStable(cell, func(i, j int) bool {
+			a, b := cell[i], cell[j]
+			if a.DueDate.IsZero() != b.DueDate.IsZero() {
+				return b.DueDate.IsZero()
+			}
+			return a.DueDate.Before(b.DueDate)
+		})
+	}
+	return matrix
+}
+
+// MoveToQuadrant changes the task's priority and due date as little as
+// needed to place it in q. Making a task important raises it to high
+// priority and the reverse lowers it to medium; making it urgent brings
+// the due date forward to tomorrow and the reverse pushes it out a week.
+func MoveToQuadrant(task *models.Task, q Quadrant, now time.Time) {
+	from := QuadrantOf(task, now)
+	if q.Important() != from.Important() {
+		if q.Important() {
+			task.Priority = models.HighPriority
+		} else {
+			task.Priority = models.MediumPriority
+		}
+		task.UpdatedAt = now
+	}
+	if q.Urgent() != from.Urgent() {
+		days := 7
+		if q.Urgent() {
+			days = 1
+		}
+		// Keep the time of day the task was due at
+		due := startOfDay(now).AddDate(0, 0, days)
+		if !task.DueDate.IsZero() {
+			at := task.DueDate.In(now.Location())
+			due = time.Date(due.Year(), due.Month(), due.Day(), at.Hour(), at.Minute(), 0, 0, now.Location())
+		}
+		task.Reschedule(due)
+	}
+}
@@ -0,0 +1,118 @@
+package planner
+
+import (
+	"sort"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// DefaultDailyCapacity is used when no capacity is configured.
+const DefaultDailyCapacity = 5
+
+// AgeGroup is a bucket of overdue tasks by how long ago they were due.
+type AgeGroup struct {
+	Label string
+	Tasks []*models.Task
+}
+
+var ageBuckets = []struct {
+	label string
+	limit time.Duration
+}{
+	{"Overdue by less than a day", 24 * time.Hour},
+	{"Overdue by up to a week", 7 * 24 * time.Hour},
+	{"Overdue by up to a month", 30 * 24 * time.Hour},
+	{"Overdue by more than a month", 0},
+}
+
+// GroupOverdue buckets the overdue tasks by age, oldest bucket last. Empty
+// buckets are left out.
+func GroupOverdue(tasks []*models.Task, now time.Time) []AgeGroup {
+	groups := make([]AgeGroup, len(ageBuckets))
+	for i, b := range ageBuckets {
+		groups[i].Label = b.label
+	}
+
+	for _, task := range overdue(tasks, now) {
+		age := now.Sub(task.DueDate)
+		for i, b := range ageBuckets {
+			if b.limit == 0 || age < b.limit {
+				groups[i].Tasks = append(groups[i].Tasks, task)
+				break
+			}
+		}
+	}
+
+	var result []AgeGroup
+	for _, g := range groups {
+		if len(g.Tasks) > 0 {
+			result = append(result, g)
+		}
+	}
+	return result
+}
+
+// Proposal is a suggested new due date for an overdue task.
+type Proposal struct {
+	Task   *models.Task
+	NewDue time.Time
+}
+
+// ProposeDates spreads the overdue tasks over the coming days, starting
+// tomorrow, without putting more than capacity open tasks on any day.
+// Higher priority and older tasks get the earlier slots, and each task
+// keeps its original time of day.
+func ProposeDates(tasks []*models.Task, now time.Time, capacity int) []Proposal {
+	if capacity <= 0 {
+		capacity = DefaultDailyCapacity
+	}
+
+	loc := now.Location()
+	dayKey := func(t time.Time) string {
+		return t.In(loc).Format("2006-01-02")
+	}
+
+	load := make(map[string]int)
+	for _, task := range tasks {
+		if task.IsOpen() && !task.DueDate.IsZero() && task.DueDate.After(now) {
+			load[dayKey(task.DueDate)]++
+		}
+	}
+
+	pending := overdue(tasks, now)
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].Priority != pending[j].Priority {
+			return pending[i].Priority > pending[j].Priority
+		}
+		return pending[i].DueDate.Before(pending[j].DueDate)
+	})
+
+	proposals := make([]Proposal, 0, len(pending))
+	day := startOfDay(now).AddDate(0, 0, 1)
+	for _, task := range pending {
+		for load[dayKey(day)] >= capacity {
+			day = day.AddDate(0, 0, 1)
+		}
+		load[dayKey(day)]++
+
+		due := task.DueDate.In(loc)
+		newDue := time.Date(day.Year(), day.Month(), day.Day(), due.Hour(), due.Minute(), 0, 0, loc)
+		proposals = append(proposals, Proposal{Task: task, NewDue: newDue})
+	}
+	return proposals
+}
+
+func overdue(tasks []*models.Task, now time.Time) []*models.Task {
+	var result []*models.Task
+	for _, task := range tasks {
+		if task.IsOpen() && !task.DueDate.IsZero() && task.DueDate.Before(now) {
+			result = append(result, task)
+		}
+	}
+	return result
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
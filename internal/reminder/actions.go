@@ -0,0 +1,150 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// Action is a button on a reminder notification.
+type Action string
+
+const (
+	ActionComplete    Action = "complete"
+	ActionSnooze      Action = "snooze"
+	ActionOpen        Action = "open"
+	ActionAcknowledge Action = "acknowledge"
+)
+
+// actionWait is how long a reminder with buttons waits for a click before
+// the buttons are given up.
+const actionWait = 30 * time.Minute
+
+type actionLabel struct {
+	action Action
+	label  string
+}
+
+// actionLabels are the buttons on a reminder, with the snooze button
+// naming how long it postpones it.
+func actionLabels(snooze time.Duration) []actionLabel {
+	return []actionLabel{
+		{ActionComplete, "Complete"},
+		{ActionSnooze, "Snooze " + snoozeLabel(snooze)},
+		{ActionOpen, "Open"},
+		{ActionAcknowledge, "Got it"},
+	}
+}
+
+// ActionHandler is called with the button clicked on a task's reminder.
+// It returns a short confirmation to show, or an error.
+type ActionHandler func(id models.TaskID, action Action) (string, error)
+
+// actionsSupported reports whether reminders can carry buttons here:
+// notify-send can wait for a click on Linux and BSD, and on macOS the
+// optional alerter tool can; osascript notifications have no buttons.
+func actionsSupported() bool {
+	switch runtime.GOOS {
+	case "darwin":
+		_, err := exec.LookPath("alerter")
+		return err == nil
+	case "windows":
+		return false
+	default:
+		_, err := exec.LookPath("notify-send")
+		return err == nil
+	}
+}
+
+// actionCommand builds a notification command that blocks until it is
+// clicked or dismissed and prints which button was used.
+func actionCommand(ctx context.Context, title, body string, alert Alert, actions []actionLabel) *exec.Cmd {
+	if runtime.GOOS == "darwin" {
+		labels := make([]string, len(actions))
+		for i, a := range actions {
+			labels[i] = a.label
+		}
+		args := []string{"-title", title, "-message", body, "-actions", strings.Join(labels, ","), "-closeLabel", "Dismiss"}
+		if alert.Sound != "" {
+			args = append(args, "-sound", alert.Sound)
+		}
+		return exec.CommandContext(ctx, "alerter", args...)
+	}
+
+	args := append(notifySendArgs(alert), "--wait")
+	for _, a := range actions {
+		args = append(args, "-A", fmt.Sprintf("%s=%s", a.action, a.label))
+	}
+	return exec.CommandContext(ctx, "notify-send", append(args, title, body)...)
+}
+
+// parseAction maps what the notification tool printed to an action. A
+// click on the notification itself opens the task.
+func parseAction(output string, actions []actionLabel) (Action, bool) {
+	output = strings.TrimSpace(output)
+	if output == "@CONTENTCLICKED" || output == "default" {
+		return ActionOpen, true
+	}
+	for _, a := range actions {
+		if output == string(a.action) || output == a.label {
+			return a.action, true
+		}
+	}
+	return "", false
+}
+
+// showWithActions shows a reminder with buttons and hands the clicked one
+// to the handler in the background, until the notifier's context is done
+// or actionWait has passed. Tools too old to know about buttons fall back
+// to a plain notification.
+func (n *DesktopNotifier) showWithActions(task *models.Task, title, body string, alert Alert) error {
+	snooze := n.SnoozeFor
+	if snooze <= 0 {
+		snooze = DefaultSnooze
+	}
+	actions := actionLabels(snooze)
+
+	parent := n.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, actionWait)
+	cmd := actionCommand(ctx, title, body, alert, actions)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return fmt.Errorf("desktop notification failed: %w", err)
+	}
+
+	go func() {
+		defer cancel()
+		err := cmd.Wait()
+		// Nobody clicked in time, or the service has stopped and can't
+		// act on a click any more
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			n.show(title, body, alert)
+			return
+		}
+		action, ok := parseAction(out.String(), actions)
+		if !ok {
+			return
+		}
+		confirmation, err := n.OnAction(task.ID, action)
+		if err != nil {
+			n.show("Couldn't update "+task.Title, err.Error(), Alert{Urgency: UrgencyNormal})
+		} else if confirmation != "" {
+			n.show(confirmation, task.Title, Alert{Urgency: UrgencyLow})
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,33 @@
+package reminder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseAction(t *testing.T) {
+	actions := actionLabels(15 * time.Minute)
+	tests := []struct {
+		name   string
+		output string
+		want   Action
+		wantOK bool
+	}{
+		{"action name", "complete\n", ActionComplete, true},
+		{"button label", "Got it", ActionAcknowledge, true},
+		{"snooze label", "  Snooze " + snoozeLabel(15*time.Minute) + "\n", ActionSnooze, true},
+		{"notification clicked", "default", ActionOpen, true},
+		{"clicked on macOS", "@CONTENTCLICKED\n", ActionOpen, true},
+		{"dismissed", "", "", false},
+		{"closed on macOS", "@CLOSED", "", false},
+		{"unknown", "Dismiss", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseAction(tt.output, actions)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseAction(%q) = %q, %v, want %q, %v", tt.output, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package reminder
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+type Urgency int
+
+const (
+	UrgencyLow Urgency = iota
+	UrgencyNormal
+	UrgencyCritical
+)
+
+func (u Urgency) String() string {
+	switch u {
+	case UrgencyLow:
+		return "low"
+	case UrgencyCritical:
+		return "critical"
+	default:
+		return "normal"
+	}
+}
+
+func ParseUrgency(s string) (Urgency, error) {
+	switch s {
+	case "low":
+		return UrgencyLow, nil
+	case "normal", "":
+		return UrgencyNormal, nil
+	case "critical":
+		return UrgencyCritical, nil
+	default:
+		return UrgencyNormal, fmt.Errorf("unknown urgency %q (want low, normal or critical)", s)
+	}
+}
+
+// Alert describes how loudly a reminder is delivered. Sound is a
+// platform sound name (freedesktop theme name or file path on Linux,
+// system sound name on macOS); empty means silent.
+type Alert struct {
+	Urgency Urgency
+	Sound   string
+}
+
+// AlertMap assigns an alert style to each task priority.
+type AlertMap map[models.Priority]Alert
+
+func DefaultAlerts() AlertMap {
+	switch runtime.GOOS {
+	case "windows":
+		// Sounds are ms-winsoundevent names
+		return AlertMap{
+			models.LowPriority:    {Urgency: UrgencyLow},
+			models.MediumPriority: {Urgency: UrgencyNormal, Sound: "Notification.Default"},
+			models.HighPriority:   {Urgency: UrgencyCritical, Sound: "Notification.Reminder"},
+		}
+	case "darwin":
+		return AlertMap{
+			models.LowPriority:    {Urgency: UrgencyLow},
+			models.MediumPriority: {Urgency: UrgencyNormal, Sound: "Glass"},
+			models.HighPriority:   {Urgency: UrgencyCritical, Sound: "Sosumi"},
+		}
+	}
+	return AlertMap{
+		models.LowPriority:    {Urgency: UrgencyLow},
+		models.MediumPriority: {Urgency: UrgencyNormal, Sound: "message-new-instant"},
+		models.HighPriority:   {Urgency: UrgencyCritical, Sound: "alarm-clock-elapsed"},
+	}
+}
+
+// For returns the alert for a priority, falling back to the defaults for
+// priorities the map doesn't configure.
+func (a AlertMap) For(priority models.Priority) Alert {
+	if alert, ok := a[priority]; ok {
+		return alert
+	}
+	if alert, ok := DefaultAlerts()[priority]; ok {
+		return alert
+	}
+	return Alert{Urgency: UrgencyNormal}
+}
@@ -0,0 +1,48 @@
+package reminder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// pendingReminder is a reminder a check is about to send and when it was
+// scheduled for.
+type pendingReminder struct {
+	task      *models.Task
+	scheduled time.Time
+}
+
+// SetBatchWindow coalesces reminders falling within window of each other
+// into one notification listing every task, instead of a burst of
+// separate ones. Reminders coming up within the window are sent early to
+// join those due.
+func (r *ReminderService) SetBatchWindow(window time.Duration) {
+	r.batchWindow = window
+}
+
+// sendBatch delivers the reminders as one digest, logging and retrying
+// each task on every channel it went through.
+func (r *ReminderService) sendBatch(batch []pendingReminder, now time.Time) CheckResult {
+	tasks := make([]*models.Task, len(batch))
+	scheduled := make(map[models.TaskID]time.Time, len(batch))
+	for i, p := range batch {
+		tasks[i] = p.task
+		scheduled[p.task.ID] = p.scheduled
+	}
+
+	digest := Digest{Title: fmt.Sprintf("%d reminders", len(batch)), Tasks: tasks}
+	failed := make(map[models.TaskID]bool)
+	for _, result := range SendDigestChannels(r.notifier, digest, r.channel) {
+		for _, task := range result.Tasks {
+			at := scheduled[task.ID]
+			r.recordDelivery(task, result.Channel, at, result.Err, r.lateReason(at, now))
+			r.settle(task, result.Channel, at, result.Err, now)
+			if result.Err != nil {
+				failed[task.ID] = true
+			}
+		}
+	}
+	return CheckResult{Sent: len(batch), Failed: len(failed)}
+}
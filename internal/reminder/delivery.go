@@ -0,0 +1,203 @@
+package reminder
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// LateAfter is how far past its scheduled time a reminder counts as
+// late. The service checks every minute, so a minute or so is expected.
+const LateAfter = 5 * time.Minute
+
+// DeliveryPath is where every reminder delivery is logged, one JSON object
+// per line.
+func DeliveryPath(dataDir string) string {
+	return filepath.Join(dataDir, "deliveries.log")
+}
+
+// Delivery records one reminder: when it was meant to go out, when it did
+// and through which channel. Error is set when the channel failed; Reason
+// explains a late delivery when the cause is known.
+type Delivery struct {
+	TaskID    models.TaskID `json:"task_id"`
+	Title     string        `json:"title"`
+	Channel   string        `json:"channel"`
+	Scheduled time.Time     `json:"scheduled"`
+	Delivered time.Time     `json:"delivered"`
+	Error     string        `json:"error,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+}
+
+// Latency is how long after its scheduled time the reminder went out.
+func (d Delivery) Latency() time.Duration {
+	return d.Delivered.Sub(d.Scheduled)
+}
+
+func (d Delivery) Late() bool {
+	return d.Latency() > LateAfter
+}
+
+func (d Delivery) Failed() bool {
+	return d.Error != ""
+}
+
+var deliveryMu sync.Mutex
+
+// RecordDelivery appends d to the log at path.
+func RecordDelivery(path string, d Delivery) error {
+	line, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to marshal delivery: %w", err)
+	}
+	deliveryMu.Lock()
+	defer deliveryMu.Unlock()
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open delivery log: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write delivery log: %w", err)
+	}
+	return nil
+}
+
+// LoadDeliveries reads the deliveries made since the given time, oldest
+// first. Lines that don't parse are skipped.
+func LoadDeliveries(path string, since time.Time) ([]Delivery, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open delivery log: %w", err)
+	}
+	defer file.Close()
+
+	var deliveries []Delivery
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var d Delivery
+		if json.Unmarshal(scanner.Bytes(), &d) != nil || d.Delivered.Before(since) {
+			continue
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read delivery log: %w", err)
+	}
+	return deliveries, nil
+}
+
+// LastReminders picks out each task's most recent reminder: one delivery
+// per channel it went out through.
+func LastReminders(deliveries []Delivery) map[models.TaskID][]Delivery {
+	last := make(map[models.TaskID][]Delivery)
+	for _, d := range deliveries {
+		prev := last[d.TaskID]
+		switch {
+		case len(prev) == 0 || d.Scheduled.After(prev[0].Scheduled):
+			last[d.TaskID] = []Delivery{d}
+		case d.Scheduled.Equal(prev[0].Scheduled):
+			// A later attempt through the same channel replaces the earlier
+			replaced := false
+			for i := range prev {
+				if prev[i].Channel == d.Channel {
+					prev[i] = d
+					replaced = true
+				}
+			}
+			if !replaced {
+				last[d.TaskID] = append(prev, d)
+			}
+		}
+	}
+	return last
+}
+
+// DeliveryReport summarises the deliveries over a period.
+type DeliveryReport struct {
+	Since     time.Time
+	Delivered int
+	Late      int
+	Failed    int
+	Median    time.Duration
+	P95       time.Duration
+	// Problems are the late and failed deliveries, most recent first.
+	Problems []Delivery
+}
+
+func SummarizeDeliveries(deliveries []Delivery, since time.Time) DeliveryReport {
+	r := DeliveryReport{Since: since}
+	var latencies []time.Duration
+	for _, d := range deliveries {
+		if d.Failed() {
+			r.Failed++
+		} else {
+			r.Delivered++
+			latencies = append(latencies, d.Latency())
+			if d.Late() {
+				r.Late++
+			}
+		}
+		if d.Failed() || d.Late() {
+			r.Problems = append(r.Problems, d)
+		}
+	}
+	if len(latencies) > 0 {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		r.Median = latencies[(len(latencies)-1)/2]
+		r.P95 = latencies[(len(latencies)*95)/100]
+	}
+	sort.SliceStable(r.Problems, func(i, j int) bool {
+		return r.Problems[i].Delivered.After(r.Problems[j].Delivered)
+	})
+	return r
+}
+
+// Text renders the report as plain text.
+func (r DeliveryReport) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Reminders since %s\n", r.Since.Format("Jan 2 15:04"))
+	if r.Delivered+r.Failed == 0 {
+		b.WriteString("No reminders delivered.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%d delivered, %d late (over %s), %d failed\n", r.Delivered, r.Late, LateAfter, r.Failed)
+	if r.Delivered > 0 {
+		fmt.Fprintf(&b, "Latency: median %s, 95th percentile %s\n", roundLatency(r.Median), roundLatency(r.P95))
+	}
+	if len(r.Problems) == 0 {
+		return b.String()
+	}
+	b.WriteString("\n")
+	for _, d := range r.Problems {
+		fmt.Fprintf(&b, "%s  %-8s %s\n", d.Delivered.Format("Jan 2 15:04"), d.Channel, d.Title)
+		if d.Failed() {
+			fmt.Fprintf(&b, "    failed: %s\n", d.Error)
+		} else {
+			fmt.Fprintf(&b, "    %s late", roundLatency(d.Latency()))
+			if d.Reason != "" {
+				fmt.Fprintf(&b, ": %s", d.Reason)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func roundLatency(d time.Duration) time.Duration {
+	if d < time.Minute {
+		return d.Round(time.Second)
+	}
+	return d.Round(time.Minute)
+}
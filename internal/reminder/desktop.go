@@ -0,0 +1,155 @@
+package reminder
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// DesktopNotifier shows reminders as native desktop notifications using
+// notify-send on Linux/BSD, osascript on macOS and toast notifications
+// through PowerShell on Windows.
+type DesktopNotifier struct {
+	Alerts AlertMap
+	// OnAction, when set, adds Complete, Snooze, Open and Got it buttons
+	// to reminders where the platform supports them.
+	OnAction ActionHandler
+	// Context bounds how long reminders with buttons wait for a click;
+	// clicks after it is done are ignored.
+	Context context.Context
+	// SnoozeFor is how long the Snooze button postpones a reminder;
+	// DefaultSnooze when zero.
+	SnoozeFor time.Duration
+	// Template, when set, renders the notification body in place of the
+	// due time.
+	Template *template.Template
+}
+
+func (n *DesktopNotifier) Notify(task *models.Task) error {
+	title := "Reminder: " + task.Title
+	body := "Due " + task.DueDate.Format("Jan 2, 2006 at 3:04 PM")
+	if n.Template != nil {
+		var err error
+		if body, err = renderMessage(n.Template, task); err != nil {
+			return err
+		}
+	}
+	if n.OnAction != nil && actionsSupported() {
+		return n.showWithActions(task, title, body, n.Alerts.For(task.Priority))
+	}
+	return n.show(title, body, n.Alerts.For(task.Priority))
+}
+
+// NotifyDigest shows one notification listing every task in the digest,
+// as loud as its most urgent task.
+func (n *DesktopNotifier) NotifyDigest(d Digest) error {
+	alert := Alert{Urgency: UrgencyLow}
+	lines := make([]string, len(d.Tasks))
+	for i, task := range d.Tasks {
+		if a := n.Alerts.For(task.Priority); a.Urgency > alert.Urgency {
+			alert = a
+		}
+		lines[i] = "• " + task.Title
+	}
+	return n.show(d.Title, strings.Join(lines, "\n"), alert)
+}
+
+func (n *DesktopNotifier) show(title, body string, alert Alert) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(title))
+		if alert.Sound != "" {
+			script += " sound name " + appleScriptString(alert.Sound)
+		}
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", toastScript(title, body, alert))
+	default:
+		cmd = exec.Command("notify-send", append(notifySendArgs(alert), title, body)...)
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("desktop notification failed: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func notifySendArgs(alert Alert) []string {
+	args := []string{"-a", "notes", "-u", alert.Urgency.String()}
+	if alert.Sound != "" {
+		hint := "string:sound-name:" + alert.Sound
+		if strings.Contains(alert.Sound, "/") {
+			hint = "string:sound-file:" + alert.Sound
+		}
+		args = append(args, "-h", hint)
+	}
+	return args
+}
+
+// DesktopTool is the helper program desktop notifications need on this
+// platform.
+func DesktopTool() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "osascript"
+	case "windows":
+		return "powershell"
+	default:
+		return "notify-send"
+	}
+}
+
+// toastAppID is the PowerShell application ID. Toasts must come from a
+// registered app, and unpackaged programs can't register their own.
+const toastAppID = `{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe`
+
+// toastScript builds a PowerShell script showing a Windows toast. Critical
+// reminders use the reminder scenario, which stays on screen until
+// dismissed.
+func toastScript(title, body string, alert Alert) string {
+	scenario := ""
+	if alert.Urgency == UrgencyCritical {
+		scenario = ` scenario="reminder"`
+	}
+	var audio string
+	switch {
+	case alert.Sound == "none" || (alert.Sound == "" && alert.Urgency == UrgencyLow):
+		audio = `<audio silent="true"/>`
+	case alert.Sound != "" && !strings.ContainsAny(alert.Sound, `/\`):
+		audio = `<audio src="ms-winsoundevent:` + xmlString(alert.Sound) + `"/>`
+	}
+	toast := fmt.Sprintf(`<toast%s><visual><binding template="ToastGeneric"><text>%s</text><text>%s</text></binding></visual>%s</toast>`,
+		scenario, xmlString(title), xmlString(body), audio)
+
+	return strings.Join([]string{
+		`[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null`,
+		`[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] > $null`,
+		`$xml = New-Object Windows.Data.Xml.Dom.XmlDocument`,
+		`$xml.LoadXml(` + powerShellString(toast) + `)`,
+		`$toast = New-Object Windows.UI.Notifications.ToastNotification $xml`,
+		`[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier(` + powerShellString(toastAppID) + `).Show($toast)`,
+	}, "; ")
+}
+
+func xmlString(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func powerShellString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,60 @@
+package reminder
+
+import "github.com/san-kum/reminder-tui/internal/models"
+
+// Digest summarises several reminders in one notification.
+type Digest struct {
+	Title string
+	Tasks []*models.Task
+}
+
+// DigestNotifier is implemented by notifiers that can deliver a digest as
+// a single notification.
+type DigestNotifier interface {
+	NotifyDigest(d Digest) error
+}
+
+// SendDigest delivers the digest through n, falling back to one
+// notification per task for notifiers without digest support.
+func SendDigest(n Notifier, d Digest) error {
+	if dn, ok := n.(DigestNotifier); ok {
+		return dn.NotifyDigest(d)
+	}
+	for _, task := range d.Tasks {
+		if err := n.Notify(task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DigestResult is how delivering a digest through one channel went, with
+// the tasks of the digest routed to that channel.
+type DigestResult struct {
+	Channel string
+	Tasks   []*models.Task
+	Err     error
+}
+
+// ChannelDigestNotifier is implemented by notifiers that deliver digests
+// through several channels and can say how each fared.
+type ChannelDigestNotifier interface {
+	NotifyDigestChannels(d Digest) []DigestResult
+}
+
+// SendDigestChannels delivers the digest through n, reporting per channel
+// where n can. Results without a channel name are reported under
+// fallback.
+func SendDigestChannels(n Notifier, d Digest, fallback string) []DigestResult {
+	cn, ok := n.(ChannelDigestNotifier)
+	if !ok {
+		return []DigestResult{{Channel: fallback, Tasks: d.Tasks, Err: SendDigest(n, d)}}
+	}
+	results := cn.NotifyDigestChannels(d)
+	for i := range results {
+		if results[i].Channel == "" {
+			results[i].Channel = fallback
+		}
+	}
+	return results
+}
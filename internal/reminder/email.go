@@ -0,0 +1,121 @@
+package reminder
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// emailTimeout bounds a whole SMTP conversation.
+const emailTimeout = 30 * time.Second
+
+// EmailNotifier mails reminders through an SMTP server, upgrading the
+// connection with STARTTLS when the server offers it.
+type EmailNotifier struct {
+	// Server is the SMTP server as host:port, e.g. smtp.example.com:587.
+	Server string
+	// Username and Password log in with PLAIN auth when Username is set.
+	Username string
+	Password string
+	From     string
+	To       []string
+	// Template, when set, renders the body in place of the due time.
+	Template *template.Template
+}
+
+func (n *EmailNotifier) Notify(task *models.Task) error {
+	body := "Due " + task.DueDate.Format("Jan 2, 2006 at 3:04 PM")
+	if task.DueDate.IsZero() {
+		body = "No due date"
+	}
+	if n.Template != nil {
+		var err error
+		if body, err = renderMessage(n.Template, task); err != nil {
+			return err
+		}
+	}
+	return n.send("Reminder: "+task.Title, body)
+}
+
+// NotifyDigest mails one message listing every task.
+func (n *EmailNotifier) NotifyDigest(d Digest) error {
+	lines := make([]string, len(d.Tasks))
+	for i, task := range d.Tasks {
+		lines[i] = "- " + task.Title
+		if !task.DueDate.IsZero() {
+			lines[i] += " (due " + task.DueDate.Format("Jan 2, 2006 at 3:04 PM") + ")"
+		}
+	}
+	return n.send(d.Title, strings.Join(lines, "\n"))
+}
+
+func (n *EmailNotifier) send(subject, body string) error {
+	host, _, err := net.SplitHostPort(n.Server)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP server %q: %w", n.Server, err)
+	}
+	conn, err := net.DialTimeout("tcp", n.Server, emailTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", n.Server, err)
+	}
+	conn.SetDeadline(time.Now().Add(emailTimeout))
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to talk to %s: %w", n.Server, err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if n.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", n.Username, n.Password, host)); err != nil {
+			return fmt.Errorf("failed to log in to %s: %w", n.Server, err)
+		}
+	}
+	if err := c.Mail(n.From); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	for _, to := range n.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("failed to send email to %s: %w", to, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if _, err := w.Write(n.message(subject, body, time.Now())); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return c.Quit()
+}
+
+// message formats a plain text email.
+func (n *EmailNotifier) message(subject, body string, now time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", n.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
@@ -0,0 +1,107 @@
+package reminder
+
+import (
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// Escalation decides how often a reminder repeats until the task is
+// acknowledged or completed: more often as the due time approaches, and
+// on and on once the task is overdue.
+type Escalation struct {
+	// Every is how often the reminder repeats while the due time is far
+	// off; zero sends it once.
+	Every time.Duration
+	// Before repeats it at a shorter interval within some time of the due
+	// time. The step with the shortest Within that still applies wins.
+	Before []EscalationStep
+	// Overdue is how often an overdue task is nagged; zero stops repeating
+	// at the due time.
+	Overdue time.Duration
+}
+
+// EscalationStep repeats a reminder Every so often once the task is due
+// Within some time.
+type EscalationStep struct {
+	Within time.Duration
+	Every  time.Duration
+}
+
+// DefaultEscalation repeats a reminder every 6 hours, every 2 hours on the
+// day it is due and every 15 minutes in the last hour, then hourly while
+// it is overdue.
+var DefaultEscalation = Escalation{
+	Every: 6 * time.Hour,
+	Before: []EscalationStep{
+		{Within: 24 * time.Hour, Every: 2 * time.Hour},
+		{Within: time.Hour, Every: 15 * time.Minute},
+	},
+	Overdue: time.Hour,
+}
+
+// DefaultEscalationName is the policy used by tasks that don't name one.
+const DefaultEscalationName = "default"
+
+// EscalationPolicies holds the escalation policies by name.
+type EscalationPolicies map[string]Escalation
+
+// For returns the policy the task names, falling back to the "default"
+// policy and then DefaultEscalation.
+func (p EscalationPolicies) For(task *models.Task) Escalation {
+	if e, ok := p[task.Escalation]; ok && task.Escalation != "" {
+		return e
+	}
+	if e, ok := p[DefaultEscalationName]; ok {
+		return e
+	}
+	return DefaultEscalation
+}
+
+// Next is when a reminder for task last sent at sent should go out again,
+// or the zero time if it shouldn't repeat.
+func (e Escalation) Next(task *models.Task, sent time.Time) time.Time {
+	var next time.Time
+	if every := e.interval(task, sent); every > 0 {
+		next = sent.Add(every)
+	}
+	if task.DueDate.IsZero() {
+		return next
+	}
+
+	// Moving into a tighter step, or becoming overdue, repeats the
+	// reminder straight away rather than at the end of the longer interval
+	earlier := func(at time.Time) {
+		if at.After(sent) && (next.IsZero() || at.Before(next)) {
+			next = at
+		}
+	}
+	for _, step := range e.Before {
+		if step.Every > 0 {
+			earlier(task.DueDate.Add(-step.Within))
+		}
+	}
+	if e.Overdue > 0 {
+		earlier(task.DueDate)
+	}
+	return next
+}
+
+// interval is how often the reminder repeats at the given time
+func (e Escalation) interval(task *models.Task, at time.Time) time.Duration {
+	if task.DueDate.IsZero() {
+		return e.Every
+	}
+	left := task.DueDate.Sub(at)
+	if left <= 0 {
+		return e.Overdue
+	}
+	every := e.Every
+	var within time.Duration
+	for _, step := range e.Before {
+		if left <= step.Within && (within == 0 || step.Within < within) {
+			every, within = step.Every, step.Within
+		}
+	}
+	return every
+}
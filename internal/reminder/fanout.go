@@ -0,0 +1,164 @@
+package reminder
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// Channel is a notifier under the name it is configured by.
+type Channel struct {
+	Name     string
+	Notifier Notifier
+}
+
+// Route sends reminders to its channels for tasks of one of the listed
+// priorities and carrying one of the listed tags. An empty list doesn't
+// narrow the route down.
+type Route struct {
+	Priorities []models.Priority
+	Tags       []string
+	Channels   []Channel
+}
+
+func (r Route) matches(task *models.Task) bool {
+	if len(r.Priorities) > 0 && !slices.Contains(r.Priorities, task.Priority) {
+		return false
+	}
+	if len(r.Tags) > 0 && !slices.ContainsFunc(task.Tags, func(tag string) bool {
+		return slices.Contains(r.Tags, tag)
+	}) {
+		return false
+	}
+	return true
+}
+
+// ChannelResult is how delivering a reminder through one channel went.
+type ChannelResult struct {
+	Channel string
+	Err     error
+}
+
+// ChannelNotifier is implemented by notifiers that deliver through
+// several channels and can say how each fared, so deliveries are logged
+// and retried per channel.
+type ChannelNotifier interface {
+	NotifyChannels(task *models.Task) []ChannelResult
+	// NotifyChannel delivers through the named channel alone.
+	NotifyChannel(task *models.Task, channel string) error
+}
+
+// NotifyChannels delivers the reminder through n. Results without a
+// channel name, as from notifiers that can't report per channel, are
+// reported under fallback.
+func NotifyChannels(n Notifier, task *models.Task, fallback string) []ChannelResult {
+	cn, ok := n.(ChannelNotifier)
+	if !ok {
+		return []ChannelResult{{Channel: fallback, Err: n.Notify(task)}}
+	}
+	results := cn.NotifyChannels(task)
+	for i := range results {
+		if results[i].Channel == "" {
+			results[i].Channel = fallback
+		}
+	}
+	return results
+}
+
+// NotifyChannel delivers the reminder through the named channel of n, or
+// through n as a whole when it has no channels.
+func NotifyChannel(n Notifier, task *models.Task, channel string) error {
+	if cn, ok := n.(ChannelNotifier); ok {
+		return cn.NotifyChannel(task, channel)
+	}
+	return n.Notify(task)
+}
+
+// FanOut delivers each reminder through every channel of the first route
+// that matches its task, or through Default when none does. A failing
+// channel doesn't keep the others from being tried.
+type FanOut struct {
+	Routes  []Route
+	Default []Channel
+}
+
+func (f *FanOut) channelsFor(task *models.Task) []Channel {
+	for _, route := range f.Routes {
+		if route.matches(task) {
+			return route.Channels
+		}
+	}
+	return f.Default
+}
+
+func (f *FanOut) Notify(task *models.Task) error {
+	var errs []error
+	for _, result := range f.NotifyChannels(task) {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Channel, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f *FanOut) NotifyChannels(task *models.Task) []ChannelResult {
+	channels := f.channelsFor(task)
+	results := make([]ChannelResult, len(channels))
+	for i, ch := range channels {
+		results[i] = ChannelResult{Channel: ch.Name, Err: ch.Notifier.Notify(task)}
+	}
+	return results
+}
+
+// NotifyChannel delivers through the channel of that name, whichever
+// route it is on.
+func (f *FanOut) NotifyChannel(task *models.Task, channel string) error {
+	for _, ch := range f.Default {
+		if ch.Name == channel {
+			return ch.Notifier.Notify(task)
+		}
+	}
+	for _, route := range f.Routes {
+		for _, ch := range route.Channels {
+			if ch.Name == channel {
+				return ch.Notifier.Notify(task)
+			}
+		}
+	}
+	return fmt.Errorf("no channel named %q", channel)
+}
+
+// NotifyDigest sends each channel one digest of the tasks routed to it.
+func (f *FanOut) NotifyDigest(d Digest) error {
+	var errs []error
+	for _, result := range f.NotifyDigestChannels(d) {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Channel, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f *FanOut) NotifyDigestChannels(d Digest) []DigestResult {
+	var order []string
+	byName := make(map[string]Channel)
+	tasks := make(map[string][]*models.Task)
+	for _, task := range d.Tasks {
+		for _, ch := range f.channelsFor(task) {
+			if _, seen := byName[ch.Name]; !seen {
+				byName[ch.Name] = ch
+				order = append(order, ch.Name)
+			}
+			tasks[ch.Name] = append(tasks[ch.Name], task)
+		}
+	}
+
+	results := make([]DigestResult, len(order))
+	for i, name := range order {
+		digest := Digest{Title: d.Title, Tasks: tasks[name]}
+		results[i] = DigestResult{Channel: name, Tasks: tasks[name], Err: SendDigest(byName[name].Notifier, digest)}
+	}
+	return results
+}
@@ -0,0 +1,124 @@
+package reminder
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// failNotifier fails every delivery.
+type failNotifier struct{}
+
+func (failNotifier) Notify(task *models.Task) error {
+	return errors.New("unreachable")
+}
+
+func newFanOut() (*FanOut, map[string]*recordNotifier) {
+	rec := map[string]*recordNotifier{
+		"console": {}, "desktop": {}, "slack": {}, "ntfy": {},
+	}
+	ch := func(name string) Channel { return Channel{Name: name, Notifier: rec[name]} }
+	return &FanOut{
+		Routes: []Route{
+			{Priorities: []models.Priority{models.HighPriority}, Tags: []string{"work", "oncall"}, Channels: []Channel{ch("desktop"), ch("slack")}},
+			{Priorities: []models.Priority{models.HighPriority}, Channels: []Channel{ch("ntfy")}},
+		},
+		Default: []Channel{ch("console")},
+	}, rec
+}
+
+func TestFanOutRoutes(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority models.Priority
+		tags     []string
+		want     []string
+	}{
+		{"first matching route wins", models.HighPriority, []string{"oncall"}, []string{"desktop", "slack"}},
+		{"tag must match too", models.HighPriority, []string{"home"}, []string{"ntfy"}},
+		{"no route matches", models.LowPriority, []string{"work"}, []string{"console"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, rec := newFanOut()
+			task := models.NewTask("Deploy", "", time.Now())
+			task.Priority = tt.priority
+			task.Tags = tt.tags
+
+			if err := f.Notify(task); err != nil {
+				t.Fatal(err)
+			}
+			for name, n := range rec {
+				want := 0
+				for _, w := range tt.want {
+					if w == name {
+						want = 1
+					}
+				}
+				if n.count() != want {
+					t.Errorf("%s got %d reminder(s), want %d", name, n.count(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestFanOutFailingChannel(t *testing.T) {
+	ok := &recordNotifier{}
+	f := &FanOut{Default: []Channel{
+		{Name: "slack", Notifier: failNotifier{}},
+		{Name: "console", Notifier: ok},
+	}}
+	task := models.NewTask("Deploy", "", time.Now())
+
+	results := NotifyChannels(f, task, "fallback")
+	if len(results) != 2 || results[0].Channel != "slack" || results[0].Err == nil || results[1].Channel != "console" || results[1].Err != nil {
+		t.Errorf("results = %+v, want slack failed and console delivered", results)
+	}
+	if ok.count() != 1 {
+		t.Errorf("console got %d reminder(s) after slack failed, want 1", ok.count())
+	}
+	if err := f.Notify(task); err == nil || !strings.Contains(err.Error(), "slack: unreachable") {
+		t.Errorf("Notify = %v, want the slack failure", err)
+	}
+}
+
+func TestFanOutNotifyChannel(t *testing.T) {
+	f, rec := newFanOut()
+	task := models.NewTask("Deploy", "", time.Now())
+
+	// Retries go to the channel that failed, even off the task's route
+	if err := NotifyChannel(f, task, "ntfy"); err != nil {
+		t.Fatal(err)
+	}
+	if rec["ntfy"].count() != 1 || rec["console"].count() != 0 {
+		t.Errorf("ntfy, console got %d, %d, want 1, 0", rec["ntfy"].count(), rec["console"].count())
+	}
+	if err := NotifyChannel(f, task, "email"); err == nil {
+		t.Error("NotifyChannel to an unknown channel succeeded")
+	}
+}
+
+func TestFanOutDigest(t *testing.T) {
+	f, rec := newFanOut()
+	urgent := models.NewTask("Page", "", time.Now())
+	urgent.Priority = models.HighPriority
+	routine := models.NewTask("Water plants", "", time.Now())
+	routine.Priority = models.LowPriority
+	other := models.NewTask("Read", "", time.Now())
+	other.Priority = models.LowPriority
+
+	results := f.NotifyDigestChannels(Digest{Title: "Overdue", Tasks: []*models.Task{urgent, routine, other}})
+	if len(results) != 2 || results[0].Channel != "ntfy" || results[1].Channel != "console" {
+		t.Fatalf("results = %+v, want ntfy then console", results)
+	}
+	if len(results[0].Tasks) != 1 || len(results[1].Tasks) != 2 {
+		t.Errorf("ntfy got %d task(s), console %d, want 1 and 2", len(results[0].Tasks), len(results[1].Tasks))
+	}
+	if rec["ntfy"].count() != 1 || rec["console"].count() != 2 || rec["slack"].count() != 0 {
+		t.Errorf("ntfy, console, slack got %d, %d, %d, want 1, 2, 0", rec["ntfy"].count(), rec["console"].count(), rec["slack"].count())
+	}
+}
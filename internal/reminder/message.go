@@ -0,0 +1,96 @@
+package reminder
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// Message is what notification templates are rendered with: every field
+// of the task, such as .Title, .DueDate and .Priority, plus a few ready to
+// print.
+type Message struct {
+	*models.Task
+	// Due is the formatted due time, empty for undated tasks.
+	Due string
+	// Emoji is the Slack emoji code for the priority.
+	Emoji string
+}
+
+func newMessage(task *models.Task) Message {
+	msg := Message{Task: task, Emoji: priorityEmoji[task.Priority]}
+	if !task.DueDate.IsZero() {
+		msg.Due = task.DueDate.Format("Jan 2 at 3:04 PM")
+	}
+	return msg
+}
+
+// templateFuncs are available in every notification template.
+var templateFuncs = template.FuncMap{
+	"humanize": Humanize,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+}
+
+// ParseMessageTemplate parses a notification template such as
+// `{{.Title}} due {{.DueDate | humanize}} [{{.Priority}}]`. Empty text
+// returns nil, leaving the notifier's own wording.
+func ParseMessageTemplate(name, text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+// renderMessage executes tmpl for the task.
+func renderMessage(tmpl *template.Template, task *models.Task) (string, error) {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, newMessage(task)); err != nil {
+		return "", fmt.Errorf("failed to render %s message: %w", tmpl.Name(), err)
+	}
+	return b.String(), nil
+}
+
+// Humanize describes a time relative to now, e.g. "in 2 hours" or
+// "3 days ago".
+func Humanize(t time.Time) string {
+	if t.IsZero() {
+		return "someday"
+	}
+	d := time.Until(t)
+	ago := d < 0
+	if ago {
+		d = -d
+	}
+
+	var amount string
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		amount = plural(int(d/time.Minute), "minute")
+	case d < 48*time.Hour:
+		amount = plural(int(d/time.Hour), "hour")
+	default:
+		amount = plural(int(d/(24*time.Hour)), "day")
+	}
+	if ago {
+		return amount + " ago"
+	}
+	return "in " + amount
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
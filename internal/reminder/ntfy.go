@@ -0,0 +1,127 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// DefaultNtfyServer is used when no server is configured.
+const DefaultNtfyServer = "https://ntfy.sh"
+
+// defaultNtfyPriorities maps task priorities to ntfy's 1 (min) to 5
+// (max) scale.
+var defaultNtfyPriorities = map[models.Priority]int{
+	models.LowPriority:    2,
+	models.MediumPriority: 3,
+	models.HighPriority:   4,
+}
+
+// NtfyNotifier publishes reminders to an ntfy topic, on ntfy.sh or a
+// self-hosted server.
+type NtfyNotifier struct {
+	Server string
+	Topic  string
+	// Token is an access token for protected topics, if any.
+	Token string
+	// Priorities overrides the ntfy priority per task priority.
+	Priorities map[models.Priority]int
+	// Template, when set, renders the message body in place of the due
+	// time.
+	Template *template.Template
+}
+
+// ParseNtfyPriority accepts ntfy's priority names (min, low, default,
+// high, max or urgent) or numbers 1 to 5.
+func ParseNtfyPriority(s string) (int, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "min":
+		return 1, nil
+	case "low":
+		return 2, nil
+	case "default":
+		return 3, nil
+	case "high":
+		return 4, nil
+	case "max", "urgent":
+		return 5, nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n >= 1 && n <= 5 {
+		return n, nil
+	}
+	return 0, fmt.Errorf("unknown ntfy priority %q (want min, low, default, high, urgent or 1-5)", s)
+}
+
+func (n *NtfyNotifier) Notify(task *models.Task) error {
+	body := "Due " + task.DueDate.Format("Jan 2, 2006 at 3:04 PM")
+	if task.DueDate.IsZero() {
+		body = "No due date"
+	}
+	if n.Template != nil {
+		var err error
+		if body, err = renderMessage(n.Template, task); err != nil {
+			return err
+		}
+	}
+	return n.publish("Reminder: "+task.Title, body, n.priority(task.Priority))
+}
+
+// NotifyDigest publishes one message listing every task, as urgent as its
+// most urgent task.
+func (n *NtfyNotifier) NotifyDigest(d Digest) error {
+	priority := 1
+	lines := make([]string, len(d.Tasks))
+	for i, task := range d.Tasks {
+		if p := n.priority(task.Priority); p > priority {
+			priority = p
+		}
+		lines[i] = "• " + task.Title
+	}
+	return n.publish(d.Title, strings.Join(lines, "\n"), priority)
+}
+
+func (n *NtfyNotifier) priority(p models.Priority) int {
+	if priority, ok := n.Priorities[p]; ok {
+		return priority
+	}
+	return defaultNtfyPriorities[p]
+}
+
+func (n *NtfyNotifier) publish(title, body string, priority int) error {
+	server := n.Server
+	if server == "" {
+		server = DefaultNtfyServer
+	}
+	url := strings.TrimRight(server, "/") + "/" + n.Topic
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to publish to ntfy: %w", err)
+	}
+	req.Header.Set("Title", title)
+	req.Header.Set("Priority", strconv.Itoa(priority))
+	req.Header.Set("Tags", "alarm_clock")
+	if n.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.Token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish to ntfy: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("ntfy returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// OverdueStatePath is where the overdue digest records when it last went
+// out, so restarting the app doesn't send it again.
+func OverdueStatePath(dataDir string) string {
+	return filepath.Join(dataDir, "overdue-digest.json")
+}
+
+// OverdueDigest periodically sends one digest listing every overdue task,
+// most urgent first, so tasks left overdue resurface instead of rotting.
+// Only the session holding notification duty runs one.
+type OverdueDigest struct {
+	storage   storage.Storage
+	notifier  Notifier
+	every     time.Duration
+	statePath string
+	pausePath string
+	interval  time.Duration
+	logger    *log.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewOverdueDigest sends the digest through notifier every so often,
+// checking at interval whether it is due.
+func NewOverdueDigest(s storage.Storage, notifier Notifier, every time.Duration, statePath string, interval time.Duration) *OverdueDigest {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &OverdueDigest{
+		storage:   s,
+		notifier:  notifier,
+		every:     every,
+		statePath: statePath,
+		interval:  interval,
+		logger:    log.Default(),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+// SetLogger sends the digest's errors to logger instead of standard error.
+func (o *OverdueDigest) SetLogger(logger *log.Logger) {
+	o.logger = logger
+}
+
+// SetPause holds the digest back while the pause file at path is active.
+func (o *OverdueDigest) SetPause(path string) {
+	o.pausePath = path
+}
+
+func (o *OverdueDigest) Start() {
+	if o.every <= 0 {
+		return
+	}
+	o.wg.Add(1)
+	go o.loop()
+}
+
+func (o *OverdueDigest) Stop() {
+	o.cancel()
+	o.wg.Wait()
+}
+
+func (o *OverdueDigest) loop() {
+	defer o.wg.Done()
+
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	o.check(time.Now())
+	for {
+		select {
+		case <-ticker.C:
+			o.check(time.Now())
+		case <-o.ctx.Done():
+			return
+		}
+	}
+}
+
+// check sends the digest once every o.every. A failed send is retried on
+// the next check.
+func (o *OverdueDigest) check(now time.Time) {
+	last, err := loadOverdueState(o.statePath)
+	if err != nil {
+		o.logger.Printf("error checking overdue digest: %v", err)
+		return
+	}
+	if !last.IsZero() && now.Sub(last) < o.every {
+		return
+	}
+	if o.pausePath != "" {
+		if pause, err := LoadPause(o.pausePath); err == nil && pause.Active(now) {
+			return
+		}
+	}
+
+	tasks, err := o.storage.GetAllTasks(o.ctx)
+	if err != nil {
+		o.logger.Printf("error checking overdue digest: %v", err)
+		return
+	}
+	if digest, ok := BuildOverdueDigest(tasks, now); ok {
+		if err := SendDigest(o.notifier, digest); err != nil {
+			o.logger.Printf("error sending overdue digest: %v", err)
+			return
+		}
+	}
+	if err := saveOverdueState(o.statePath, now); err != nil {
+		o.logger.Printf("error checking overdue digest: %v", err)
+	}
+}
+
+// BuildOverdueDigest lists the open tasks past their due date as of now,
+// grouped by priority from high to low and oldest first within each. It
+// returns false when nothing is overdue.
+func BuildOverdueDigest(tasks []*models.Task, now time.Time) (Digest, bool) {
+	var overdue []*models.Task
+	counts := make(map[models.Priority]int)
+	for _, task := range tasks {
+		if task.IsOpen() && !task.DueDate.IsZero() && task.DueDate.Before(now) {
+			overdue = append(overdue, task)
+			counts[task.Priority]++
+		}
+	}
+	if len(overdue) == 0 {
+		return Digest{}, false
+	}
+	sort.SliceStable(overdue, func(i, j int) bool {
+		if overdue[i].Priority != overdue[j].Priority {
+			return overdue[i].Priority > overdue[j].Priority
+		}
+		return overdue[i].DueDate.Before(overdue[j].DueDate)
+	})
+
+	var groups []string
+	for _, p := range []models.Priority{models.HighPriority, models.MediumPriority, models.LowPriority} {
+		if counts[p] > 0 {
+			groups = append(groups, fmt.Sprintf("%d %s", counts[p], strings.ToLower(p.String())))
+		}
+	}
+	return Digest{
+		Title: fmt.Sprintf("%d task(s) overdue: %s", len(overdue), strings.Join(groups, ", ")),
+		Tasks: overdue,
+	}, true
+}
+
+type overdueState struct {
+	Sent time.Time `json:"sent"`
+}
+
+func loadOverdueState(path string) (time.Time, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read overdue digest state: %w", err)
+	}
+	var state overdueState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse overdue digest state: %w", err)
+	}
+	return state.Sent, nil
+}
+
+func saveOverdueState(path string, sent time.Time) error {
+	data, err := json.MarshalIndent(overdueState{Sent: sent}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal overdue digest state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write overdue digest state: %w", err)
+	}
+	return nil
+}
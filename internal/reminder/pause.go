@@ -0,0 +1,108 @@
+package reminder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// Pause holds non-critical reminders back until a date, e.g. during a
+// vacation. Reminders suppressed meanwhile are collected in Missed and
+// delivered as one catch-up digest once the pause ends.
+type Pause struct {
+	Since  time.Time       `json:"since"`
+	Until  time.Time       `json:"until"`
+	Missed []models.TaskID `json:"missed,omitempty"`
+}
+
+func (p *Pause) Active(now time.Time) bool {
+	return p != nil && now.Before(p.Until)
+}
+
+func (p *Pause) miss(id models.TaskID) bool {
+	for _, missed := range p.Missed {
+		if missed == id {
+			return false
+		}
+	}
+	p.Missed = append(p.Missed, id)
+	return true
+}
+
+func PausePath(dataDir string) string {
+	return filepath.Join(dataDir, "pause.json")
+}
+
+// LoadPause reads the pause file, returning nil when reminders aren't
+// paused.
+func LoadPause(path string) (*Pause, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pause file: %w", err)
+	}
+	var p Pause
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse pause file: %w", err)
+	}
+	return &p, nil
+}
+
+func SavePause(path string, p *Pause) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pause: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write pause file: %w", err)
+	}
+	return nil
+}
+
+// PauseUntil starts or extends a pause, keeping reminders already missed.
+func PauseUntil(path string, until time.Time) (*Pause, error) {
+	p, err := LoadPause(path)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil || (!p.Active(time.Now()) && len(p.Missed) == 0) {
+		p = &Pause{Since: time.Now()}
+	}
+	p.Until = until
+	return p, SavePause(path, p)
+}
+
+// Resume ends a pause now. The missed reminders stay in the file so the
+// reminder service can deliver the catch-up digest.
+func Resume(path string) (*Pause, error) {
+	p, err := LoadPause(path)
+	if err != nil || p == nil {
+		return p, err
+	}
+	p.Until = time.Now()
+	return p, SavePause(path, p)
+}
+
+// ParsePauseUntil accepts a date (2006-01-02, the day you are back), a
+// day count (3d) or a Go duration (4h).
+func ParsePauseUntil(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if at, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		if !at.After(now) {
+			return time.Time{}, fmt.Errorf("%s is not in the future", value)
+		}
+		return at, nil
+	}
+	d, err := models.ParseQuickDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("can't pause until %q; use a date (YYYY-MM-DD), 3d or 4h", value)
+	}
+	return now.Add(d), nil
+}
@@ -1,8 +1,13 @@
 package reminder
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
+	"os"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/san-kum/reminder-tui/internal/models"
@@ -13,98 +18,584 @@ type Notifier interface {
 	Notify(task *models.Task) error
 }
 
-type ConsoleNotifier struct{}
+type ConsoleNotifier struct {
+	Alerts AlertMap
+	// Template, when set, renders the reminder text in place of the
+	// built-in wording.
+	Template *template.Template
+}
 
 func (n *ConsoleNotifier) Notify(task *models.Task) error {
-	fmt.Printf("\n[REMINDER] Task: %s is due on %s\n", task.Title, task.DueDate.Format("Jan 2, 2006 at 3:04 PM"))
+	label := "REMINDER"
+	switch n.Alerts.For(task.Priority).Urgency {
+	case UrgencyCritical:
+		// Ring the terminal bell for urgent reminders
+		label = "\aURGENT REMINDER"
+	case UrgencyLow:
+		label = "reminder"
+	}
+	if n.Template != nil {
+		text, err := renderMessage(n.Template, task)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\n[%s] %s\n", label, text)
+		return nil
+	}
+	fmt.Printf("\n[%s] Task: %s is due on %s\n", label, task.Title, task.DueDate.Format("Jan 2, 2006 at 3:04 PM"))
+	return nil
+}
+
+func (n *ConsoleNotifier) NotifyDigest(d Digest) error {
+	fmt.Printf("\n[%s]\n", d.Title)
+	for _, task := range d.Tasks {
+		fmt.Printf("  - %s (due %s)\n", task.Title, task.DueDate.Format("Jan 2, 2006 at 3:04 PM"))
+	}
 	return nil
 }
 
 type ReminderService struct {
 	storage        storage.Storage
 	notifier       Notifier
-	checkInterval  time.Duration
-	stopChan       chan struct{}
+	ctx            context.Context
+	cancel         context.CancelFunc
 	wg             sync.WaitGroup
 	remindersMutex sync.Mutex
 	sentReminders  map[models.TaskID]time.Time
+	unsubscribe    func()
+	pausePath      string
+	critical       func(*models.Task) bool
+	// closed holds tasks completed, cancelled or deleted since the running
+	// check loaded its batch, so their reminders are dropped before they go
+	// out.
+	closed map[models.TaskID]bool
+	// deliveryPath logs every reminder sent through channel, when set.
+	deliveryPath string
+	channel      string
+	started      time.Time
+	escalation   EscalationPolicies
+	// statePath keeps sentReminders across restarts, when set.
+	statePath string
+	// retries are failed deliveries waiting to be tried again, by task
+	// and channel.
+	retries        map[retryKey]*Retry
+	retry          RetryPolicy
+	retryPath      string
+	deadLetterPath string
+	// maxWait is the longest the loop sleeps between checks, so it
+	// notices a wall clock that jumped, e.g. after the computer slept.
+	maxWait time.Duration
+	// wake interrupts the sleep when tasks change.
+	wake chan struct{}
+	// batchWindow, when set, sends reminders this close together as one.
+	batchWindow time.Duration
+	// logger records what goes wrong in the background; printing would
+	// garble the TUI.
+	logger *log.Logger
 }
 
-func NewReminderService(storage storage.Storage, notifier Notifier, checkInterval time.Duration) *ReminderService {
+// NewReminderService checks for reminders whenever the next one is due or
+// a task changes, and at least every maxWait.
+func NewReminderService(storage storage.Storage, notifier Notifier, maxWait time.Duration) *ReminderService {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &ReminderService{
 		storage:       storage,
 		notifier:      notifier,
-		checkInterval: checkInterval,
-		stopChan:      make(chan struct{}),
+		maxWait:       maxWait,
+		wake:          make(chan struct{}, 1),
+		ctx:           ctx,
+		cancel:        cancel,
 		sentReminders: make(map[models.TaskID]time.Time),
+		closed:        make(map[models.TaskID]bool),
+		retries:       make(map[retryKey]*Retry),
+		logger:        log.Default(),
 	}
 }
 
+// SetLogger sends the service's errors to logger instead of standard error.
+func (r *ReminderService) SetLogger(logger *log.Logger) {
+	r.logger = logger
+}
+
+// SetPause makes the service honour the pause file at path. Reminders for
+// which critical returns true are still delivered while paused.
+func (r *ReminderService) SetPause(path string, critical func(*models.Task) bool) {
+	r.pausePath = path
+	r.critical = critical
+}
+
+// SetDeliveryLog records every reminder sent, and how late it was, in
+// the log at path. channel names the notifier in the log.
+func (r *ReminderService) SetDeliveryLog(path, channel string) {
+	r.deliveryPath = path
+	r.channel = channel
+}
+
+// SetEscalation sets the policies deciding how often unacknowledged
+// reminders repeat; without them DefaultEscalation applies.
+func (r *ReminderService) SetEscalation(policies EscalationPolicies) {
+	r.escalation = policies
+}
+
+// SetStatePath keeps track of which reminders went out in the file at
+// path, so a service started afresh, such as a one-shot run from cron,
+// doesn't send them again.
+func (r *ReminderService) SetStatePath(path string) {
+	r.statePath = path
+}
+
 func (r *ReminderService) Start() {
+	r.started = time.Now()
+	r.loadState()
+	r.loadRetries()
+	r.unsubscribe = r.storage.Events().Subscribe(r.handleStorageEvent)
 	r.wg.Add(1)
 	go r.reminderLoop()
 }
 
 func (r *ReminderService) Stop() {
-	close(r.stopChan)
+	r.unsubscribe()
+	r.cancel()
 	r.wg.Wait()
 }
 
+// handleStorageEvent forgets sent reminders for tasks that were deleted,
+// completed, snoozed or given a new reminder time, so the next check sees
+// them fresh. Tasks closed meanwhile are also dropped from a check that is
+// still running.
+func (r *ReminderService) handleStorageEvent(e storage.Event) {
+	// Whatever changed may move the next reminder
+	defer r.wakeUp()
+
+	if e.Kind == storage.TasksReloaded {
+		// Another device may have finished tasks; don't wait for the next
+		// check to notice
+		r.suppressClosed()
+		return
+	}
+
+	r.remindersMutex.Lock()
+	defer r.remindersMutex.Unlock()
+
+	switch e.Kind {
+	case storage.TaskDeleted:
+		delete(r.sentReminders, e.TaskID)
+		r.closed[e.TaskID] = true
+	case storage.TaskSaved:
+		if !e.Task.IsOpen() {
+			r.closed[e.TaskID] = true
+		}
+		sentAt, found := r.sentReminders[e.TaskID]
+		if !found {
+			return
+		}
+		if !e.Task.IsOpen() || e.Task.ReminderAt.After(sentAt) || e.Task.SnoozedUntil.After(sentAt) {
+			delete(r.sentReminders, e.TaskID)
+		}
+	}
+}
+
+// suppressClosed cancels the reminders of every task that is no longer
+// open or was deleted after the tasks file changed underneath us: a check
+// in progress skips them, and they are taken off the paused reminders'
+// catch-up list.
+func (r *ReminderService) suppressClosed() {
+	tasks, err := r.storage.GetTasksByStatus(r.ctx, models.ClosedStatuses...)
+	if err != nil {
+		r.logger.Printf("error checking synced tasks: %v", err)
+		return
+	}
+	closed := make(map[models.TaskID]bool, len(tasks))
+	for _, task := range tasks {
+		closed[task.ID] = true
+	}
+
+	r.remindersMutex.Lock()
+	sent := make([]models.TaskID, 0, len(r.sentReminders))
+	for id := range r.sentReminders {
+		sent = append(sent, id)
+	}
+	r.remindersMutex.Unlock()
+	r.markDeleted(closed, sent)
+
+	r.remindersMutex.Lock()
+	for id := range closed {
+		r.closed[id] = true
+		delete(r.sentReminders, id)
+	}
+	r.remindersMutex.Unlock()
+
+	if r.pausePath == "" {
+		return
+	}
+	pause, err := LoadPause(r.pausePath)
+	if err != nil || pause == nil {
+		return
+	}
+	r.markDeleted(closed, pause.Missed)
+	missed := pause.Missed[:0]
+	for _, id := range pause.Missed {
+		if !closed[id] {
+			missed = append(missed, id)
+		}
+	}
+	if len(missed) == len(pause.Missed) {
+		return
+	}
+	pause.Missed = missed
+	if err := SavePause(r.pausePath, pause); err != nil {
+		r.logger.Printf("error updating paused reminders: %v", err)
+	}
+}
+
+// markDeleted adds those of ids whose task no longer exists to closed.
+// Only the few tasks with reminders outstanding are looked up, rather than
+// loading every task.
+func (r *ReminderService) markDeleted(closed map[models.TaskID]bool, ids []models.TaskID) {
+	for _, id := range ids {
+		if closed[id] {
+			continue
+		}
+		if _, err := r.storage.GetTask(r.ctx, id); errors.Is(err, storage.ErrNotFound) {
+			closed[id] = true
+		}
+	}
+}
+
+// reminderLoop checks for reminders, then sleeps until the next one is
+// due or a task changes
 func (r *ReminderService) reminderLoop() {
 	defer r.wg.Done()
 
-	ticker := time.NewTicker(r.checkInterval)
-	defer ticker.Stop()
-
 	for {
+		if _, err := r.checkReminders(); err != nil {
+			r.logger.Printf("error checking reminders: %v", err)
+		}
+		now := time.Now()
+		timer := time.NewTimer(r.nextCheck(now).Sub(now))
 		select {
-		case <-ticker.C:
-			r.checkReminders()
-		case <-r.stopChan:
+		case <-timer.C:
+		case <-r.wake:
+			timer.Stop()
+		case <-r.ctx.Done():
+			timer.Stop()
 			return
 		}
 	}
 }
 
-func (r *ReminderService) checkReminders() {
+// wakeUp has the loop check again straight away
+func (r *ReminderService) wakeUp() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// nextCheck is when the loop next has something to do: a reminder, a
+// snooze or a pause running out, a repeat or a retry. Reminders already
+// due were dealt with by the last check; those it held back, such as for
+// blocked tasks, wait for the change that frees them.
+func (r *ReminderService) nextCheck(now time.Time) time.Time {
+	next := now.Add(r.maxWait)
+	consider := func(at time.Time) {
+		if !at.Before(now) && at.Before(next) {
+			next = at
+		}
+	}
+
+	tasks, err := r.storage.GetTasksByStatus(r.ctx, models.OpenStatuses...)
+	if err != nil {
+		// Try again soon rather than sleeping through reminders
+		return now.Add(time.Minute)
+	}
+	r.remindersMutex.Lock()
+	for _, task := range tasks {
+		if task.Cron != "" {
+			consider(task.NextCronReminder(now))
+		} else {
+			consider(task.ReminderAt)
+		}
+		consider(task.SnoozedUntil)
+		if sent, found := r.sentReminders[task.ID]; found && !task.ReminderAcknowledged() {
+			consider(r.escalation.For(task).Next(task, sent))
+		}
+	}
+	for _, retry := range r.retries {
+		consider(retry.Next)
+	}
+	r.remindersMutex.Unlock()
+
+	if r.pausePath != "" {
+		if pause, err := LoadPause(r.pausePath); err == nil && pause.Active(now) {
+			consider(pause.Until)
+		}
+	}
+	return next
+}
+
+// CheckOnce runs a single check without starting the loop, for one-shot
+// runs. It reports how many reminders were sent and how many of those
+// failed.
+func (r *ReminderService) CheckOnce() (CheckResult, error) {
+	r.started = time.Now()
+	r.loadState()
+	r.loadRetries()
+	return r.checkReminders()
+}
+
+// CheckResult counts the reminders a check sent.
+type CheckResult struct {
+	Sent, Failed int
+}
+
+func (r *ReminderService) checkReminders() (CheckResult, error) {
+	var result CheckResult
 	now := time.Now()
-	tasks, err := r.storage.GetTasksWithRemindersBy(now)
+	// Anything closed from here on is caught before its reminder is sent
+	r.remindersMutex.Lock()
+	clear(r.closed)
+	r.remindersMutex.Unlock()
+
+	// Reminders coming up within the batch window may join those due now
+	horizon := now.Add(r.batchWindow)
+	tasks, err := r.storage.GetTasksWithRemindersBy(r.ctx, horizon)
 	if err != nil {
-		fmt.Printf("error checking reminders %v\n", err)
-		return
+		return result, err
+	}
+
+	index, err := r.dependencyIndex(tasks)
+	if err != nil {
+		return result, err
 	}
 
+	pause := r.checkPause(now)
+	missed := false
+	if !pause.Active(now) {
+		r.retryFailed(now)
+	}
+
+	var ready, upcoming []pendingReminder
+
 	for _, task := range tasks {
+		if task.Cron != "" {
+			// The schedule's latest firing stands in for ReminderAt
+			fired := task.CronReminder(now)
+			if fired.IsZero() || fired.Before(task.CreatedAt) {
+				continue
+			}
+			task = task.Clone()
+			task.ReminderAt = fired
+		}
+		if task.IsSnoozed(now) || task.ReminderAcknowledged() {
+			continue
+		}
+		if task.IsBlocked(index) {
+			// Blocked tasks can't be acted on yet; remind once they're free
+			continue
+		}
+		r.remindersMutex.Lock()
+		closed := r.closed[task.ID]
+		r.remindersMutex.Unlock()
+		if closed {
+			continue
+		}
+		if pause.Active(now) && !r.critical(task) {
+			// Held back for the catch-up digest once it is due
+			if !task.ReminderAt.After(now) {
+				missed = pause.miss(task.ID) || missed
+			}
+			continue
+		}
+
 		r.remindersMutex.Lock()
 		lastSent, found := r.sentReminders[task.ID]
-		shouldSend := !found || now.Sub(lastSent) > 6*time.Hour
-		if shouldSend {
+		if found && (task.ReminderAt.After(lastSent) || task.SnoozedUntil.After(lastSent)) {
+			// Re-armed while no storage events reached us, e.g. snoozed
+			// between one-shot runs
+			found = false
+		}
+		scheduled := scheduledAt(task)
+		if found {
+			// A repeat of an earlier reminder, if the policy repeats it
+			scheduled = r.escalation.For(task).Next(task, lastSent)
+		}
+		switch {
+		case r.closed[task.ID] || (found && scheduled.IsZero()) || scheduled.After(horizon):
+		case scheduled.After(now):
+			upcoming = append(upcoming, pendingReminder{task, scheduled})
+		default:
 			r.sentReminders[task.ID] = now
-			r.remindersMutex.Unlock()
+			ready = append(ready, pendingReminder{task, scheduled})
+		}
+		r.remindersMutex.Unlock()
+	}
 
-			task.UpdateStatus()
-			r.storage.SaveTask(task)
+	if r.batchWindow > 0 && len(ready) > 0 && len(ready)+len(upcoming) > 1 {
+		// Bring the upcoming ones forward into a single notification
+		r.remindersMutex.Lock()
+		for _, p := range upcoming {
+			if !r.closed[p.task.ID] {
+				r.sentReminders[p.task.ID] = now
+				ready = append(ready, p)
+			}
+		}
+		r.remindersMutex.Unlock()
+	}
+	if len(ready) > 1 && r.batchWindow > 0 {
+		result = r.sendBatch(ready, now)
+	} else {
+		for _, p := range ready {
+			failed := false
+			for _, ch := range NotifyChannels(r.notifier, p.task, r.channel) {
+				r.recordDelivery(p.task, ch.Channel, p.scheduled, ch.Err, r.lateReason(p.scheduled, now))
+				r.settle(p.task, ch.Channel, p.scheduled, ch.Err, now)
+				failed = failed || ch.Err != nil
+			}
+			result.Sent++
+			if failed {
+				result.Failed++
+			}
+		}
+	}
 
-			r.notifier.Notify(task)
-		} else {
-			r.remindersMutex.Unlock()
+	if missed {
+		if err := SavePause(r.pausePath, pause); err != nil {
+			r.logger.Printf("error recording paused reminders: %v", err)
 		}
 	}
 
+	// Forget reminders for tasks that no longer need one. The rest are
+	// kept however old, or a policy that stops repeating would start over
+	due := make(map[models.TaskID]bool, len(tasks))
+	for _, task := range tasks {
+		due[task.ID] = true
+	}
 	r.remindersMutex.Lock()
-	for id, sentTime := range r.sentReminders {
-		if now.Sub(sentTime) > 24*time.Hour {
+	for id := range r.sentReminders {
+		if !due[id] {
 			delete(r.sentReminders, id)
 		}
 	}
 	r.remindersMutex.Unlock()
 
+	r.saveState()
+	r.saveRetries()
+	return result, nil
+}
+
+// checkPause loads the pause state. Once a pause has ended it delivers the
+// digest of reminders missed meanwhile and removes the pause file.
+func (r *ReminderService) checkPause(now time.Time) *Pause {
+	if r.pausePath == "" {
+		return nil
+	}
+	pause, err := LoadPause(r.pausePath)
+	if err != nil {
+		r.logger.Printf("error checking reminder pause: %v", err)
+		return nil
+	}
+	if pause == nil || pause.Active(now) {
+		return pause
+	}
+
+	var missed []*models.Task
+	for _, id := range pause.Missed {
+		task, err := r.storage.GetTask(r.ctx, id)
+		if err == nil && task.IsOpen() {
+			missed = append(missed, task)
+		}
+	}
+	if len(missed) > 0 {
+		digest := Digest{
+			Title: fmt.Sprintf("While reminders were paused: %d task(s) need attention", len(missed)),
+			Tasks: missed,
+		}
+		err := SendDigest(r.notifier, digest)
+		for _, task := range missed {
+			r.recordDelivery(task, r.channel, scheduledAt(task), err, "held back while reminders were paused")
+		}
+		if err != nil {
+			r.logger.Printf("error sending catch-up digest: %v", err)
+			return nil
+		}
+
+		// The digest stands in for their individual reminders
+		r.remindersMutex.Lock()
+		for _, task := range missed {
+			r.sentReminders[task.ID] = now
+		}
+		r.remindersMutex.Unlock()
+	}
+	if err := os.Remove(r.pausePath); err != nil && !os.IsNotExist(err) {
+		r.logger.Printf("error clearing reminder pause: %v", err)
+	}
+	return nil
+}
+
+// scheduledAt is when the task's reminder was due to go out.
+func scheduledAt(task *models.Task) time.Time {
+	if task.SnoozedUntil.After(task.ReminderAt) {
+		return task.SnoozedUntil
+	}
+	return task.ReminderAt
+}
+
+// lateReason guesses why a reminder due at scheduled only went out now,
+// or returns "" when it wasn't late.
+func (r *ReminderService) lateReason(scheduled, now time.Time) string {
+	switch {
+	case now.Sub(scheduled) <= LateAfter:
+		return ""
+	case scheduled.Before(r.started):
+		return "the app wasn't running"
+	default:
+		// The loop wakes when reminders are due, so anything later was
+		// held up
+		return "checks were held up, e.g. while the computer slept"
+	}
+}
+
+// recordDelivery logs a reminder sent for task through channel
+func (r *ReminderService) recordDelivery(task *models.Task, channel string, scheduled time.Time, err error, reason string) {
+	if r.deliveryPath == "" {
+		return
+	}
+	d := Delivery{
+		TaskID:    task.ID,
+		Title:     task.Title,
+		Channel:   channel,
+		Scheduled: scheduled,
+		Delivered: time.Now(),
+		Reason:    reason,
+	}
+	if err != nil {
+		d.Error = err.Error()
+	}
+	if err := RecordDelivery(r.deliveryPath, d); err != nil {
+		r.logger.Printf("error logging reminder delivery: %v", err)
+	}
+}
+
+// dependencyIndex loads every task when any of the due ones has
+// dependencies, so blocked tasks can be told apart.
+func (r *ReminderService) dependencyIndex(tasks []*models.Task) (map[models.TaskID]*models.Task, error) {
+	for _, task := range tasks {
+		if len(task.DependsOn) > 0 {
+			all, err := r.storage.GetAllTasks(r.ctx)
+			if err != nil {
+				return nil, err
+			}
+			return models.IndexTasks(all), nil
+		}
+	}
+	return nil, nil
 }
 
-func (r *ReminderService) CreateTaskWithReminder(title, description string, dueDate time.Time, reminderPeriod time.Duration) (*models.Task, error) {
+func (r *ReminderService) CreateTaskWithReminder(ctx context.Context, title, description string, dueDate time.Time, reminderPeriod time.Duration) (*models.Task, error) {
 	task := models.NewTask(title, description, dueDate)
 	task.SetReminderPeriod(reminderPeriod)
-	if err := r.storage.SaveTask(task); err != nil {
+	if err := r.storage.SaveTask(ctx, task); err != nil {
 		return nil, err
 	}
 	return task, nil
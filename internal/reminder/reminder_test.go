@@ -0,0 +1,153 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+func newTestStorage(t *testing.T) (*storage.FileStorage, string) {
+	t.Helper()
+	dir := t.TempDir()
+	s, err := storage.NewFileStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s, dir
+}
+
+// addTask saves a task whose reminder went off at remindAt.
+func addTask(t *testing.T, s storage.Storage, title string, remindAt time.Time) *models.Task {
+	t.Helper()
+	task := models.NewTask(title, "", remindAt.Add(time.Hour))
+	task.ReminderAt = remindAt
+	if err := s.SaveTask(context.Background(), task); err != nil {
+		t.Fatal(err)
+	}
+	return task
+}
+
+func TestCheckOnce(t *testing.T) {
+	s, dir := newTestStorage(t)
+	now := time.Now()
+	due := addTask(t, s, "Due", now.Add(-time.Minute))
+	addTask(t, s, "Later", now.Add(2*time.Hour))
+	snoozed := addTask(t, s, "Snoozed", now.Add(-time.Minute))
+	snoozed.SnoozedUntil = now.Add(time.Hour)
+	done := addTask(t, s, "Done", now.Add(-time.Minute))
+	done.Status = models.TaskStatusCompleted
+	for _, task := range []*models.Task{snoozed, done} {
+		if err := s.SaveTask(context.Background(), task); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	check := func() (CheckResult, *recordNotifier) {
+		n := &recordNotifier{}
+		r := NewReminderService(s, n, time.Hour)
+		r.SetStatePath(StatePath(dir))
+		result, err := r.CheckOnce()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result, n
+	}
+
+	result, n := check()
+	if result.Sent != 1 || result.Failed != 0 || n.count() != 1 || n.tasks[0].ID != due.ID {
+		t.Fatalf("first check sent %+v to %d task(s), want only %q", result, n.count(), due.Title)
+	}
+	// A fresh run, as from cron, remembers what went out
+	if result, _ := check(); result.Sent != 0 {
+		t.Errorf("second check sent %d reminder(s), want 0", result.Sent)
+	}
+}
+
+func TestCheckOnceRetry(t *testing.T) {
+	s, dir := newTestStorage(t)
+	task := addTask(t, s, "Due", time.Now().Add(-time.Minute))
+
+	r := NewReminderService(s, failNotifier{}, time.Hour)
+	r.SetRetry(DefaultRetry, RetryPath(dir), DeadLetterPath(dir))
+	result, err := r.CheckOnce()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Sent != 1 || result.Failed != 1 {
+		t.Errorf("result = %+v, want 1 sent and 1 failed", result)
+	}
+
+	data, err := os.ReadFile(RetryPath(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var retries []*Retry
+	if err := json.Unmarshal(data, &retries); err != nil {
+		t.Fatal(err)
+	}
+	if len(retries) != 1 || retries[0].TaskID != task.ID || retries[0].Attempts != 1 {
+		t.Errorf("retries = %+v, want one first attempt for %s", retries, task.ID)
+	}
+}
+
+func TestNextCheck(t *testing.T) {
+	s, _ := newTestStorage(t)
+	now := time.Now()
+	soon := addTask(t, s, "Soon", now.Add(10*time.Minute))
+
+	r := NewReminderService(s, &recordNotifier{}, time.Hour)
+	if got := r.nextCheck(now); !got.Equal(soon.ReminderAt) {
+		t.Errorf("nextCheck = %v, want the reminder at %v", got, soon.ReminderAt)
+	}
+	r.maxWait = 5 * time.Minute
+	if got, want := r.nextCheck(now), now.Add(5*time.Minute); !got.Equal(want) {
+		t.Errorf("nextCheck = %v, want maxWait at %v", got, want)
+	}
+}
+
+func TestReminderLoopWakesOnChange(t *testing.T) {
+	s, _ := newTestStorage(t)
+	n := &recordNotifier{}
+	r := NewReminderService(s, n, time.Hour)
+	r.Start()
+	defer r.Stop()
+
+	// The loop is asleep for an hour; saving a due task must wake it
+	addTask(t, s, "Due", time.Now().Add(-time.Minute))
+	deadline := time.Now().Add(2 * time.Second)
+	for n.count() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the loop didn't notice the new task")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSuppressClosedForgetsDeleted(t *testing.T) {
+	s, dir := newTestStorage(t)
+	task := addTask(t, s, "Due", time.Now().Add(-time.Minute))
+	r := NewReminderService(s, &recordNotifier{}, time.Hour)
+	r.SetStatePath(filepath.Join(dir, "sent.json"))
+	if _, err := r.CheckOnce(); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.DeleteTask(context.Background(), task.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	r.suppressClosed()
+	r.remindersMutex.Lock()
+	_, sent := r.sentReminders[task.ID]
+	closed := r.closed[task.ID]
+	r.remindersMutex.Unlock()
+	if sent || !closed {
+		t.Errorf("after deleting the task sent = %v, closed = %v, want false, true", sent, closed)
+	}
+}
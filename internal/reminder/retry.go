@@ -0,0 +1,301 @@
+package reminder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// RetryPolicy decides how often a channel that failed to deliver a
+// reminder is tried again. The wait doubles after each failure, from
+// Backoff up to MaxBackoff; once Attempts retries have failed the reminder
+// is recorded as a dead letter.
+type RetryPolicy struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetry tries again after 1, 2, 4, 8 and 16 minutes.
+var DefaultRetry = RetryPolicy{
+	Attempts:   5,
+	Backoff:    time.Minute,
+	MaxBackoff: 30 * time.Minute,
+}
+
+// Delay is how long to wait before the given retry, counting from 1.
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+// Retry is a reminder waiting to be delivered again through a channel
+// that failed.
+type Retry struct {
+	TaskID    models.TaskID `json:"task_id"`
+	Title     string        `json:"title"`
+	Channel   string        `json:"channel"`
+	Scheduled time.Time     `json:"scheduled"`
+	// Attempts counts the deliveries tried so far, the first included.
+	Attempts int       `json:"attempts"`
+	Next     time.Time `json:"next"`
+}
+
+type retryKey struct {
+	task    models.TaskID
+	channel string
+}
+
+// RetryPath is where the reminder service keeps the retries still
+// pending, so a one-shot run picks up where the last one left off.
+func RetryPath(dataDir string) string {
+	return filepath.Join(dataDir, "reminder-retries.json")
+}
+
+// DeadLetter is a reminder given up on after every retry failed.
+type DeadLetter struct {
+	TaskID    models.TaskID `json:"task_id"`
+	Title     string        `json:"title"`
+	Channel   string        `json:"channel"`
+	Scheduled time.Time     `json:"scheduled"`
+	Attempts  int           `json:"attempts"`
+	Error     string        `json:"error"`
+	GaveUp    time.Time     `json:"gave_up"`
+}
+
+// DeadLetterPath is where reminders that couldn't be delivered are kept
+// until dismissed.
+func DeadLetterPath(dataDir string) string {
+	return filepath.Join(dataDir, "dead-letters.json")
+}
+
+var deadLetterMu sync.Mutex
+
+// LoadDeadLetters reads the reminders given up on, oldest first.
+func LoadDeadLetters(path string) ([]DeadLetter, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dead letters: %w", err)
+	}
+	var letters []DeadLetter
+	if err := json.Unmarshal(data, &letters); err != nil {
+		return nil, fmt.Errorf("failed to parse dead letters: %w", err)
+	}
+	return letters, nil
+}
+
+// AddDeadLetter appends d to the dead letters at path.
+func AddDeadLetter(path string, d DeadLetter) error {
+	deadLetterMu.Lock()
+	defer deadLetterMu.Unlock()
+	letters, err := LoadDeadLetters(path)
+	if err != nil {
+		return err
+	}
+	return writeJSON(path, append(letters, d))
+}
+
+// ClearDeadLetters dismisses every dead letter.
+func ClearDeadLetters(path string) error {
+	deadLetterMu.Lock()
+	defer deadLetterMu.Unlock()
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to clear dead letters: %w", err)
+	}
+	return nil
+}
+
+func writeJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", filepath.Base(path), err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// SetRetry makes the service try failed channels again under policy,
+// keeping the pending retries in retryPath and the reminders given up on
+// in deadLetterPath. Without it a failed reminder waits for its next
+// repeat.
+func (r *ReminderService) SetRetry(policy RetryPolicy, retryPath, deadLetterPath string) {
+	r.retry = policy
+	r.retryPath = retryPath
+	r.deadLetterPath = deadLetterPath
+}
+
+// scheduleRetry queues another try at a channel that failed to deliver
+// the reminder for task; a failure past the last retry is a dead letter.
+func (r *ReminderService) scheduleRetry(task *models.Task, channel string, scheduled time.Time, attempts int, err error, now time.Time) {
+	if r.retryPath == "" {
+		return
+	}
+	key := retryKey{task.ID, channel}
+	if attempts > r.retry.Attempts {
+		r.remindersMutex.Lock()
+		delete(r.retries, key)
+		r.remindersMutex.Unlock()
+		letter := DeadLetter{
+			TaskID:    task.ID,
+			Title:     task.Title,
+			Channel:   channel,
+			Scheduled: scheduled,
+			Attempts:  attempts,
+			Error:     err.Error(),
+			GaveUp:    now,
+		}
+		if err := AddDeadLetter(r.deadLetterPath, letter); err != nil {
+			r.logger.Printf("error recording undelivered reminder: %v", err)
+		}
+		return
+	}
+	r.remindersMutex.Lock()
+	r.retries[key] = &Retry{
+		TaskID:    task.ID,
+		Title:     task.Title,
+		Channel:   channel,
+		Scheduled: scheduled,
+		Attempts:  attempts,
+		Next:      now.Add(r.retry.Delay(attempts)),
+	}
+	r.remindersMutex.Unlock()
+}
+
+// settle queues a retry for a channel that failed to deliver the
+// reminder, or drops one pending for a channel that just delivered it.
+func (r *ReminderService) settle(task *models.Task, channel string, scheduled time.Time, err error, now time.Time) {
+	if err != nil {
+		r.scheduleRetry(task, channel, scheduled, 1, err, now)
+		return
+	}
+	r.remindersMutex.Lock()
+	delete(r.retries, retryKey{task.ID, channel})
+	r.remindersMutex.Unlock()
+}
+
+// retryFailed delivers the retries that are due through the channels that
+// failed them. Retries for tasks that no longer need a reminder are
+// dropped.
+func (r *ReminderService) retryFailed(now time.Time) {
+	r.remindersMutex.Lock()
+	var due []*Retry
+	for key, retry := range r.retries {
+		if !now.Before(retry.Next) {
+			due = append(due, retry)
+			delete(r.retries, key)
+		}
+	}
+	r.remindersMutex.Unlock()
+
+	for _, retry := range due {
+		task, err := r.storage.GetTask(r.ctx, retry.TaskID)
+		if err != nil || !task.IsOpen() || task.IsSnoozed(now) || task.ReminderAcknowledged() {
+			continue
+		}
+		attempts := retry.Attempts + 1
+		err = NotifyChannel(r.notifier, task, retry.Channel)
+		r.recordDelivery(task, retry.Channel, retry.Scheduled, err, fmt.Sprintf("retry %d of %d", retry.Attempts, r.retry.Attempts))
+		if err != nil {
+			r.scheduleRetry(task, retry.Channel, retry.Scheduled, attempts, err, now)
+		}
+	}
+}
+
+// loadRetries restores the retries pending when the service last stopped.
+func (r *ReminderService) loadRetries() {
+	if r.retryPath == "" {
+		return
+	}
+	data, err := os.ReadFile(r.retryPath)
+	if os.IsNotExist(err) {
+		return
+	}
+	var retries []*Retry
+	if err == nil {
+		err = json.Unmarshal(data, &retries)
+	}
+	if err != nil {
+		r.logger.Printf("error loading reminder retries: %v", err)
+		return
+	}
+
+	r.remindersMutex.Lock()
+	defer r.remindersMutex.Unlock()
+	for _, retry := range retries {
+		r.retries[retryKey{retry.TaskID, retry.Channel}] = retry
+	}
+}
+
+func (r *ReminderService) saveRetries() {
+	if r.retryPath == "" {
+		return
+	}
+	r.remindersMutex.Lock()
+	retries := make([]*Retry, 0, len(r.retries))
+	for _, retry := range r.retries {
+		retries = append(retries, retry)
+	}
+	r.remindersMutex.Unlock()
+
+	var err error
+	if len(retries) == 0 {
+		if err = os.Remove(r.retryPath); os.IsNotExist(err) {
+			err = nil
+		}
+	} else {
+		err = writeJSON(r.retryPath, retries)
+	}
+	if err != nil {
+		r.logger.Printf("error saving reminder retries: %v", err)
+	}
+}
+
+// RewriteRetryIDs moves the retries pending at path to the tasks' new IDs,
+// for `notes migrate ids`.
+func RewriteRetryIDs(path string, rewrite *models.IDRewrite) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read reminder retries: %w", err)
+	}
+	var retries []*Retry
+	if err := json.Unmarshal(data, &retries); err != nil {
+		return fmt.Errorf("failed to parse reminder retries: %w", err)
+	}
+
+	ids := make([]models.TaskID, len(retries))
+	for i, retry := range retries {
+		ids[i] = retry.TaskID
+	}
+	rewrite.TaskIDs(ids)
+	for i, retry := range retries {
+		retry.TaskID = ids[i]
+	}
+
+	if data, err = json.MarshalIndent(retries, "", "  "); err != nil {
+		return fmt.Errorf("failed to marshal reminder retries: %w", err)
+	}
+	if err := storage.WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write reminder retries: %w", err)
+	}
+	return nil
+}
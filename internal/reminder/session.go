@@ -0,0 +1,236 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// ErrSessionActive is returned by Listen when another process already
+// delivers reminders for the data directory.
+var ErrSessionActive = errors.New("another session is delivering reminders")
+
+// Event is a delivered reminder, passed on to every session watching the
+// data directory.
+type Event struct {
+	TaskID models.TaskID `json:"task_id,omitempty"`
+	Title  string        `json:"title"`
+	Due    time.Time     `json:"due,omitempty"`
+	// Count is the number of tasks in a digest, 0 for single reminders.
+	Count int `json:"count,omitempty"`
+}
+
+// SocketPath is where the session delivering reminders listens. The
+// socket doubles as the lock: whoever holds it has notification duty.
+func SocketPath(dataDir string) string {
+	return filepath.Join(dataDir, "reminder.sock")
+}
+
+const (
+	// takeoverWait is how long Listen waits for another process taking
+	// over before leaving duty to it.
+	takeoverWait = 2 * time.Second
+	// takeoverStale is the age after which a takeover lock left by a
+	// crashed process is removed.
+	takeoverStale = 10 * time.Second
+	takeoverRetry = 20 * time.Millisecond
+)
+
+// lockTakeover serializes taking over the socket at path between
+// processes, with a lock file created exclusively next to it. It returns
+// ErrSessionActive if another process is taking over.
+func lockTakeover(path string) (release func(), err error) {
+	lockPath := path + ".lock"
+	deadline := time.Now().Add(takeoverWait)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			f.WriteString(strconv.Itoa(os.Getpid()))
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock reminder socket: %w", err)
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > takeoverStale {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, ErrSessionActive
+		}
+		time.Sleep(takeoverRetry)
+	}
+}
+
+// Hub is held by the one process delivering reminders. It wraps that
+// process's notifier and broadcasts every reminder to the sessions that
+// follow it.
+type Hub struct {
+	next     Notifier
+	listener net.Listener
+	local    chan Event
+
+	mu      sync.Mutex
+	clients map[net.Conn]bool
+}
+
+// Listen takes notification duty for the socket at path. It fails with
+// ErrSessionActive if a live process already holds it or is taking it
+// over; a socket left behind by a crashed process is replaced.
+func Listen(path string, next Notifier) (*Hub, error) {
+	// Followers all retry when the leader goes; without the lock one
+	// could remove the socket another has just started listening on
+	release, err := lockTakeover(path)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		conn.Close()
+		return nil, ErrSessionActive
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on reminder socket: %w", err)
+	}
+
+	h := &Hub{
+		next:     next,
+		listener: listener,
+		local:    make(chan Event, 16),
+		clients:  make(map[net.Conn]bool),
+	}
+	go h.accept()
+	return h, nil
+}
+
+func (h *Hub) accept() {
+	for {
+		conn, err := h.listener.Accept()
+		if err != nil {
+			return
+		}
+		h.mu.Lock()
+		h.clients[conn] = true
+		h.mu.Unlock()
+	}
+}
+
+// Events delivers reminders to the session holding the hub. Events are
+// dropped if it falls behind.
+func (h *Hub) Events() <-chan Event {
+	return h.local
+}
+
+func (h *Hub) Notify(task *models.Task) error {
+	err := h.next.Notify(task)
+	h.broadcast(Event{TaskID: task.ID, Title: task.Title, Due: task.DueDate})
+	return err
+}
+
+// NotifyChannels reports per channel when the wrapped notifier can; a
+// single result has no channel name.
+func (h *Hub) NotifyChannels(task *models.Task) []ChannelResult {
+	results := NotifyChannels(h.next, task, "")
+	h.broadcast(Event{TaskID: task.ID, Title: task.Title, Due: task.DueDate})
+	return results
+}
+
+// NotifyChannel retries a channel; the reminder was broadcast the first
+// time round.
+func (h *Hub) NotifyChannel(task *models.Task, channel string) error {
+	return NotifyChannel(h.next, task, channel)
+}
+
+func (h *Hub) NotifyDigest(d Digest) error {
+	err := SendDigest(h.next, d)
+	h.broadcast(Event{Title: d.Title, Count: len(d.Tasks)})
+	return err
+}
+
+func (h *Hub) NotifyDigestChannels(d Digest) []DigestResult {
+	results := SendDigestChannels(h.next, d, "")
+	h.broadcast(Event{Title: d.Title, Count: len(d.Tasks)})
+	return results
+}
+
+func (h *Hub) broadcast(e Event) {
+	select {
+	case h.local <- e:
+	default:
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	line = append(line, '\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for conn := range h.clients {
+		conn.SetWriteDeadline(time.Now().Add(time.Second))
+		if _, err := conn.Write(line); err != nil {
+			conn.Close()
+			delete(h.clients, conn)
+		}
+	}
+}
+
+// Close gives up notification duty and disconnects the followers, which
+// lets one of them take over.
+func (h *Hub) Close() error {
+	err := h.listener.Close()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for conn := range h.clients {
+		conn.Close()
+		delete(h.clients, conn)
+	}
+	return err
+}
+
+// Follow connects to the session holding notification duty and streams
+// its reminders. The channel is closed when that session goes away or ctx
+// is cancelled.
+func Follow(ctx context.Context, path string) (<-chan Event, error) {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to reminder session: %w", err)
+	}
+
+	events := make(chan Event)
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	go func() {
+		defer close(events)
+		defer conn.Close()
+		defer stop()
+		dec := json.NewDecoder(conn)
+		for {
+			var e Event
+			if err := dec.Decode(&e); err != nil {
+				return
+			}
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
@@ -0,0 +1,134 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// recordNotifier remembers the tasks it was asked to remind about.
+type recordNotifier struct {
+	mu    sync.Mutex
+	tasks []*models.Task
+}
+
+func (n *recordNotifier) Notify(task *models.Task) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.tasks = append(n.tasks, task)
+	return nil
+}
+
+func (n *recordNotifier) count() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.tasks)
+}
+
+func TestListenSingleLeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reminder.sock")
+	leader, err := Listen(path, &recordNotifier{})
+	if err != nil {
+		t.Fatalf("Listen() = %v", err)
+	}
+	if _, err := Listen(path, &recordNotifier{}); !errors.Is(err, ErrSessionActive) {
+		t.Fatalf("second Listen() = %v, want ErrSessionActive", err)
+	}
+	leader.Close()
+
+	// Every follower retries at once when the leader goes
+	const followers = 8
+	hubs := make(chan *Hub, followers)
+	var wg sync.WaitGroup
+	for range followers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hub, err := Listen(path, &recordNotifier{})
+			switch {
+			case err == nil:
+				hubs <- hub
+			case !errors.Is(err, ErrSessionActive):
+				t.Errorf("Listen() = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(hubs)
+
+	leaders := 0
+	for hub := range hubs {
+		leaders++
+		defer hub.Close()
+	}
+	if leaders != 1 {
+		t.Errorf("%d sessions took over, want 1", leaders)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reminder.sock")
+	next := &recordNotifier{}
+	hub, err := Listen(path, next)
+	if err != nil {
+		t.Fatalf("Listen() = %v", err)
+	}
+	defer hub.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	feed, err := Follow(ctx, path)
+	if err != nil {
+		t.Fatalf("Follow() = %v", err)
+	}
+
+	// The follower connects in the background; retry until it is counted
+	task := &models.Task{ID: "t1", Title: "Call back"}
+	deadline := time.Now().Add(time.Second)
+	for {
+		hub.mu.Lock()
+		n := len(hub.clients)
+		hub.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("follower never connected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := hub.Notify(task); err != nil {
+		t.Fatalf("Notify() = %v", err)
+	}
+	if next.count() != 1 {
+		t.Errorf("leader's notifier got %d reminders, want 1", next.count())
+	}
+	select {
+	case e := <-feed:
+		if e.TaskID != task.ID || e.Title != task.Title {
+			t.Errorf("follower got %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("follower got no reminder")
+	}
+
+	// A follower nobody reads from stops once cancelled
+	hub.Notify(task)
+	cancel()
+	select {
+	case _, ok := <-feed:
+		if ok {
+			// The reminder may have been passed on before the cancel
+			if _, ok := <-feed; ok {
+				t.Error("feed still open after cancel")
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("feed not closed after cancel")
+	}
+}
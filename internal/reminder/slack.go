@@ -0,0 +1,60 @@
+package reminder
+
+import (
+	"context"
+	"strings"
+	"text/template"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/report"
+)
+
+// DefaultSlackTemplate is the reminder message posted to Slack. It is
+// rendered with a Message.
+const DefaultSlackTemplate = `{{.Emoji}} *{{.Title}}*{{if .Due}} — due {{.Due}}{{end}}`
+
+var priorityEmoji = map[models.Priority]string{
+	models.LowPriority:    ":white_circle:",
+	models.MediumPriority: ":large_yellow_circle:",
+	models.HighPriority:   ":red_circle:",
+}
+
+// SlackNotifier posts reminders to a Slack incoming webhook.
+type SlackNotifier struct {
+	Webhook  string
+	Template *template.Template
+}
+
+// ParseSlackTemplate parses a reminder message template, falling back to
+// DefaultSlackTemplate when text is empty.
+func ParseSlackTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultSlackTemplate
+	}
+	return ParseMessageTemplate("slack", text)
+}
+
+func (n *SlackNotifier) Notify(task *models.Task) error {
+	text, err := n.render(task)
+	if err != nil {
+		return err
+	}
+	return report.Post(context.Background(), n.Webhook, text)
+}
+
+// NotifyDigest posts one message with a line per task.
+func (n *SlackNotifier) NotifyDigest(d Digest) error {
+	lines := []string{"*" + d.Title + "*"}
+	for _, task := range d.Tasks {
+		text, err := n.render(task)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, text)
+	}
+	return report.Post(context.Background(), n.Webhook, strings.Join(lines, "\n"))
+}
+
+func (n *SlackNotifier) render(task *models.Task) (string, error) {
+	return renderMessage(n.Template, task)
+}
@@ -0,0 +1,58 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// DefaultSnooze is how long the Snooze button on a reminder postpones it
+// unless configured otherwise.
+const DefaultSnooze = time.Hour
+
+// Snooze postpones the task's reminder by d. Saving the task re-arms it in
+// whichever service holds notification duty, so it fires again once the
+// snooze is over.
+func Snooze(ctx context.Context, s storage.Storage, id models.TaskID, d time.Duration) (*models.Task, error) {
+	if d <= 0 {
+		return nil, fmt.Errorf("can't snooze for %s", d)
+	}
+	task, err := s.GetTask(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+	task = task.Clone()
+	task.Snooze(d)
+	if err := s.SaveTask(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to snooze task: %w", err)
+	}
+	return task, nil
+}
+
+// Snooze postpones a reminder the service sent and re-arms it straight
+// away, without waiting for the storage event, so it goes out again after
+// d.
+func (r *ReminderService) Snooze(ctx context.Context, id models.TaskID, d time.Duration) (*models.Task, error) {
+	task, err := Snooze(ctx, r.storage, id, d)
+	if err != nil {
+		return nil, err
+	}
+	r.remindersMutex.Lock()
+	delete(r.sentReminders, id)
+	r.remindersMutex.Unlock()
+	return task, nil
+}
+
+// snoozeLabel names a snooze length on a button, e.g. "1h" or "1h30m".
+func snoozeLabel(d time.Duration) string {
+	s := d.Round(time.Minute).String()
+	s = strings.TrimSuffix(s, "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
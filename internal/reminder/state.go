@@ -0,0 +1,101 @@
+package reminder
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// StatePath is where the reminder service records which reminders went
+// out and when.
+func StatePath(dataDir string) string {
+	return filepath.Join(dataDir, "reminders-sent.json")
+}
+
+// loadState restores the reminders sent before the service started. A
+// missing or unreadable file only means they may be sent again.
+func (r *ReminderService) loadState() {
+	if r.statePath == "" {
+		return
+	}
+	data, err := os.ReadFile(r.statePath)
+	if os.IsNotExist(err) {
+		return
+	}
+	sent := make(map[models.TaskID]time.Time)
+	if err == nil {
+		err = json.Unmarshal(data, &sent)
+	}
+	if err != nil {
+		r.logger.Printf("error loading sent reminders: %v", err)
+		return
+	}
+
+	r.remindersMutex.Lock()
+	defer r.remindersMutex.Unlock()
+	for id, at := range sent {
+		if at.After(r.sentReminders[id]) {
+			r.sentReminders[id] = at
+		}
+	}
+}
+
+func (r *ReminderService) saveState() {
+	if r.statePath == "" {
+		return
+	}
+	r.remindersMutex.Lock()
+	data, err := json.MarshalIndent(r.sentReminders, "", "  ")
+	r.remindersMutex.Unlock()
+	if err == nil {
+		err = os.WriteFile(r.statePath, data, 0644)
+	}
+	if err != nil {
+		r.logger.Printf("error saving sent reminders: %v", err)
+	}
+}
+
+// RewriteStateIDs moves the reminders recorded as sent at path to the
+// tasks' new IDs, for `notes migrate ids`.
+func RewriteStateIDs(path string, rewrite *models.IDRewrite) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read sent reminders: %w", err)
+	}
+	var sent map[models.TaskID]time.Time
+	if err := json.Unmarshal(data, &sent); err != nil {
+		return fmt.Errorf("failed to parse sent reminders: %w", err)
+	}
+
+	ids := make([]models.TaskID, 0, len(sent))
+	for id := range sent {
+		ids = append(ids, id)
+	}
+	times := make([]time.Time, len(ids))
+	for i, id := range ids {
+		times[i] = sent[id]
+	}
+	rewrite.TaskIDs(ids)
+	rewritten := make(map[models.TaskID]time.Time, len(ids))
+	for i, id := range ids {
+		if times[i].After(rewritten[id]) {
+			rewritten[id] = times[i]
+		}
+	}
+
+	if data, err = json.MarshalIndent(rewritten, "", "  "); err != nil {
+		return fmt.Errorf("failed to marshal sent reminders: %w", err)
+	}
+	if err := storage.WriteFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write sent reminders: %w", err)
+	}
+	return nil
+}
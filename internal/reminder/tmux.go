@@ -0,0 +1,82 @@
+package reminder
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// TmuxNotifier shows reminders in every attached tmux client with
+// display-message, so they are seen whichever pane has focus.
+type TmuxNotifier struct {
+	// Duration is how long the message stays up; zero uses tmux's
+	// display-time.
+	Duration time.Duration
+	// StatusOption, when set, is a global user option (e.g. "@reminder")
+	// set to the latest reminder for use in status-right as
+	// #{@reminder}.
+	StatusOption string
+	// Template, when set, renders the message in place of the built-in
+	// wording.
+	Template *template.Template
+}
+
+func (n *TmuxNotifier) Notify(task *models.Task) error {
+	msg := "⏰ " + task.Title
+	if !task.DueDate.IsZero() {
+		msg += " (due " + task.DueDate.Format("Jan 2 3:04 PM") + ")"
+	}
+	if n.Template != nil {
+		var err error
+		if msg, err = renderMessage(n.Template, task); err != nil {
+			return err
+		}
+	}
+	return n.show(msg)
+}
+
+func (n *TmuxNotifier) NotifyDigest(d Digest) error {
+	titles := make([]string, len(d.Tasks))
+	for i, task := range d.Tasks {
+		titles[i] = task.Title
+	}
+	return n.show("⏰ " + d.Title + ": " + strings.Join(titles, ", "))
+}
+
+func (n *TmuxNotifier) show(msg string) error {
+	// tmux expands #{...} and strftime sequences in messages
+	msg = strings.ReplaceAll(msg, "#", "##")
+	msg = strings.ReplaceAll(msg, "%", "%%")
+
+	if n.StatusOption != "" {
+		if out, err := exec.Command("tmux", "set-option", "-g", n.StatusOption, msg).CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to set tmux option %s: %v: %s", n.StatusOption, err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	out, err := exec.Command("tmux", "list-clients", "-F", "#{client_name}").Output()
+	if err != nil {
+		return fmt.Errorf("failed to list tmux clients (is a tmux server running?): %w", err)
+	}
+	clients := strings.Fields(string(out))
+	if len(clients) == 0 && n.StatusOption == "" {
+		return fmt.Errorf("no tmux clients attached")
+	}
+
+	for _, client := range clients {
+		args := []string{"display-message", "-c", client}
+		if n.Duration > 0 {
+			args = append(args, "-d", strconv.FormatInt(n.Duration.Milliseconds(), 10))
+		}
+		args = append(args, msg)
+		if out, err := exec.Command("tmux", args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("tmux display-message failed: %v: %s", err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
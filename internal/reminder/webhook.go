@@ -0,0 +1,108 @@
+package reminder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// webhookTimeout bounds each post, so an unreachable endpoint can't hold up
+// the other channels.
+const webhookTimeout = 30 * time.Second
+
+// WebhookNotifier posts reminders as JSON to any HTTP endpoint, for
+// automations that aren't Slack or ntfy.
+type WebhookNotifier struct {
+	URL string
+	// Template, when set, renders the message field in place of the
+	// title.
+	Template *template.Template
+}
+
+// WebhookPayload is the JSON body posted for a reminder. A digest carries
+// its tasks, each as a payload of its own.
+type WebhookPayload struct {
+	TaskID   models.TaskID    `json:"task_id,omitempty"`
+	Title    string           `json:"title"`
+	Message  string           `json:"message,omitempty"`
+	Due      *time.Time       `json:"due,omitempty"`
+	Priority string           `json:"priority,omitempty"`
+	Tags     []string         `json:"tags,omitempty"`
+	Tasks    []WebhookPayload `json:"tasks,omitempty"`
+}
+
+func (n *WebhookNotifier) Notify(task *models.Task) error {
+	payload, err := n.payload(task)
+	if err != nil {
+		return err
+	}
+	return n.post(payload)
+}
+
+// NotifyDigest posts one payload listing every task.
+func (n *WebhookNotifier) NotifyDigest(d Digest) error {
+	payload := WebhookPayload{Title: d.Title}
+	for _, task := range d.Tasks {
+		p, err := n.payload(task)
+		if err != nil {
+			return err
+		}
+		payload.Tasks = append(payload.Tasks, p)
+	}
+	return n.post(payload)
+}
+
+func (n *WebhookNotifier) payload(task *models.Task) (WebhookPayload, error) {
+	p := WebhookPayload{
+		TaskID:   task.ID,
+		Title:    task.Title,
+		Message:  task.Title,
+		Priority: strings.ToLower(task.Priority.String()),
+		Tags:     task.Tags,
+	}
+	if !task.DueDate.IsZero() {
+		due := task.DueDate
+		p.Due = &due
+	}
+	if n.Template != nil {
+		var err error
+		if p.Message, err = renderMessage(n.Template, task); err != nil {
+			return p, err
+		}
+	}
+	return p, nil
+}
+
+func (n *WebhookNotifier) post(payload WebhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode reminder: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post reminder: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post reminder: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
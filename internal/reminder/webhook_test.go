@@ -0,0 +1,75 @@
+package reminder
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+func TestWebhookNotify(t *testing.T) {
+	var got WebhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("got %s with Content-Type %q, want a JSON POST", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding body: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	due := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	task := models.NewTask("Pay rent", "", due)
+	task.SetPriority(models.HighPriority)
+	tmpl, err := ParseMessageTemplate("webhook", "{{.Title}} is due")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n := &WebhookNotifier{URL: srv.URL, Template: tmpl}
+	if err := n.Notify(task); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got.TaskID != task.ID || got.Title != "Pay rent" || got.Message != "Pay rent is due" || got.Priority != "high" {
+		t.Errorf("payload = %+v", got)
+	}
+	if got.Due == nil || !got.Due.Equal(due) {
+		t.Errorf("due = %v, want %v", got.Due, due)
+	}
+}
+
+func TestWebhookNotifyError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no such hook", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	n := &WebhookNotifier{URL: srv.URL}
+	err := n.Notify(models.NewTask("Pay rent", "", time.Now()))
+	if err == nil || !strings.Contains(err.Error(), "404") || !strings.Contains(err.Error(), "no such hook") {
+		t.Errorf("Notify = %v, want the status and body", err)
+	}
+}
+
+func TestEmailMessage(t *testing.T) {
+	n := &EmailNotifier{From: "notes@example.com", To: []string{"a@example.com", "b@example.com"}}
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	msg := string(n.message("Reminder: Pay rent", "Due today\nDon't forget", now))
+
+	for _, want := range []string{
+		"From: notes@example.com\r\n",
+		"To: a@example.com, b@example.com\r\n",
+		"Subject: Reminder: Pay rent\r\n",
+		"Date: Sun, 01 Mar 2026 09:00:00 +0000\r\n",
+		"\r\n\r\nDue today\r\nDon't forget\r\n",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message missing %q:\n%s", want, msg)
+		}
+	}
+}
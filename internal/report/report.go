@@ -0,0 +1,96 @@
+// Package report builds standup summaries of tasks and posts them to chat
+// webhooks on a schedule.
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// DefaultTemplate lays a report out as three bulleted sections.
+const DefaultTemplate = `*{{.Name}} · {{.Date.Format "Mon Jan 2"}}*
+Completed since {{.Since.Format "Monday"}}:
+{{range .Completed}}• {{.Title}}
+{{else}}• nothing
+{{end}}
+Planned today:
+{{range .Planned}}• {{.Title}}{{if .DueDate.IsZero | not}} (due {{.DueDate.Format "15:04"}}){{end}}
+{{else}}• nothing
+{{end}}
+Blockers (overdue):
+{{range .Blockers}}• {{.Title}} (due {{.DueDate.Format "Jan 2"}})
+{{else}}• none
+{{end}}`
+
+// Report is the data a report template is executed with.
+type Report struct {
+	Name string
+	// Date is when the report is for; Since is the start of the previous
+	// report day, so Monday's report covers Friday.
+	Date  time.Time
+	Since time.Time
+
+	Completed []*models.Task
+	Planned   []*models.Task
+	Blockers  []*models.Task
+}
+
+// Build sorts the tasks matching filter into the report's sections.
+// Planned tasks are those in progress or due today; blockers are open
+// tasks past their due date.
+func Build(name string, tasks []*models.Task, filter models.Filter, since, now time.Time) *Report {
+	r := &Report{Name: name, Date: now, Since: since}
+	y, m, d := now.Date()
+	endOfDay := time.Date(y, m, d+1, 0, 0, 0, 0, now.Location())
+
+	for _, task := range tasks {
+		if !filter.MatchTask(task, now) {
+			continue
+		}
+		switch {
+		case task.Status == models.TaskStatusCompleted:
+			if !task.CompletedAt.Before(since) && task.CompletedAt.Before(now) {
+				r.Completed = append(r.Completed, task)
+			}
+		case !task.IsOpen():
+		case task.DueDate.IsZero() && task.Status != models.TaskStatusInProgress:
+		case !task.DueDate.IsZero() && task.DueDate.Before(now) && !sameDay(task.DueDate, now):
+			r.Blockers = append(r.Blockers, task)
+		case task.Status == models.TaskStatusInProgress || task.DueDate.Before(endOfDay):
+			r.Planned = append(r.Planned, task)
+		}
+	}
+	return r
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// ParseTemplate checks a report template, using DefaultTemplate when text
+// is empty.
+func ParseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultTemplate
+	}
+	tmpl, err := template.New("report").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid report template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Render executes the template with the report.
+func (r *Report) Render(tmpl *template.Template) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, r); err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,197 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// catchUp is how late a report may still go out, e.g. when the app was
+// started after the scheduled time.
+const catchUp = 2 * time.Hour
+
+// Job is one configured report.
+type Job struct {
+	Name     string
+	Webhook  string
+	Schedule Schedule
+	Filter   models.Filter
+	// Project limits the report to the project with this name.
+	Project  string
+	Template *template.Template
+}
+
+// Run builds the job's report as of now.
+func (j *Job) Run(ctx context.Context, s storage.Storage, now time.Time) (string, error) {
+	tasks, err := s.GetAllTasks(ctx)
+	if err != nil {
+		return "", err
+	}
+	filter := j.Filter
+	if j.Project != "" {
+		projects, err := s.GetProjects(ctx)
+		if err != nil {
+			return "", err
+		}
+		project := models.FindProject(projects, j.Project)
+		if project == nil {
+			return "", fmt.Errorf("no project named %q", j.Project)
+		}
+		filter.Project = project.ID
+	}
+
+	// Completed work counts from the start of the previous report day
+	y, m, d := now.Date()
+	since := j.Schedule.Prev(time.Date(y, m, d, 0, 0, 0, 0, now.Location()).Add(-time.Second))
+	y, m, d = since.Date()
+	since = time.Date(y, m, d, 0, 0, 0, 0, now.Location())
+
+	return Build(j.Name, tasks, filter, since, now).Render(j.Template)
+}
+
+// StatePath is where the runner records when each report last went out.
+func StatePath(dataDir string) string {
+	return filepath.Join(dataDir, "reports.json")
+}
+
+// Runner posts reports when they fall due. Only the session holding
+// notification duty runs one, so each report is posted once.
+type Runner struct {
+	storage   storage.Storage
+	jobs      []*Job
+	statePath string
+	interval  time.Duration
+	logger    *log.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// warned records the occurrence whose failure was already reported
+	warned map[string]time.Time
+}
+
+func NewRunner(s storage.Storage, jobs []*Job, statePath string, interval time.Duration) *Runner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Runner{
+		storage:   s,
+		jobs:      jobs,
+		statePath: statePath,
+		interval:  interval,
+		logger:    log.Default(),
+		ctx:       ctx,
+		cancel:    cancel,
+		warned:    make(map[string]time.Time),
+	}
+}
+
+// SetLogger sends the runner's errors to logger instead of standard error.
+func (r *Runner) SetLogger(logger *log.Logger) {
+	r.logger = logger
+}
+
+func (r *Runner) Start() {
+	if len(r.jobs) == 0 {
+		return
+	}
+	r.wg.Add(1)
+	go r.loop()
+}
+
+func (r *Runner) Stop() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *Runner) loop() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.check(time.Now())
+	for {
+		select {
+		case <-ticker.C:
+			r.check(time.Now())
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// check posts every report whose latest occurrence hasn't gone out yet.
+// Failed posts are retried on the next check until the catch-up window
+// closes.
+func (r *Runner) check(now time.Time) {
+	sent, err := loadState(r.statePath)
+	if err != nil {
+		r.logger.Printf("error checking reports: %v", err)
+		return
+	}
+
+	for _, job := range r.jobs {
+		due := job.Schedule.Prev(now)
+		if due.IsZero() || !due.After(sent[job.Name]) || now.Sub(due) > catchUp {
+			continue
+		}
+		if err := r.post(job, now); err != nil {
+			if !r.warned[job.Name].Equal(due) {
+				r.warned[job.Name] = due
+				r.logger.Printf("error posting report %s: %v", job.Name, err)
+			}
+			continue
+		}
+		sent[job.Name] = due
+		if err := saveState(r.statePath, sent); err != nil {
+			r.logger.Printf("error checking reports: %v", err)
+		}
+	}
+}
+
+func (r *Runner) post(job *Job, now time.Time) error {
+	text, err := job.Run(r.ctx, r.storage, now)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	return Post(r.ctx, job.Webhook, text)
+}
+
+func loadState(path string) (map[string]time.Time, error) {
+	sent := make(map[string]time.Time)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return sent, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report state: %w", err)
+	}
+	if err := json.Unmarshal(data, &sent); err != nil {
+		return nil, fmt.Errorf("failed to parse report state: %w", err)
+	}
+	return sent, nil
+}
+
+func saveState(path string, sent map[string]time.Time) error {
+	data, err := json.MarshalIndent(sent, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report state: %w", err)
+	}
+	return nil
+}
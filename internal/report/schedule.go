@@ -0,0 +1,81 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Schedule is a time of day on a set of weekdays.
+type Schedule struct {
+	Hour, Minute int
+	Days         [7]bool
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseSchedule reads a time such as "09:00" and day names such as "mon"
+// or "friday". No days means Monday to Friday; "daily" and "weekdays" are
+// accepted as shorthands.
+func ParseSchedule(at string, days []string) (Schedule, error) {
+	var s Schedule
+	t, err := time.Parse("15:04", strings.TrimSpace(at))
+	if err != nil {
+		return s, fmt.Errorf("invalid time %q; use HH:MM", at)
+	}
+	s.Hour, s.Minute = t.Hour(), t.Minute()
+
+	if len(days) == 0 {
+		days = []string{"weekdays"}
+	}
+	for _, day := range days {
+		day = strings.ToLower(strings.TrimSpace(day))
+		switch day {
+		case "daily":
+			for i := range s.Days {
+				s.Days[i] = true
+			}
+		case "weekdays":
+			for d := time.Monday; d <= time.Friday; d++ {
+				s.Days[d] = true
+			}
+		default:
+			if len(day) < 3 {
+				return s, fmt.Errorf("unknown day %q", day)
+			}
+			wd, ok := weekdayNames[day[:3]]
+			if !ok {
+				return s, fmt.Errorf("unknown day %q", day)
+			}
+			s.Days[wd] = true
+		}
+	}
+	return s, nil
+}
+
+// Prev returns the latest scheduled time at or before t.
+func (s Schedule) Prev(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), s.Hour, s.Minute, 0, 0, t.Location())
+	for i := 0; i < 8; i++ {
+		if s.Days[day.Weekday()] && !day.After(t) {
+			return day
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return time.Time{}
+}
+
+// Next returns the first scheduled time after t.
+func (s Schedule) Next(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), s.Hour, s.Minute, 0, 0, t.Location())
+	for i := 0; i < 8; i++ {
+		if s.Days[day.Weekday()] && day.After(t) {
+			return day
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return time.Time{}
+}
@@ -0,0 +1,126 @@
+package script
+
+import (
+	"strings"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// taskTable exposes a task to scripts. Dates are Unix seconds, 0 when unset.
+func taskTable(L *lua.LState, task *models.Task) *lua.LTable {
+	tbl := L.NewTable()
+	tbl.RawSetString("kind", lua.LString("task"))
+	tbl.RawSetString("id", lua.LString(task.ID))
+	tbl.RawSetString("title", lua.LString(task.Title))
+	tbl.RawSetString("description", lua.LString(task.Description))
+	tbl.RawSetString("status", lua.LString(strings.ToLower(task.Status.String())))
+	tbl.RawSetString("priority", lua.LString(strings.ToLower(task.Priority.String())))
+	tbl.RawSetString("due", unixValue(task.DueDate))
+	tbl.RawSetString("tags", stringList(L, task.Tags))
+	tbl.RawSetString("context", lua.LString(task.Context))
+	return tbl
+}
+
+func noteTable(L *lua.LState, note *models.Note) *lua.LTable {
+	tbl := L.NewTable()
+	tbl.RawSetString("kind", lua.LString("note"))
+	tbl.RawSetString("id", lua.LString(note.ID))
+	tbl.RawSetString("title", lua.LString(note.Title))
+	tbl.RawSetString("content", lua.LString(note.Content))
+	tbl.RawSetString("priority", lua.LString(strings.ToLower(note.Priority.String())))
+	tbl.RawSetString("tags", stringList(L, note.Tags))
+	return tbl
+}
+
+// applyTask copies the script-editable fields back onto the task.
+func applyTask(tbl *lua.LTable, task *models.Task) error {
+	task.Title = lua.LVAsString(tbl.RawGetString("title"))
+	task.Description = lua.LVAsString(tbl.RawGetString("description"))
+	task.Tags = listStrings(tbl.RawGetString("tags"))
+	task.Context = models.NormalizeContext(lua.LVAsString(tbl.RawGetString("context")))
+
+	if status := lua.LVAsString(tbl.RawGetString("status")); status != strings.ToLower(task.Status.String()) {
+		parsed, err := models.ParseTaskStatus(status)
+		if err != nil {
+			return err
+		}
+		if err := task.Transition(parsed); err != nil {
+			return err
+		}
+	}
+	if err := applyPriority(tbl, &task.Priority); err != nil {
+		return err
+	}
+	if due := timeValue(tbl.RawGetString("due")); due.Unix() != task.DueDate.Unix() {
+		task.Reschedule(due)
+	}
+	task.UpdatedAt = time.Now()
+	return nil
+}
+
+func applyNote(tbl *lua.LTable, note *models.Note) error {
+	note.Title = lua.LVAsString(tbl.RawGetString("title"))
+	note.Content = lua.LVAsString(tbl.RawGetString("content"))
+	note.Tags = listStrings(tbl.RawGetString("tags"))
+	if err := applyPriority(tbl, &note.Priority); err != nil {
+		return err
+	}
+	note.UpdatedAt = time.Now()
+	return nil
+}
+
+func applyPriority(tbl *lua.LTable, priority *models.Priority) error {
+	value := lua.LVAsString(tbl.RawGetString("priority"))
+	if value == strings.ToLower(priority.String()) {
+		return nil
+	}
+	parsed, err := models.ParsePriority(value)
+	if err != nil {
+		return err
+	}
+	*priority = parsed
+	return nil
+}
+
+func unixValue(t time.Time) lua.LNumber {
+	if t.IsZero() {
+		return 0
+	}
+	return lua.LNumber(t.Unix())
+}
+
+func timeValue(v lua.LValue) time.Time {
+	n, ok := v.(lua.LNumber)
+	if !ok || n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(n), 0)
+}
+
+func stringList(L *lua.LState, items []string) *lua.LTable {
+	tbl := L.NewTable()
+	for _, item := range items {
+		tbl.Append(lua.LString(item))
+	}
+	return tbl
+}
+
+func listStrings(v lua.LValue) []string {
+	tbl, ok := v.(*lua.LTable)
+	if !ok {
+		return nil
+	}
+	var items []string
+	tbl.ForEach(func(_, value lua.LValue) {
+		if s := lua.LVAsString(value); s != "" {
+			items = append(items, s)
+		}
+	})
+	if len(items) == 0 {
+		return nil
+	}
+	return items
+}
@@ -0,0 +1,367 @@
+// Package script runs user Lua scripts on storage and reminder events so
+// automations such as auto-tagging can be added without recompiling.
+//
+// Every *.lua file in the scripts directory is loaded at startup. Scripts
+// register handlers with on(event, fn); fn receives the item as a table and
+// may change its fields, which are saved afterwards. Events:
+//
+//	task_created  a task was added
+//	note_created  a note was added
+//	reminder      a reminder is about to be delivered; return false to
+//	              suppress it
+//
+// Scripts also get tasks() and notes() to read everything, save(item) to
+// write another item back, and log(...) to write to the script log. Only
+// the base, string, table and math libraries are available; there is no
+// file, OS or module access.
+package script
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+const (
+	EventTaskCreated = "task_created"
+	EventNoteCreated = "note_created"
+	EventReminder    = "reminder"
+)
+
+// handlerTimeout bounds how long one handler may run.
+const handlerTimeout = 2 * time.Second
+
+func DefaultDir(dataDir string) string {
+	return filepath.Join(dataDir, "scripts")
+}
+
+type Engine struct {
+	storage     storage.Storage
+	logger      *log.Logger
+	mutex       sync.Mutex
+	state       *lua.LState
+	ctx         context.Context
+	handlers    map[string][]*lua.LFunction
+	unsubscribe func()
+}
+
+// Load runs every script in dir so they can register handlers. It returns
+// nil when the directory holds no scripts.
+func Load(dir string, s storage.Storage, logger *log.Logger) (*Engine, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.lua"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scripts: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	sort.Strings(files)
+
+	e := &Engine{
+		storage:  s,
+		logger:   logger,
+		state:    newSandbox(),
+		ctx:      context.Background(),
+		handlers: make(map[string][]*lua.LFunction),
+	}
+	e.register()
+
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			e.state.Close()
+			return nil, fmt.Errorf("failed to read script: %w", err)
+		}
+		fn, err := e.state.Load(bytes.NewReader(src), filepath.Base(file))
+		if err == nil {
+			err = e.call(fn)
+		}
+		if err != nil {
+			e.state.Close()
+			return nil, fmt.Errorf("failed to load script %s: %w", filepath.Base(file), err)
+		}
+	}
+	return e, nil
+}
+
+// newSandbox opens only the libraries that can't touch the system.
+func newSandbox() *lua.LState {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	for _, lib := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.open))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	for _, name := range []string{"dofile", "loadfile", "load", "loadstring", "require", "module"} {
+		L.SetGlobal(name, lua.LNil)
+	}
+	return L
+}
+
+func (e *Engine) register() {
+	L := e.state
+	L.SetGlobal("on", L.NewFunction(func(L *lua.LState) int {
+		event := L.CheckString(1)
+		fn := L.CheckFunction(2)
+		switch event {
+		case EventTaskCreated, EventNoteCreated, EventReminder:
+		default:
+			L.ArgError(1, fmt.Sprintf("unknown event %q", event))
+		}
+		e.handlers[event] = append(e.handlers[event], fn)
+		return 0
+	}))
+	L.SetGlobal("log", L.NewFunction(func(L *lua.LState) int {
+		args := make([]interface{}, L.GetTop())
+		for i := range args {
+			args[i] = L.Get(i + 1).String()
+		}
+		e.logger.Println(args...)
+		return 0
+	}))
+	L.SetGlobal("tasks", L.NewFunction(func(L *lua.LState) int {
+		tasks, err := e.storage.GetAllTasks(e.ctx)
+		if err != nil {
+			L.RaiseError("%v", err)
+		}
+		list := L.NewTable()
+		for _, task := range tasks {
+			list.Append(taskTable(L, task))
+		}
+		L.Push(list)
+		return 1
+	}))
+	L.SetGlobal("notes", L.NewFunction(func(L *lua.LState) int {
+		notes, err := e.storage.GetAllNotes(e.ctx)
+		if err != nil {
+			L.RaiseError("%v", err)
+		}
+		list := L.NewTable()
+		for _, note := range notes {
+			list.Append(noteTable(L, note))
+		}
+		L.Push(list)
+		return 1
+	}))
+	L.SetGlobal("save", L.NewFunction(func(L *lua.LState) int {
+		if err := e.saveTable(L.CheckTable(1)); err != nil {
+			L.RaiseError("%v", err)
+		}
+		return 0
+	}))
+}
+
+// Start runs the creation handlers for every new item saved from now on.
+func (e *Engine) Start() {
+	e.unsubscribe = e.storage.Events().Subscribe(e.handleStorageEvent)
+}
+
+func (e *Engine) Stop() {
+	if e.unsubscribe != nil {
+		e.unsubscribe()
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.state.Close()
+}
+
+func (e *Engine) handleStorageEvent(ev storage.Event) {
+	if !ev.Created {
+		return
+	}
+	switch ev.Kind {
+	case storage.TaskSaved:
+		e.runTask(EventTaskCreated, ev.Task.Clone())
+	case storage.NoteSaved:
+		e.runNote(EventNoteCreated, ev.Note.Clone())
+	}
+}
+
+// Notifier wraps next so reminder handlers run before each delivery.
+func (e *Engine) Notifier(next reminder.Notifier) reminder.Notifier {
+	return &scriptNotifier{engine: e, next: next}
+}
+
+type scriptNotifier struct {
+	engine *Engine
+	next   reminder.Notifier
+}
+
+func (n *scriptNotifier) Notify(task *models.Task) error {
+	if !n.engine.runTask(EventReminder, task.Clone()) {
+		return nil
+	}
+	return n.next.Notify(task)
+}
+
+// NotifyChannels passes on per-channel results; a reminder a handler
+// held back has none.
+func (n *scriptNotifier) NotifyChannels(task *models.Task) []reminder.ChannelResult {
+	if !n.engine.runTask(EventReminder, task.Clone()) {
+		return nil
+	}
+	return reminder.NotifyChannels(n.next, task, "")
+}
+
+// NotifyChannel retries a channel without running the handlers again;
+// they let the reminder through the first time.
+func (n *scriptNotifier) NotifyChannel(task *models.Task, channel string) error {
+	return reminder.NotifyChannel(n.next, task, channel)
+}
+
+// NotifyDigest runs the handlers for each task of the digest and sends
+// the ones they let through as one digest.
+func (n *scriptNotifier) NotifyDigest(d reminder.Digest) error {
+	d.Tasks = n.allowed(d.Tasks)
+	if len(d.Tasks) == 0 {
+		return nil
+	}
+	return reminder.SendDigest(n.next, d)
+}
+
+func (n *scriptNotifier) NotifyDigestChannels(d reminder.Digest) []reminder.DigestResult {
+	d.Tasks = n.allowed(d.Tasks)
+	if len(d.Tasks) == 0 {
+		return nil
+	}
+	return reminder.SendDigestChannels(n.next, d, "")
+}
+
+// allowed keeps the tasks no reminder handler held back
+func (n *scriptNotifier) allowed(tasks []*models.Task) []*models.Task {
+	var kept []*models.Task
+	for _, task := range tasks {
+		if n.engine.runTask(EventReminder, task.Clone()) {
+			kept = append(kept, task)
+		}
+	}
+	return kept
+}
+
+// runTask passes the task to every handler of the event and saves it if
+// they changed it. It reports false when a handler returned false.
+func (e *Engine) runTask(event string, task *models.Task) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	handlers := e.handlers[event]
+	if len(handlers) == 0 {
+		return true
+	}
+
+	tbl := taskTable(e.state, task)
+	proceed := e.runHandlers(event, handlers, tbl)
+
+	edited := task.Clone()
+	if err := applyTask(tbl, edited); err != nil {
+		e.logger.Printf("%s handler: %v", event, err)
+		return proceed
+	}
+	if len(models.DiffTasks(task, edited)) > 0 {
+		if err := e.storage.SaveTask(e.ctx, edited); err != nil {
+			e.logger.Printf("%s handler: failed to save task: %v", event, err)
+		}
+	}
+	return proceed
+}
+
+func (e *Engine) runNote(event string, note *models.Note) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	handlers := e.handlers[event]
+	if len(handlers) == 0 {
+		return
+	}
+
+	tbl := noteTable(e.state, note)
+	e.runHandlers(event, handlers, tbl)
+
+	edited := note.Clone()
+	if err := applyNote(tbl, edited); err != nil {
+		e.logger.Printf("%s handler: %v", event, err)
+		return
+	}
+	if len(models.DiffNotes(note, edited)) > 0 {
+		if err := e.storage.SaveNote(e.ctx, edited); err != nil {
+			e.logger.Printf("%s handler: failed to save note: %v", event, err)
+		}
+	}
+}
+
+func (e *Engine) runHandlers(event string, handlers []*lua.LFunction, arg lua.LValue) bool {
+	proceed := true
+	for _, fn := range handlers {
+		if err := e.call(fn, arg); err != nil {
+			e.logger.Printf("%s handler: %v", event, err)
+			continue
+		}
+		ret := e.state.Get(-1)
+		e.state.Pop(1)
+		if ret == lua.LFalse {
+			proceed = false
+		}
+	}
+	return proceed
+}
+
+// call runs fn with a deadline, leaving one return value on the stack.
+func (e *Engine) call(fn *lua.LFunction, args ...lua.LValue) error {
+	ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+	defer cancel()
+
+	e.state.SetContext(ctx)
+	defer e.state.RemoveContext()
+	e.ctx = ctx
+	defer func() { e.ctx = context.Background() }()
+
+	return e.state.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, args...)
+}
+
+// saveTable writes an item passed to save() back to storage.
+func (e *Engine) saveTable(tbl *lua.LTable) error {
+	id := lua.LVAsString(tbl.RawGetString("id"))
+	switch lua.LVAsString(tbl.RawGetString("kind")) {
+	case "task":
+		task, err := e.storage.GetTask(e.ctx, models.TaskID(id))
+		if err != nil {
+			return err
+		}
+		if err := applyTask(tbl, task); err != nil {
+			return err
+		}
+		return e.storage.SaveTask(e.ctx, task)
+	case "note":
+		note, err := e.storage.GetNote(e.ctx, models.NoteID(id))
+		if err != nil {
+			return err
+		}
+		if err := applyNote(tbl, note); err != nil {
+			return err
+		}
+		return e.storage.SaveNote(e.ctx, note)
+	default:
+		return errors.New("save expects a task or note")
+	}
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// Note bodies live in one file per note under notes/, so notes.json only
+// carries metadata and listing notes stays cheap. Notes written before this
+// layout keep their content inline until they are next saved.
+
+// ErrContentOmitted is returned when saving a note listed without its body,
+// which would otherwise erase the body on disk.
+var ErrContentOmitted = errors.New("note content not loaded")
+
+func (s *FileStorage) contentPath(id models.NoteID) string {
+	return filepath.Join(s.noteContentDir, string(id)+".md")
+}
+
+// GetNoteMeta returns every note with its Content left empty.
+func (s *FileStorage) GetNoteMeta(ctx context.Context) ([]*models.Note, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.loadNotes()
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range notes.Notes {
+		note.Content = ""
+		note.ContentOmitted = true
+	}
+	return notes.Notes, nil
+}
+
+func (s *FileStorage) GetNoteContent(ctx context.Context, id models.NoteID) (string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	notes, err := s.loadNotes()
+	if err != nil {
+		return "", err
+	}
+	for _, note := range notes.Notes {
+		if note.ID == id {
+			if err := s.loadNoteContent(note); err != nil {
+				return "", err
+			}
+			return note.Content, nil
+		}
+	}
+	return "", fmt.Errorf("note with ID %s %w", id, ErrNotFound)
+}
+
+// loadNoteContent fills in the note body from its content file, leaving
+// inline content from older data files untouched.
+func (s *FileStorage) loadNoteContent(note *models.Note) error {
+	data, err := os.ReadFile(s.contentPath(note.ID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read note content: %w", err)
+	}
+	note.Content = string(data)
+	return nil
+}
+
+// checkContent refuses notes whose body was never loaded.
+func checkContent(notes ...*models.Note) error {
+	for _, note := range notes {
+		if note.ContentOmitted {
+			return fmt.Errorf("note %q: %w", note.Title, ErrContentOmitted)
+		}
+	}
+	return nil
+}
+
+// writeNoteContent stores the note body and returns a copy of the note
+// without it, ready for notes.json.
+func (s *FileStorage) writeNoteContent(note *models.Note) (*models.Note, error) {
+	if err := os.MkdirAll(s.noteContentDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create note content directory: %w", err)
+	}
+	if err := WriteFileAtomic(s.contentPath(note.ID), []byte(note.Content)); err != nil {
+		return nil, fmt.Errorf("failed to write note content: %w", err)
+	}
+
+	meta := note.Clone()
+	meta.Content = ""
+	return meta, nil
+}
+
+func (s *FileStorage) removeNoteContent(id models.NoteID) error {
+	if err := os.Remove(s.contentPath(id)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove note content: %w", err)
+	}
+	return nil
+}
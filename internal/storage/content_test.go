@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+func TestNoteContentRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	note := models.NewNote("Long note", "the body")
+	if err := s.SaveNote(ctx, note); err != nil {
+		t.Fatalf("SaveNote() = %v", err)
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, "notes.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(index), "the body") {
+		t.Error("notes.json holds the note body")
+	}
+
+	metas, err := s.GetNoteMeta(ctx)
+	if err != nil {
+		t.Fatalf("GetNoteMeta() = %v", err)
+	}
+	if len(metas) != 1 {
+		t.Fatalf("GetNoteMeta() returned %d notes, want 1", len(metas))
+	}
+	meta := metas[0]
+	if meta.Content != "" || !meta.ContentOmitted {
+		t.Errorf("GetNoteMeta() note has Content %q, ContentOmitted %v", meta.Content, meta.ContentOmitted)
+	}
+
+	// Saving a note listed without its body must not erase the body
+	meta.Title = "Renamed"
+	if err := s.SaveNote(ctx, meta); !errors.Is(err, ErrContentOmitted) {
+		t.Errorf("SaveNote() of a meta note = %v, want ErrContentOmitted", err)
+	}
+	if err := s.SaveNotesBatch(ctx, []*models.Note{meta}); !errors.Is(err, ErrContentOmitted) {
+		t.Errorf("SaveNotesBatch() of a meta note = %v, want ErrContentOmitted", err)
+	}
+
+	got, err := s.GetNote(ctx, note.ID)
+	if err != nil {
+		t.Fatalf("GetNote() = %v", err)
+	}
+	if got.Content != "the body" || got.Title != "Long note" || got.ContentOmitted {
+		t.Errorf("GetNote() = %q %q, ContentOmitted %v", got.Title, got.Content, got.ContentOmitted)
+	}
+	content, err := s.GetNoteContent(ctx, note.ID)
+	if err != nil || content != "the body" {
+		t.Errorf("GetNoteContent() = %q, %v", content, err)
+	}
+
+	// Once the body is filled in the note saves normally
+	got.Content = "new body"
+	if err := s.SaveNote(ctx, got); err != nil {
+		t.Fatalf("SaveNote() = %v", err)
+	}
+	if content, err := s.GetNoteContent(ctx, note.ID); err != nil || content != "new body" {
+		t.Errorf("GetNoteContent() after save = %q, %v", content, err)
+	}
+
+	if _, err := s.GetNoteContent(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetNoteContent() of a missing note = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// ConvertNoteToTask creates a task from the note, due at due. The note is
+// kept and the task links back to it.
+func (s *FileStorage) ConvertNoteToTask(ctx context.Context, id models.NoteID, due time.Time) (*models.Task, error) {
+	if err := s.lock(); err != nil {
+		return nil, err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	notes, err := s.loadNotes()
+	if err != nil {
+		return nil, err
+	}
+	for _, note := range notes.Notes {
+		if note.ID != id {
+			continue
+		}
+		if err := s.loadNoteContent(note); err != nil {
+			return nil, err
+		}
+		task := note.ToTask(due)
+		if err := s.upsertTask(task); err != nil {
+			return nil, err
+		}
+		return task, nil
+	}
+	return nil, fmt.Errorf("note with ID %s %w", id, ErrNotFound)
+}
+
+// ConvertTaskToNote creates a note from the task. The task is kept and
+// linked to the new note.
+func (s *FileStorage) ConvertTaskToNote(ctx context.Context, id models.TaskID) (*models.Note, error) {
+	if err := s.lock(); err != nil {
+		return nil, err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.loadTasks()
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks.Tasks {
+		if task.ID != id {
+			continue
+		}
+		note := task.ToNote()
+		if err := s.upsertNote(note); err != nil {
+			return nil, err
+		}
+		task.LinkToNote(note.ID)
+		if err := s.upsertTask(task); err != nil {
+			return nil, err
+		}
+		return note, nil
+	}
+	return nil, fmt.Errorf("task with ID %s %w", id, ErrNotFound)
+}
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"sync"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+type EventKind int
+
+const (
+	NoteSaved EventKind = iota
+	NoteDeleted
+	TaskSaved
+	TaskDeleted
+	// NotesReloaded and TasksReloaded mean the data file was changed by
+	// another program; subscribers should reload everything.
+	NotesReloaded
+	TasksReloaded
+)
+
+// Event describes a change that was written to storage. Note and Task are
+// set for save events; only the ID is set for deletes. Created is set when a
+// save added a new item rather than replacing one.
+type Event struct {
+	Kind    EventKind
+	NoteID  models.NoteID
+	TaskID  models.TaskID
+	Note    *models.Note
+	Task    *models.Task
+	Created bool
+}
+
+// EventBus fans storage events out to subscribers. Every subscriber has its
+// own queue and goroutine, so handlers see events in order, may call back
+// into storage, and never block the writer.
+type EventBus struct {
+	mutex       sync.Mutex
+	subscribers map[int]*subscriber
+	nextID      int
+}
+
+type subscriber struct {
+	handler func(Event)
+	mutex   sync.Mutex
+	queue   []Event
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[int]*subscriber),
+	}
+}
+
+// Subscribe registers a handler for every event and returns a function
+// that removes it again.
+func (b *EventBus) Subscribe(handler func(Event)) (unsubscribe func()) {
+	sub := &subscriber{
+		handler: handler,
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+
+	b.mutex.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = sub
+	b.mutex.Unlock()
+
+	go sub.run()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			delete(b.subscribers, id)
+			b.mutex.Unlock()
+			close(sub.done)
+		})
+	}
+}
+
+func (b *EventBus) OnNoteSaved(fn func(*models.Note)) func() {
+	return b.Subscribe(func(e Event) {
+		if e.Kind == NoteSaved {
+			fn(e.Note)
+		}
+	})
+}
+
+func (b *EventBus) OnNoteDeleted(fn func(models.NoteID)) func() {
+	return b.Subscribe(func(e Event) {
+		if e.Kind == NoteDeleted {
+			fn(e.NoteID)
+		}
+	})
+}
+
+func (b *EventBus) OnTaskSaved(fn func(*models.Task)) func() {
+	return b.Subscribe(func(e Event) {
+		if e.Kind == TaskSaved {
+			fn(e.Task)
+		}
+	})
+}
+
+func (b *EventBus) OnTaskDeleted(fn func(models.TaskID)) func() {
+	return b.Subscribe(func(e Event) {
+		if e.Kind == TaskDeleted {
+			fn(e.TaskID)
+		}
+	})
+}
+
+func (b *EventBus) publish(e Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, sub := range b.subscribers {
+		sub.push(e)
+	}
+}
+
+func (sub *subscriber) push(e Event) {
+	sub.mutex.Lock()
+	sub.queue = append(sub.queue, e)
+	sub.mutex.Unlock()
+
+	select {
+	case sub.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (sub *subscriber) run() {
+	for {
+		select {
+		case <-sub.done:
+			return
+		case <-sub.wake:
+		}
+
+		for {
+			sub.mutex.Lock()
+			if len(sub.queue) == 0 {
+				sub.mutex.Unlock()
+				break
+			}
+			e := sub.queue[0]
+			sub.queue = sub.queue[1:]
+			sub.mutex.Unlock()
+
+			sub.handler(e)
+		}
+	}
+}
+
+func noteSavedEvent(note *models.Note, created bool) Event {
+	return Event{Kind: NoteSaved, NoteID: note.ID, Note: note, Created: created}
+}
+
+func taskSavedEvent(task *models.Task, created bool) Event {
+	return Event{Kind: TaskSaved, TaskID: task.ID, Task: task, Created: created}
+}
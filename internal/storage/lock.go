@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// The data files are shared by every process using the data directory:
+// the TUI, CLI commands and sync tools. Writers take a lock file, created
+// exclusively so it works the same on NTFS and Unix file systems without
+// relying on advisory locks.
+const (
+	// lockWait is how long a writer waits for the lock before giving up
+	// with ErrLocked.
+	lockWait = 5 * time.Second
+	// lockStale is the age after which a lock left by a crashed process is
+	// removed.
+	lockStale = 30 * time.Second
+	lockRetry = 20 * time.Millisecond
+	// renameAttempts covers Windows refusing to replace a file another
+	// process has open for a moment.
+	renameAttempts = 10
+)
+
+// ErrLocked is returned by writes when another process has held the data
+// lock for longer than lockWait.
+var ErrLocked = errors.New("data directory is locked by another process")
+
+type fileLock struct {
+	path string
+	held bool
+}
+
+func (l *fileLock) acquire() error {
+	deadline := time.Now().Add(lockWait)
+	for {
+		f, err := os.OpenFile(l.path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			f.WriteString(strconv.Itoa(os.Getpid()))
+			f.Close()
+			l.held = true
+			return nil
+		}
+		if info, statErr := os.Stat(l.path); statErr == nil && time.Since(info.ModTime()) > lockStale {
+			os.Remove(l.path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return ErrLocked
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+func (l *fileLock) release() {
+	if l.held {
+		os.Remove(l.path)
+		l.held = false
+	}
+}
+
+// lock takes the in-process mutex and the cross-process lock file,
+// holding neither if the lock file can't be had.
+func (s *FileStorage) lock() error {
+	s.mutex.Lock()
+	if err := s.fileLock.acquire(); err != nil {
+		s.mutex.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (s *FileStorage) unlock() {
+	s.fileLock.release()
+	s.mutex.Unlock()
+}
+
+// WriteFileAtomic replaces path with data by writing a temp file next to it
+// and renaming it over the original, so readers and the file watcher never
+// see a half-written file. Other files kept in the data directory are
+// written the same way.
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		err = os.Rename(tmp.Name(), path)
+		if err == nil || attempt == renameAttempts {
+			break
+		}
+		time.Sleep(time.Duration(attempt) * lockRetry)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to replace %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+func TestLockHeldElsewhere(t *testing.T) {
+	if testing.Short() {
+		t.Skip("waits out lockWait")
+	}
+	t.Parallel()
+	dir := t.TempDir()
+	s, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// Another process holds the lock and keeps it fresh
+	lockPath := filepath.Join(dir, "data.lock")
+	if err := os.WriteFile(lockPath, []byte("1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	err = s.SaveNote(context.Background(), models.NewNote("Blocked", ""))
+	if !errors.Is(err, ErrLocked) {
+		t.Fatalf("SaveNote() = %v, want ErrLocked", err)
+	}
+	if waited := time.Since(start); waited < lockWait {
+		t.Errorf("gave up after %v, want at least %v", waited, lockWait)
+	}
+	if _, err := os.Stat(lockPath); err != nil {
+		t.Errorf("the other process's lock was removed: %v", err)
+	}
+	notes, err := s.GetAllNotes(context.Background())
+	if err != nil || len(notes) != 0 {
+		t.Errorf("GetAllNotes() = %d note(s), %v, want none written", len(notes), err)
+	}
+}
+
+func TestStaleLockRemoved(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	// Left behind by a process that crashed a while ago
+	lockPath := filepath.Join(dir, "data.lock")
+	if err := os.WriteFile(lockPath, []byte("1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * lockStale)
+	if err := os.Chtimes(lockPath, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	if err := s.SaveNote(context.Background(), models.NewNote("After crash", "")); err != nil {
+		t.Fatalf("SaveNote() = %v", err)
+	}
+	if waited := time.Since(start); waited >= lockWait {
+		t.Errorf("waited %v for a stale lock", waited)
+	}
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Errorf("lock file left after the write: %v", err)
+	}
+}
+
+func TestLockSerializesWriters(t *testing.T) {
+	dir := t.TempDir()
+	// Two storages on one directory stand in for two processes
+	var stores []*FileStorage
+	for range 2 {
+		s, err := NewFileStorage(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer s.Close()
+		stores = append(stores, s)
+	}
+
+	const each = 20
+	var wg sync.WaitGroup
+	for i, s := range stores {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range each {
+				note := models.NewNote(fmt.Sprintf("writer %d note %d", i, j), "")
+				if err := s.SaveNote(context.Background(), note); err != nil {
+					t.Errorf("SaveNote() = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	notes, err := stores[0].GetAllNotes(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 2*each {
+		t.Errorf("%d notes saved, want %d; writes were lost", len(notes), 2*each)
+	}
+	leftovers, _ := filepath.Glob(filepath.Join(dir, ".*.tmp"))
+	if _, err := os.Stat(filepath.Join(dir, "data.lock")); !os.IsNotExist(err) || len(leftovers) > 0 {
+		t.Errorf("lock file (%v) or temp files %q left behind", err, leftovers)
+	}
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+type projectData struct {
+	Projects []*models.Project `json:"projects"`
+}
+
+func (s *FileStorage) SaveProject(ctx context.Context, project *models.Project) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	projects, err := s.loadProjects()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, p := range projects.Projects {
+		if p.ID == project.ID {
+			projects.Projects[i] = project
+			found = true
+			break
+		}
+	}
+	if !found {
+		projects.Projects = append(projects.Projects, project)
+	}
+	return s.saveProjects(projects)
+}
+
+func (s *FileStorage) GetProjects(ctx context.Context) ([]*models.Project, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	projects, err := s.loadProjects()
+	if err != nil {
+		return nil, err
+	}
+	return projects.Projects, nil
+}
+
+func (s *FileStorage) DeleteProject(ctx context.Context, id models.ProjectID) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	projects, err := s.loadProjects()
+	if err != nil {
+		return err
+	}
+	for i, p := range projects.Projects {
+		if p.ID == id {
+			projects.Projects = append(projects.Projects[:i], projects.Projects[i+1:]...)
+			if err := s.saveProjects(projects); err != nil {
+				return err
+			}
+			return s.detachProject(id)
+		}
+	}
+	return fmt.Errorf("project with ID %s not found", id)
+}
+
+// detachProject clears a deleted project from the notes and tasks in it.
+func (s *FileStorage) detachProject(id models.ProjectID) error {
+	notes, err := s.loadNotes()
+	if err != nil {
+		return err
+	}
+	var detachedNotes []*models.Note
+	for _, note := range notes.Notes {
+		if note.ProjectID == id {
+			note.ProjectID = ""
+			detachedNotes = append(detachedNotes, note)
+		}
+	}
+	if len(detachedNotes) > 0 {
+		if err := s.saveNotes(notes); err != nil {
+			return err
+		}
+	}
+
+	tasks, err := s.loadTasks()
+	if err != nil {
+		return err
+	}
+	var detachedTasks []*models.Task
+	for _, task := range tasks.Tasks {
+		if task.ProjectID == id {
+			task.ProjectID = ""
+			detachedTasks = append(detachedTasks, task)
+		}
+	}
+	if len(detachedTasks) > 0 {
+		if err := s.saveTasks(tasks); err != nil {
+			return err
+		}
+	}
+
+	for _, note := range detachedNotes {
+		s.events.publish(noteSavedEvent(note, false))
+	}
+	for _, task := range detachedTasks {
+		s.events.publish(taskSavedEvent(task, false))
+	}
+	return nil
+}
+
+func (s *FileStorage) loadProjects() (*projectData, error) {
+	projects := &projectData{
+		Projects: []*models.Project{},
+	}
+
+	data, err := os.ReadFile(s.projectsFilePath)
+	if os.IsNotExist(err) {
+		return projects, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read projects: %w", err)
+	}
+
+	if err := json.Unmarshal(data, projects); err != nil {
+		return nil, fmt.Errorf("failed to parse projects: %w", err)
+	}
+	return projects, nil
+}
+
+func (s *FileStorage) saveProjects(projects *projectData) error {
+	data, err := json.MarshalIndent(projects, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal projects: %w", err)
+	}
+
+	if err := WriteFileAtomic(s.projectsFilePath, data); err != nil {
+		return fmt.Errorf("failed to write projects: %w", err)
+	}
+	return nil
+}
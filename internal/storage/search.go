@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// SearchResult is a note or task matching a search, with its relevance.
+// Exactly one of Note and Task is set.
+type SearchResult struct {
+	Note  *models.Note
+	Task  *models.Task
+	Score int
+}
+
+func (r SearchResult) Title() string {
+	if r.Task != nil {
+		return r.Task.Title
+	}
+	return r.Note.Title
+}
+
+func (r SearchResult) updatedAt() time.Time {
+	if r.Task != nil {
+		return r.Task.UpdatedAt
+	}
+	return r.Note.UpdatedAt
+}
+
+// Weights for where a query term was found. Matching the start of a title
+// word beats matching inside it, titles beat tags, tags beat body text.
+const (
+	scoreTitleExact  = 20
+	scoreTitleWord   = 8
+	scoreTitleSubstr = 5
+	scoreTag         = 4
+	scoreBody        = 1
+	maxBodyHits      = 3
+)
+
+// SearchAll returns notes and tasks containing every word of the query in
+// their title, tags or body, best matches first.
+func (s *FileStorage) SearchAll(ctx context.Context, query string) ([]SearchResult, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	notes, err := s.loadNotes()
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := s.loadTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	for _, note := range notes.Notes {
+		if err := s.loadNoteContent(note); err != nil {
+			return nil, err
+		}
+		if score := scoreItem(terms, note.Title, note.Tags, note.Content); score > 0 {
+			results = append(results, SearchResult{Note: note, Score: score})
+		}
+	}
+	for _, task := range tasks.Tasks {
+		body := task.Description
+		for _, sub := range task.Subtasks {
+			body += "\n" + sub.Title
+		}
+		for _, value := range task.Fields {
+			body += "\n" + value
+		}
+		if score := scoreItem(terms, task.Title, task.Tags, body); score > 0 {
+			results = append(results, SearchResult{Task: task, Score: score})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].updatedAt().After(results[j].updatedAt())
+	})
+	return results, nil
+}
+
+// scoreItem returns 0 unless every term matches somewhere.
+func scoreItem(terms []string, title string, tags []string, body string) int {
+	title = strings.ToLower(title)
+	body = strings.ToLower(body)
+	titleWords := strings.Fields(title)
+
+	total := 0
+	if title == strings.Join(terms, " ") {
+		total += scoreTitleExact
+	}
+
+	for _, term := range terms {
+		score := 0
+
+		for _, w := range titleWords {
+			if strings.HasPrefix(w, term) {
+				score += scoreTitleWord
+				break
+			}
+		}
+		if score == 0 && strings.Contains(title, term) {
+			score += scoreTitleSubstr
+		}
+
+		for _, tag := range tags {
+			if strings.EqualFold(strings.TrimPrefix(term, "#"), tag) {
+				score += scoreTag
+				break
+			}
+		}
+
+		hits := strings.Count(body, term)
+		if hits > maxBodyHits {
+			hits = maxBodyHits
+		}
+		score += hits * scoreBody
+
+		if score == 0 {
+			return 0
+		}
+		total += score
+	}
+	return total
+}
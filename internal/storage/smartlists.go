@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+type smartListData struct {
+	SmartLists []*models.SmartList `json:"smart_lists"`
+}
+
+func (s *FileStorage) SaveSmartList(ctx context.Context, list *models.SmartList) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	lists, err := s.loadSmartLists()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i, l := range lists.SmartLists {
+		if l.ID == list.ID {
+			lists.SmartLists[i] = list
+			found = true
+			break
+		}
+	}
+	if !found {
+		lists.SmartLists = append(lists.SmartLists, list)
+	}
+	return s.saveSmartLists(lists)
+}
+
+func (s *FileStorage) GetSmartLists(ctx context.Context) ([]*models.SmartList, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	lists, err := s.loadSmartLists()
+	if err != nil {
+		return nil, err
+	}
+	return lists.SmartLists, nil
+}
+
+func (s *FileStorage) DeleteSmartList(ctx context.Context, id models.SmartListID) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	lists, err := s.loadSmartLists()
+	if err != nil {
+		return err
+	}
+	for i, l := range lists.SmartLists {
+		if l.ID == id {
+			lists.SmartLists = append(lists.SmartLists[:i], lists.SmartLists[i+1:]...)
+			return s.saveSmartLists(lists)
+		}
+	}
+	return fmt.Errorf("smart list with ID %s not found", id)
+}
+
+func (s *FileStorage) loadSmartLists() (*smartListData, error) {
+	lists := &smartListData{
+		SmartLists: []*models.SmartList{},
+	}
+
+	data, err := os.ReadFile(s.smartListsFilePath)
+	if os.IsNotExist(err) {
+		return lists, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read smart lists: %w", err)
+	}
+
+	if err := json.Unmarshal(data, lists); err != nil {
+		return nil, fmt.Errorf("failed to parse smart lists: %w", err)
+	}
+	return lists, nil
+}
+
+func (s *FileStorage) saveSmartLists(lists *smartListData) error {
+	data, err := json.MarshalIndent(lists, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal smart lists: %w", err)
+	}
+
+	if err := WriteFileAtomic(s.smartListsFilePath, data); err != nil {
+		return fmt.Errorf("failed to write smart lists: %w", err)
+	}
+	return nil
+}
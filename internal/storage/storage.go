@@ -1,41 +1,114 @@
 package storage
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/san-kum/reminder-tui/internal/models"
 )
 
+// ErrNotFound is wrapped by lookups of notes and tasks that don't exist.
+var ErrNotFound = errors.New("not found")
+
 type Storage interface {
 
 	// Notes operations
-	SaveNote(note *models.Note) error
-	GetNote(id models.NoteID) (*models.Note, error)
-	GetAllNotes() ([]*models.Note, error)
-	DeleteNote(id models.NoteID) error
+	SaveNote(ctx context.Context, note *models.Note) error
+	GetNote(ctx context.Context, id models.NoteID) (*models.Note, error)
+	GetAllNotes(ctx context.Context) ([]*models.Note, error)
+	// GetNoteMeta returns all notes without their content; fetch bodies
+	// individually with GetNoteContent.
+	GetNoteMeta(ctx context.Context) ([]*models.Note, error)
+	GetNoteContent(ctx context.Context, id models.NoteID) (string, error)
+	DeleteNote(ctx context.Context, id models.NoteID) error
+	// SaveNotesBatch upserts several notes in a single write.
+	SaveNotesBatch(ctx context.Context, notes []*models.Note) error
 
 	// Task operations
-	SaveTask(task *models.Task) error
-	GetTask(id models.TaskID) (*models.Task, error)
-	GetAllTasks() ([]*models.Task, error)
-	DeleteTask(id models.TaskID) error
+	SaveTask(ctx context.Context, task *models.Task) error
+	GetTask(ctx context.Context, id models.TaskID) (*models.Task, error)
+	GetAllTasks(ctx context.Context) ([]*models.Task, error)
+	DeleteTask(ctx context.Context, id models.TaskID) error
+	// SaveTasksBatch upserts several tasks in a single write.
+	SaveTasksBatch(ctx context.Context, tasks []*models.Task) error
 
 	// Query operations
-	GetTasksDueBefore(time time.Time) ([]*models.Task, error)
-	GetTasksWithRemindersBy(time time.Time) ([]*models.Task, error)
-	GetNotesByTag(tag string) ([]*models.Note, error)
-	GetTaskByTag(tag string) ([]*models.Task, error)
+	GetTasksDueBefore(ctx context.Context, time time.Time) ([]*models.Task, error)
+	GetTasksWithRemindersBy(ctx context.Context, time time.Time) ([]*models.Task, error)
+	GetNotesByTag(ctx context.Context, tag string) ([]*models.Note, error)
+	GetTaskByTag(ctx context.Context, tag string) ([]*models.Task, error)
+	// GetTasksByStatus returns tasks stored with any of the statuses.
+	GetTasksByStatus(ctx context.Context, statuses ...models.TaskStatus) ([]*models.Task, error)
+	GetTasksByPriority(ctx context.Context, priority models.Priority) ([]*models.Task, error)
+	// GetTasksDueBetween returns tasks due in [start, end), regardless of status.
+	GetTasksDueBetween(ctx context.Context, start, end time.Time) ([]*models.Task, error)
+	// ListAllTags returns every tag in use with counts, most used first.
+	ListAllTags(ctx context.Context) ([]TagCount, error)
+	// SearchAll returns matching notes and tasks, most relevant first.
+	SearchAll(ctx context.Context, query string) ([]SearchResult, error)
+
+	// Review queue operations
+	StageChange(ctx context.Context, change *models.Change) error
+	GetPendingChanges(ctx context.Context) ([]*models.Change, error)
+	AcceptChange(ctx context.Context, id models.ChangeID) error
+	RejectChange(ctx context.Context, id models.ChangeID) error
+
+	// Smart list operations
+	SaveSmartList(ctx context.Context, list *models.SmartList) error
+	GetSmartLists(ctx context.Context) ([]*models.SmartList, error)
+	DeleteSmartList(ctx context.Context, id models.SmartListID) error
+
+	// Project operations
+	SaveProject(ctx context.Context, project *models.Project) error
+	GetProjects(ctx context.Context) ([]*models.Project, error)
+	// DeleteProject removes a project and clears it from its notes and
+	// tasks.
+	DeleteProject(ctx context.Context, id models.ProjectID) error
+
+	// Trash operations. Trashed notes and tasks leave their lists but
+	// can be restored until the trash is emptied.
+	TrashNote(ctx context.Context, id models.NoteID) error
+	TrashTask(ctx context.Context, id models.TaskID) error
+	GetTrash(ctx context.Context) (*Trash, error)
+	RestoreNote(ctx context.Context, id models.NoteID) error
+	RestoreTask(ctx context.Context, id models.TaskID) error
+	// SaveTrash replaces everything in the trash.
+	SaveTrash(ctx context.Context, trash *Trash) error
+	EmptyTrash(ctx context.Context) error
+
+	// Conversions keep the original and link the two.
+	ConvertNoteToTask(ctx context.Context, id models.NoteID, due time.Time) (*models.Task, error)
+	ConvertTaskToNote(ctx context.Context, id models.TaskID) (*models.Note, error)
+
+	// Events returns the bus that publishes every successful write.
+	Events() *EventBus
 }
 
 type FileStorage struct {
-	notesFilePath string
-	tasksFilePath string
-	mutex         sync.RWMutex
+	notesFilePath      string
+	noteContentDir     string
+	tasksFilePath      string
+	reviewFilePath     string
+	smartListsFilePath string
+	projectsFilePath   string
+	trashFilePath      string
+	mutex              sync.RWMutex
+	fileLock           fileLock
+	events             *EventBus
+	tags               tagIndex
+
+	// Hashes of the last contents written, so Watch can tell our own
+	// writes from external ones.
+	notesHash [sha256.Size]byte
+	tasksHash [sha256.Size]byte
 }
 
 type notesData struct {
@@ -46,73 +119,228 @@ type taskData struct {
 	Tasks []*models.Task `json:"tasks"`
 }
 
+type reviewData struct {
+	Changes []*models.Change `json:"changes"`
+}
+
 func NewFileStorage(dataDir string) (*FileStorage, error) {
 	if err := os.MkdirAll(dataDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create data directory: %w", err)
 	}
 
-	return &FileStorage{
-		notesFilePath: filepath.Join(dataDir, "notes.json"),
-		tasksFilePath: filepath.Join(dataDir, "tasks.json"),
-	}, nil
+	s := &FileStorage{
+		notesFilePath:      filepath.Join(dataDir, "notes.json"),
+		noteContentDir:     filepath.Join(dataDir, "notes"),
+		tasksFilePath:      filepath.Join(dataDir, "tasks.json"),
+		reviewFilePath:     filepath.Join(dataDir, "review.json"),
+		smartListsFilePath: filepath.Join(dataDir, "smartlists.json"),
+		projectsFilePath:   filepath.Join(dataDir, "projects.json"),
+		trashFilePath:      filepath.Join(dataDir, "trash.json"),
+		fileLock:           fileLock{path: filepath.Join(dataDir, "data.lock")},
+		events:             NewEventBus(),
+	}
+	if err := s.createMissing(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// createMissing writes empty notes and tasks files where there are none
+// yet, under the write lock so readers never have to.
+func (s *FileStorage) createMissing() error {
+	if fileExists(s.notesFilePath) && fileExists(s.tasksFilePath) {
+		return nil
+	}
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if !fileExists(s.notesFilePath) {
+		if err := s.saveNotes(&notesData{Notes: []*models.Note{}}); err != nil {
+			return err
+		}
+	}
+	if !fileExists(s.tasksFilePath) {
+		if err := s.saveTasks(&taskData{Tasks: []*models.Task{}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return !os.IsNotExist(err)
+}
+
+func (s *FileStorage) Events() *EventBus {
+	return s.events
 }
 
-func (s *FileStorage) SaveNote(note *models.Note) error {
+// Close waits for a write in progress to finish, so the process doesn't
+// exit partway through a save.
+func (s *FileStorage) Close() error {
 	s.mutex.Lock()
-	defer s.mutex.Unlock()
+	s.mutex.Unlock()
+	return nil
+}
+
+func (s *FileStorage) SaveNote(ctx context.Context, note *models.Note) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.upsertNote(note)
+}
 
+func (s *FileStorage) upsertNote(note *models.Note) error {
+	if err := checkContent(note); err != nil {
+		return err
+	}
 	notes, err := s.loadNotes()
 	if err != nil {
 		return err
 	}
 
-	found := false
+	note.ResolveLinks(notes.Notes)
+	meta, err := s.writeNoteContent(note)
+	if err != nil {
+		return err
+	}
+
+	var old *models.Note
 	for i, n := range notes.Notes {
 		if n.ID == note.ID {
-			notes.Notes[i] = note
-			found = true
+			old = n
+			notes.Notes[i] = meta
 			break
 		}
 	}
 
-	if !found {
-		notes.Notes = append(notes.Notes, note)
+	if old == nil {
+		notes.Notes = append(notes.Notes, meta)
+	}
+	if err := s.saveNotes(notes); err != nil {
+		return err
+	}
+	s.tags.replaceNote(old, note)
+	s.events.publish(noteSavedEvent(note, old == nil))
+	return nil
+
+}
+
+func (s *FileStorage) SaveNotesBatch(ctx context.Context, batch []*models.Note) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := checkContent(batch...); err != nil {
+		return err
+	}
+
+	notes, err := s.loadNotes()
+	if err != nil {
+		return err
+	}
+
+	index := make(map[models.NoteID]int, len(notes.Notes))
+	for i, n := range notes.Notes {
+		index[n.ID] = i
+	}
+	olds := make([]*models.Note, len(batch))
+	// Titles in the batch win over stored ones, which they may be replacing
+	titled := append(append([]*models.Note(nil), batch...), notes.Notes...)
+	for j, note := range batch {
+		note.ResolveLinks(titled)
+		meta, err := s.writeNoteContent(note)
+		if err != nil {
+			return err
+		}
+		if i, ok := index[note.ID]; ok {
+			olds[j] = notes.Notes[i]
+			notes.Notes[i] = meta
+		} else {
+			index[note.ID] = len(notes.Notes)
+			notes.Notes = append(notes.Notes, meta)
+		}
 	}
-	return s.saveNotes(notes)
 
+	if err := s.saveNotes(notes); err != nil {
+		return err
+	}
+	for j, note := range batch {
+		s.tags.replaceNote(olds[j], note)
+		s.events.publish(noteSavedEvent(note, olds[j] == nil))
+	}
+	return nil
 }
 
-func (s *FileStorage) GetNote(id models.NoteID) (*models.Note, error) {
+func (s *FileStorage) GetNote(ctx context.Context, id models.NoteID) (*models.Note, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	notes, err := s.loadNotes()
 	if err != nil {
 		return nil, err
 	}
 	for _, note := range notes.Notes {
 		if note.ID == id {
+			if err := s.loadNoteContent(note); err != nil {
+				return nil, err
+			}
 			return note, nil
 		}
 	}
-	return nil, fmt.Errorf("note with ID %s not found", id)
+	return nil, fmt.Errorf("note with ID %s %w", id, ErrNotFound)
 }
 
-func (s *FileStorage) GetAllNotes() ([]*models.Note, error) {
+func (s *FileStorage) GetAllNotes(ctx context.Context) ([]*models.Note, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	notes, err := s.loadNotes()
 	if err != nil {
 		return nil, err
 	}
+	for _, note := range notes.Notes {
+		if err := s.loadNoteContent(note); err != nil {
+			return nil, err
+		}
+	}
 	return notes.Notes, nil
 }
 
-func (s *FileStorage) DeleteNote(id models.NoteID) error {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
+func (s *FileStorage) DeleteNote(ctx context.Context, id models.NoteID) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.deleteNote(id)
+}
 
+func (s *FileStorage) deleteNote(id models.NoteID) error {
 	notes, err := s.loadNotes()
 	if err != nil {
 		return err
@@ -121,41 +349,108 @@ func (s *FileStorage) DeleteNote(id models.NoteID) error {
 	for i, note := range notes.Notes {
 		if note.ID == id {
 			notes.Notes = append(notes.Notes[:i], notes.Notes[i+1:]...)
-			return s.saveNotes(notes)
+			if err := s.saveNotes(notes); err != nil {
+				return err
+			}
+			if err := s.removeNoteContent(id); err != nil {
+				return err
+			}
+			s.tags.replaceNote(note, nil)
+			s.events.publish(Event{Kind: NoteDeleted, NoteID: id})
+			return nil
 		}
 	}
-	return fmt.Errorf("note with ID %s not found.", id)
+	return fmt.Errorf("note with ID %s %w", id, ErrNotFound)
 }
 
-func (s *FileStorage) SaveTask(task *models.Task) error {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
+func (s *FileStorage) SaveTask(ctx context.Context, task *models.Task) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.upsertTask(task)
+}
 
+func (s *FileStorage) upsertTask(task *models.Task) error {
 	tasks, err := s.loadTasks()
 	if err != nil {
 		return err
 	}
 
-	found := false
+	var old *models.Task
 	for i, t := range tasks.Tasks {
 		if t.ID == task.ID {
+			old = t
 			tasks.Tasks[i] = task
-			found = true
 			break
 		}
 	}
 
-	if !found {
+	if old == nil {
 		tasks.Tasks = append(tasks.Tasks, task)
 	}
 
-	return s.saveTasks(tasks)
+	if err := s.saveTasks(tasks); err != nil {
+		return err
+	}
+	s.tags.replaceTask(old, task)
+	s.events.publish(taskSavedEvent(task, old == nil))
+	return nil
 }
 
-func (s *FileStorage) GetTask(id models.TaskID) (*models.Task, error) {
+func (s *FileStorage) SaveTasksBatch(ctx context.Context, batch []*models.Task) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tasks, err := s.loadTasks()
+	if err != nil {
+		return err
+	}
+
+	index := make(map[models.TaskID]int, len(tasks.Tasks))
+	for i, t := range tasks.Tasks {
+		index[t.ID] = i
+	}
+	olds := make([]*models.Task, len(batch))
+	for j, task := range batch {
+		if i, ok := index[task.ID]; ok {
+			olds[j] = tasks.Tasks[i]
+			tasks.Tasks[i] = task
+		} else {
+			index[task.ID] = len(tasks.Tasks)
+			tasks.Tasks = append(tasks.Tasks, task)
+		}
+	}
+
+	if err := s.saveTasks(tasks); err != nil {
+		return err
+	}
+	for j, task := range batch {
+		s.tags.replaceTask(olds[j], task)
+		s.events.publish(taskSavedEvent(task, olds[j] == nil))
+	}
+	return nil
+}
+
+func (s *FileStorage) GetTask(ctx context.Context, id models.TaskID) (*models.Task, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	tasks, err := s.loadTasks()
 	if err != nil {
 		return nil, err
@@ -165,12 +460,17 @@ func (s *FileStorage) GetTask(id models.TaskID) (*models.Task, error) {
 			return task, nil
 		}
 	}
-	return nil, fmt.Errorf("task with ID %s not found", err)
+	return nil, fmt.Errorf("task with ID %s %w", id, ErrNotFound)
 }
 
-func (s *FileStorage) GetAllTasks() ([]*models.Task, error) {
+func (s *FileStorage) GetAllTasks(ctx context.Context) ([]*models.Task, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	tasks, err := s.loadTasks()
 	if err != nil {
 		return nil, err
@@ -178,10 +478,19 @@ func (s *FileStorage) GetAllTasks() ([]*models.Task, error) {
 	return tasks.Tasks, nil
 }
 
-func (s *FileStorage) DeleteTask(id models.TaskID) error {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
+func (s *FileStorage) DeleteTask(ctx context.Context, id models.TaskID) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.deleteTask(id)
+}
+
+func (s *FileStorage) deleteTask(id models.TaskID) error {
 	tasks, err := s.loadTasks()
 	if err != nil {
 		return err
@@ -189,49 +498,68 @@ func (s *FileStorage) DeleteTask(id models.TaskID) error {
 	for i, task := range tasks.Tasks {
 		if task.ID == id {
 			tasks.Tasks = append(tasks.Tasks[:i], tasks.Tasks[i+1:]...)
-			return s.saveTasks(tasks)
+			if err := s.saveTasks(tasks); err != nil {
+				return err
+			}
+			s.tags.replaceTask(task, nil)
+			s.events.publish(Event{Kind: TaskDeleted, TaskID: id})
+			return nil
 		}
 	}
-	return fmt.Errorf("task with ID %s not found", id)
+	return fmt.Errorf("task with ID %s %w", id, ErrNotFound)
 }
 
-func (s *FileStorage) GetTasksDueBefore(time time.Time) ([]*models.Task, error) {
+func (s *FileStorage) GetTasksDueBefore(ctx context.Context, time time.Time) ([]*models.Task, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	allTasks, err := s.loadTasks()
 	if err != nil {
 		return nil, err
 	}
 	var result []*models.Task
 	for _, task := range allTasks.Tasks {
-		if task.DueDate.Before(time) && task.Status != models.TaskStatusCompleted {
+		if task.DueDate.Before(time) && task.IsOpen() {
 			result = append(result, task)
 		}
 	}
 	return result, nil
 }
 
-func (s *FileStorage) GetTasksWithRemindersBy(time time.Time) ([]*models.Task, error) {
+func (s *FileStorage) GetTasksWithRemindersBy(ctx context.Context, time time.Time) ([]*models.Task, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	allTasks, err := s.loadTasks()
 	if err != nil {
 		return nil, err
 	}
 	var result []*models.Task
 	for _, task := range allTasks.Tasks {
-		if task.ReminderAt.Before(time) && task.Status != models.TaskStatusCompleted {
+		// Cron schedules are evaluated by the reminder service
+		if (task.ReminderAt.Before(time) || task.Cron != "") && task.IsOpen() {
 			result = append(result, task)
 		}
 	}
 	return result, nil
 }
 
-func (s *FileStorage) GetNotesByTag(tag string) ([]*models.Note, error) {
+func (s *FileStorage) GetNotesByTag(ctx context.Context, tag string) ([]*models.Note, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	allNotes, err := s.loadNotes()
 	if err != nil {
 		return nil, err
@@ -241,6 +569,9 @@ func (s *FileStorage) GetNotesByTag(tag string) ([]*models.Note, error) {
 	for _, note := range allNotes.Notes {
 		for _, noteTag := range note.Tags {
 			if noteTag == tag {
+				if err := s.loadNoteContent(note); err != nil {
+					return nil, err
+				}
 				result = append(result, note)
 				break
 			}
@@ -250,10 +581,14 @@ func (s *FileStorage) GetNotesByTag(tag string) ([]*models.Note, error) {
 
 }
 
-func (s *FileStorage) GetTaskByTag(tag string) ([]*models.Task, error) {
+func (s *FileStorage) GetTaskByTag(ctx context.Context, tag string) ([]*models.Task, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	allTasks, err := s.loadTasks()
 	if err != nil {
 		return nil, err
@@ -270,17 +605,165 @@ func (s *FileStorage) GetTaskByTag(tag string) ([]*models.Task, error) {
 	return result, nil
 }
 
+func (s *FileStorage) GetTasksByStatus(ctx context.Context, statuses ...models.TaskStatus) ([]*models.Task, error) {
+	return s.filterTasks(ctx, func(task *models.Task) bool {
+		return slices.Contains(statuses, task.Status)
+	})
+}
+
+func (s *FileStorage) GetTasksByPriority(ctx context.Context, priority models.Priority) ([]*models.Task, error) {
+	return s.filterTasks(ctx, func(task *models.Task) bool {
+		return task.Priority == priority
+	})
+}
+
+func (s *FileStorage) GetTasksDueBetween(ctx context.Context, start, end time.Time) ([]*models.Task, error) {
+	return s.filterTasks(ctx, func(task *models.Task) bool {
+		return !task.DueDate.Before(start) && task.DueDate.Before(end)
+	})
+}
+
+func (s *FileStorage) filterTasks(ctx context.Context, match func(*models.Task) bool) ([]*models.Task, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	allTasks, err := s.loadTasks()
+	if err != nil {
+		return nil, err
+	}
+	var result []*models.Task
+	for _, task := range allTasks.Tasks {
+		if match(task) {
+			result = append(result, task)
+		}
+	}
+	return result, nil
+}
+
+func (s *FileStorage) StageChange(ctx context.Context, change *models.Change) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	review, err := s.loadReview()
+	if err != nil {
+		return err
+	}
+
+	// A newer incoming version of the same item replaces the staged one.
+	for i, c := range review.Changes {
+		if sameItem(c, change) {
+			review.Changes = append(review.Changes[:i], review.Changes[i+1:]...)
+			break
+		}
+	}
+	review.Changes = append(review.Changes, change)
+	return s.saveReview(review)
+}
+
+func (s *FileStorage) GetPendingChanges(ctx context.Context) ([]*models.Change, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	review, err := s.loadReview()
+	if err != nil {
+		return nil, err
+	}
+	return review.Changes, nil
+}
+
+func (s *FileStorage) AcceptChange(ctx context.Context, id models.ChangeID) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	review, change, err := s.takeChange(id)
+	if err != nil {
+		return err
+	}
+
+	if change.Note != nil {
+		err = s.upsertNote(change.Note)
+	} else if change.Task != nil {
+		err = s.upsertTask(change.Task)
+	}
+	if err != nil {
+		return err
+	}
+	return s.saveReview(review)
+}
+
+func (s *FileStorage) RejectChange(ctx context.Context, id models.ChangeID) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	review, _, err := s.takeChange(id)
+	if err != nil {
+		return err
+	}
+	return s.saveReview(review)
+}
+
+// takeChange removes a change from the loaded queue and returns both; the
+// caller decides whether to persist the shortened queue.
+func (s *FileStorage) takeChange(id models.ChangeID) (*reviewData, *models.Change, error) {
+	review, err := s.loadReview()
+	if err != nil {
+		return nil, nil, err
+	}
+	for i, change := range review.Changes {
+		if change.ID == id {
+			review.Changes = append(review.Changes[:i], review.Changes[i+1:]...)
+			return review, change, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("change with ID %s %w", id, ErrNotFound)
+}
+
+func sameItem(a, b *models.Change) bool {
+	if a.Note != nil && b.Note != nil {
+		return a.Note.ID == b.Note.ID
+	}
+	if a.Task != nil && b.Task != nil {
+		return a.Task.ID == b.Task.ID
+	}
+	return false
+}
+
 func (s *FileStorage) loadNotes() (*notesData, error) {
 	notes := &notesData{
 		Notes: []*models.Note{},
 	}
 
-	if _, err := os.Stat(s.notesFilePath); os.IsNotExist(err) {
-		return notes, s.saveNotes(notes)
-	}
-
 	// Read the file
 	data, err := os.ReadFile(s.notesFilePath)
+	if os.IsNotExist(err) {
+		return notes, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read notes: %w", err)
 	}
@@ -298,9 +781,10 @@ func (s *FileStorage) saveNotes(notes *notesData) error {
 		return fmt.Errorf("failed to marshal notes data: %w", err)
 	}
 
-	if err := os.WriteFile(s.notesFilePath, data, 0644); err != nil {
+	if err := WriteFileAtomic(s.notesFilePath, data); err != nil {
 		return fmt.Errorf("failed to write notes file: %w", err)
 	}
+	s.notesHash = sha256.Sum256(data)
 	return nil
 }
 
@@ -309,11 +793,10 @@ func (s *FileStorage) loadTasks() (*taskData, error) {
 		Tasks: []*models.Task{},
 	}
 
-	if _, err := os.Stat(s.tasksFilePath); os.IsNotExist(err) {
-		return tasks, s.saveTasks(tasks)
-	}
-
 	data, err := os.ReadFile(s.tasksFilePath)
+	if os.IsNotExist(err) {
+		return tasks, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to read tasks file: %w", err)
 	}
@@ -330,9 +813,41 @@ func (s *FileStorage) saveTasks(tasks *taskData) error {
 		return fmt.Errorf("failed to marshal tasks data: %w", err)
 	}
 
-	if err := os.WriteFile(s.tasksFilePath, data, 0644); err != nil {
+	if err := WriteFileAtomic(s.tasksFilePath, data); err != nil {
 		return fmt.Errorf("failed to write tasks: %w", err)
 	}
+	s.tasksHash = sha256.Sum256(data)
 
 	return nil
 }
+
+func (s *FileStorage) loadReview() (*reviewData, error) {
+	review := &reviewData{
+		Changes: []*models.Change{},
+	}
+
+	data, err := os.ReadFile(s.reviewFilePath)
+	if os.IsNotExist(err) {
+		return review, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read review queue: %w", err)
+	}
+
+	if err := json.Unmarshal(data, review); err != nil {
+		return nil, fmt.Errorf("failed to parse review queue: %w", err)
+	}
+	return review, nil
+}
+
+func (s *FileStorage) saveReview(review *reviewData) error {
+	data, err := json.MarshalIndent(review, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal review queue: %w", err)
+	}
+
+	if err := WriteFileAtomic(s.reviewFilePath, data); err != nil {
+		return fmt.Errorf("failed to write review queue: %w", err)
+	}
+	return nil
+}
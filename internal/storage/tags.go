@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+type TagCount struct {
+	Tag   string
+	Notes int
+	Tasks int
+}
+
+func (c TagCount) Total() int {
+	return c.Notes + c.Tasks
+}
+
+// tagIndex counts how many notes and tasks carry each tag. It is built from
+// the data files on first use and then kept current by every write.
+type tagIndex struct {
+	built bool
+	notes map[string]int
+	tasks map[string]int
+}
+
+func (ix *tagIndex) replaceNote(old, new *models.Note) {
+	if !ix.built {
+		return
+	}
+	if old != nil {
+		adjustTags(ix.notes, old.Tags, -1)
+	}
+	if new != nil {
+		adjustTags(ix.notes, new.Tags, 1)
+	}
+}
+
+func (ix *tagIndex) replaceTask(old, new *models.Task) {
+	if !ix.built {
+		return
+	}
+	if old != nil {
+		adjustTags(ix.tasks, old.Tags, -1)
+	}
+	if new != nil {
+		adjustTags(ix.tasks, new.Tags, 1)
+	}
+}
+
+func adjustTags(counts map[string]int, tags []string, delta int) {
+	for _, tag := range tags {
+		counts[tag] += delta
+		if counts[tag] <= 0 {
+			delete(counts, tag)
+		}
+	}
+}
+
+// ListAllTags returns every tag in use with its note and task counts, most
+// used first.
+func (s *FileStorage) ListAllTags(ctx context.Context) ([]TagCount, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if !s.tags.built {
+		if err := s.buildTagIndex(); err != nil {
+			return nil, err
+		}
+	}
+
+	byTag := make(map[string]*TagCount)
+	for tag, n := range s.tags.notes {
+		byTag[tag] = &TagCount{Tag: tag, Notes: n}
+	}
+	for tag, n := range s.tags.tasks {
+		if c, ok := byTag[tag]; ok {
+			c.Tasks = n
+		} else {
+			byTag[tag] = &TagCount{Tag: tag, Tasks: n}
+		}
+	}
+
+	result := make([]TagCount, 0, len(byTag))
+	for _, c := range byTag {
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Total() != result[j].Total() {
+			return result[i].Total() > result[j].Total()
+		}
+		return result[i].Tag < result[j].Tag
+	})
+	return result, nil
+}
+
+func (s *FileStorage) buildTagIndex() error {
+	notes, err := s.loadNotes()
+	if err != nil {
+		return err
+	}
+	tasks, err := s.loadTasks()
+	if err != nil {
+		return err
+	}
+
+	s.tags = tagIndex{
+		built: true,
+		notes: make(map[string]int),
+		tasks: make(map[string]int),
+	}
+	for _, note := range notes.Notes {
+		adjustTags(s.tags.notes, note.Tags, 1)
+	}
+	for _, task := range tasks.Tasks {
+		adjustTags(s.tags.tasks, task.Tags, 1)
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// Trash holds deleted notes and tasks until they are restored or the
+// trash is emptied. Trashed notes keep their content inline.
+type Trash struct {
+	Notes []*TrashedNote `json:"notes"`
+	Tasks []*TrashedTask `json:"tasks"`
+}
+
+type TrashedNote struct {
+	Note      *models.Note `json:"note"`
+	DeletedAt time.Time    `json:"deleted_at"`
+}
+
+type TrashedTask struct {
+	Task      *models.Task `json:"task"`
+	DeletedAt time.Time    `json:"deleted_at"`
+}
+
+// TrashNote moves a note to the trash.
+func (s *FileStorage) TrashNote(ctx context.Context, id models.NoteID) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	notes, err := s.loadNotes()
+	if err != nil {
+		return err
+	}
+	var note *models.Note
+	for _, n := range notes.Notes {
+		if n.ID == id {
+			note = n
+			break
+		}
+	}
+	if note == nil {
+		return fmt.Errorf("note with ID %s %w", id, ErrNotFound)
+	}
+	if err := s.loadNoteContent(note); err != nil {
+		return err
+	}
+
+	trash, err := s.loadTrash()
+	if err != nil {
+		return err
+	}
+	trash.Notes = append(trash.Notes, &TrashedNote{Note: note, DeletedAt: time.Now()})
+	if err := s.saveTrash(trash); err != nil {
+		return err
+	}
+	return s.deleteNote(id)
+}
+
+// TrashTask moves a task to the trash.
+func (s *FileStorage) TrashTask(ctx context.Context, id models.TaskID) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tasks, err := s.loadTasks()
+	if err != nil {
+		return err
+	}
+	var task *models.Task
+	for _, t := range tasks.Tasks {
+		if t.ID == id {
+			task = t
+			break
+		}
+	}
+	if task == nil {
+		return fmt.Errorf("task with ID %s %w", id, ErrNotFound)
+	}
+
+	trash, err := s.loadTrash()
+	if err != nil {
+		return err
+	}
+	trash.Tasks = append(trash.Tasks, &TrashedTask{Task: task, DeletedAt: time.Now()})
+	if err := s.saveTrash(trash); err != nil {
+		return err
+	}
+	return s.deleteTask(id)
+}
+
+func (s *FileStorage) GetTrash(ctx context.Context) (*Trash, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return s.loadTrash()
+}
+
+// RestoreNote takes a note out of the trash and back into the note list.
+func (s *FileStorage) RestoreNote(ctx context.Context, id models.NoteID) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	trash, err := s.loadTrash()
+	if err != nil {
+		return err
+	}
+	for i, trashed := range trash.Notes {
+		if trashed.Note.ID == id {
+			if err := s.upsertNote(trashed.Note); err != nil {
+				return err
+			}
+			trash.Notes = append(trash.Notes[:i], trash.Notes[i+1:]...)
+			return s.saveTrash(trash)
+		}
+	}
+	return fmt.Errorf("trashed note with ID %s %w", id, ErrNotFound)
+}
+
+// RestoreTask takes a task out of the trash and back into the task list.
+func (s *FileStorage) RestoreTask(ctx context.Context, id models.TaskID) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	trash, err := s.loadTrash()
+	if err != nil {
+		return err
+	}
+	for i, trashed := range trash.Tasks {
+		if trashed.Task.ID == id {
+			if err := s.upsertTask(trashed.Task); err != nil {
+				return err
+			}
+			trash.Tasks = append(trash.Tasks[:i], trash.Tasks[i+1:]...)
+			return s.saveTrash(trash)
+		}
+	}
+	return fmt.Errorf("trashed task with ID %s %w", id, ErrNotFound)
+}
+
+func (s *FileStorage) SaveTrash(ctx context.Context, trash *Trash) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return s.saveTrash(trash)
+}
+
+// EmptyTrash deletes everything in the trash for good.
+func (s *FileStorage) EmptyTrash(ctx context.Context) error {
+	if err := s.lock(); err != nil {
+		return err
+	}
+	defer s.unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := os.Remove(s.trashFilePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to empty trash: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStorage) loadTrash() (*Trash, error) {
+	trash := &Trash{
+		Notes: []*TrashedNote{},
+		Tasks: []*TrashedTask{},
+	}
+
+	data, err := os.ReadFile(s.trashFilePath)
+	if os.IsNotExist(err) {
+		return trash, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trash: %w", err)
+	}
+
+	if err := json.Unmarshal(data, trash); err != nil {
+		return nil, fmt.Errorf("failed to parse trash: %w", err)
+	}
+	return trash, nil
+}
+
+func (s *FileStorage) saveTrash(trash *Trash) error {
+	data, err := json.MarshalIndent(trash, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal trash: %w", err)
+	}
+
+	if err := WriteFileAtomic(s.trashFilePath, data); err != nil {
+		return fmt.Errorf("failed to write trash: %w", err)
+	}
+	return nil
+}
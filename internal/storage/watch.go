@@ -0,0 +1,105 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces the burst of events editors and sync clients
+// produce for a single save.
+const watchDebounce = 200 * time.Millisecond
+
+// Watch reloads the data files when something other than this process
+// changes them and publishes NotesReloaded/TasksReloaded events. It returns
+// once the watcher is running; watching stops when ctx is cancelled.
+func (s *FileStorage) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start file watcher: %w", err)
+	}
+	// Watch the directory rather than the files: editors often save by
+	// writing a temp file and renaming it over the original.
+	if err := watcher.Add(filepath.Dir(s.notesFilePath)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch data directory: %w", err)
+	}
+
+	s.mutex.Lock()
+	s.notesHash = hashFile(s.notesFilePath)
+	s.tasksHash = hashFile(s.tasksFilePath)
+	s.mutex.Unlock()
+
+	go s.watchLoop(ctx, watcher)
+	return nil
+}
+
+func (s *FileStorage) watchLoop(ctx context.Context, watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	var notesDirty, tasksDirty bool
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			switch filepath.Clean(event.Name) {
+			case s.notesFilePath:
+				notesDirty = true
+			case s.tasksFilePath:
+				tasksDirty = true
+			default:
+				continue
+			}
+			timer.Reset(watchDebounce)
+		case <-watcher.Errors:
+			// Errors here are transient (e.g. event queue overflow); the
+			// next event will trigger a fresh comparison.
+		case <-timer.C:
+			if notesDirty && s.externallyChanged(s.notesFilePath, &s.notesHash) {
+				s.events.publish(Event{Kind: NotesReloaded})
+			}
+			if tasksDirty && s.externallyChanged(s.tasksFilePath, &s.tasksHash) {
+				s.events.publish(Event{Kind: TasksReloaded})
+			}
+			notesDirty, tasksDirty = false, false
+		}
+	}
+}
+
+// externallyChanged reports whether the file differs from what this
+// process last wrote, and if so drops cached state derived from it.
+func (s *FileStorage) externallyChanged(path string, last *[sha256.Size]byte) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current := hashFile(path)
+	if current == *last {
+		return false
+	}
+	*last = current
+	s.tags = tagIndex{}
+	return true
+}
+
+func hashFile(path string) [sha256.Size]byte {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}
+	}
+	return sha256.Sum256(data)
+}
@@ -0,0 +1,142 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// TaskTemplate creates a recurring kind of task in one step. Due and
+// Remind are offsets: Due from when the task is created, Remind before
+// the due date.
+type TaskTemplate struct {
+	Name        string   `yaml:"-"`
+	Title       string   `yaml:"title"`
+	Description string   `yaml:"description,omitempty"`
+	Due         string   `yaml:"due,omitempty"`
+	Remind      string   `yaml:"remind,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	Priority    string   `yaml:"priority,omitempty"`
+}
+
+// TasksDir is where task templates are kept, one <name>.yaml file each.
+func TasksDir(dataDir string) string {
+	return filepath.Join(dataDir, "templates", "tasks")
+}
+
+var defaultTaskTemplates = map[string]string{
+	"invoice": `title: Prepare invoice for {{month}}
+description: Hours, expenses, send by email
+due: 3d
+remind: 1d
+tags: [billing]
+priority: high
+`,
+	"weekly-review": `title: Weekly review, week {{week}}
+due: 2h
+remind: 30m
+tags: [review]
+`,
+}
+
+// LoadTasks reads the task templates in dir, sorted by name. A missing
+// directory is created with the default templates.
+func LoadTasks(dir string) ([]TaskTemplate, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if err := seedTasks(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates: %w", err)
+	}
+	var templates []TaskTemplate
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".yaml" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		var t TaskTemplate
+		if err := yaml.Unmarshal(data, &t); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", entry.Name(), err)
+		}
+		t.Name = strings.TrimSuffix(entry.Name(), ".yaml")
+		if _, err := t.NewTask(time.Now()); err != nil {
+			return nil, fmt.Errorf("template %s: %w", t.Name, err)
+		}
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+func seedTasks(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create templates directory: %w", err)
+	}
+	for name, text := range defaultTaskTemplates {
+		if err := os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(text), 0644); err != nil {
+			return fmt.Errorf("failed to write template: %w", err)
+		}
+	}
+	return nil
+}
+
+// NewTask creates a task from the template as of now. Without a due
+// offset the task is due in a day; without a reminder offset it reminds
+// an hour before.
+func (t TaskTemplate) NewTask(now time.Time) (*models.Task, error) {
+	if strings.TrimSpace(t.Title) == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	due, err := parseOffset(t.Due, 24*time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("invalid due: %w", err)
+	}
+	remind, err := parseOffset(t.Remind, time.Hour)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remind: %w", err)
+	}
+	priority := models.MediumPriority
+	if t.Priority != "" {
+		if priority, err = models.ParsePriority(t.Priority); err != nil {
+			return nil, err
+		}
+	}
+
+	task := models.NewTask(Expand(t.Title, now), Expand(t.Description, now), now.Add(due))
+	task.SetReminderPeriod(remind)
+	task.SetPriority(priority)
+	for _, tag := range t.Tags {
+		task.AddTag(tag)
+	}
+	return task, nil
+}
+
+// parseOffset accepts a day count (3d) or a Go duration (4h); blank
+// means fallback.
+func parseOffset(value string, fallback time.Duration) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fallback, nil
+	}
+	d, err := models.ParseQuickDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%q is not an offset like 3d or 4h", value)
+	}
+	return d, nil
+}
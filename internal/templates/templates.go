@@ -0,0 +1,99 @@
+// Package templates loads note and task templates from the data
+// directory and fills in their placeholders.
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Template pre-fills a new note. In the file the first line is the title
+// and everything after it is the body.
+type Template struct {
+	Name  string
+	Title string
+	Body  string
+}
+
+// NotesDir is where note templates are kept, one <name>.md file each.
+func NotesDir(dataDir string) string {
+	return filepath.Join(dataDir, "templates", "notes")
+}
+
+// defaultNoteTemplates are written the first time the directory is read,
+// as examples to edit.
+var defaultNoteTemplates = map[string]string{
+	"meeting":       "Meeting {{date}}\n\nAttendees:\nAgenda:\nNotes:\nAction items:\n",
+	"weekly-review": "Weekly review, week {{week}} {{year}}\n\nWins:\nWhat slipped:\nNext week's focus:\n",
+	"bug-report":    "Bug: \n\nReported {{date}} {{time}}\nSteps to reproduce:\nExpected:\nActual:\n",
+}
+
+// Load reads the templates in dir, sorted by name. A missing directory is
+// created with the default templates.
+func Load(dir string) ([]Template, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if err := seed(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates: %w", err)
+	}
+	var templates []Template
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		title, body, _ := strings.Cut(string(data), "\n")
+		templates = append(templates, Template{
+			Name:  strings.TrimSuffix(entry.Name(), ".md"),
+			Title: strings.TrimSpace(title),
+			Body:  strings.TrimLeft(body, "\n"),
+		})
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+func seed(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create templates directory: %w", err)
+	}
+	for name, text := range defaultNoteTemplates {
+		if err := os.WriteFile(filepath.Join(dir, name+".md"), []byte(text), 0644); err != nil {
+			return fmt.Errorf("failed to write template: %w", err)
+		}
+	}
+	return nil
+}
+
+// Expand fills in {{date}}, {{time}}, {{weekday}}, {{week}}, {{month}}
+// and {{year}}. Other placeholders are left for the user to replace.
+func Expand(s string, now time.Time) string {
+	_, week := now.ISOWeek()
+	return strings.NewReplacer(
+		"{{date}}", now.Format("2006-01-02"),
+		"{{time}}", now.Format("15:04"),
+		"{{weekday}}", now.Format("Monday"),
+		"{{week}}", fmt.Sprint(week),
+		"{{month}}", now.Format("January"),
+		"{{year}}", now.Format("2006"),
+	).Replace(s)
+}
+
+// Apply returns the template's title and body as of now.
+func (t Template) Apply(now time.Time) (title, body string) {
+	return Expand(t.Title, now), Expand(t.Body, now)
+}
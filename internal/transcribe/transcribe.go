@@ -0,0 +1,56 @@
+package transcribe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Transcriber runs an external speech-to-text command on an audio file.
+type Transcriber struct {
+	Command string
+}
+
+func New(command string) *Transcriber {
+	return &Transcriber{Command: command}
+}
+
+func (t *Transcriber) Enabled() bool {
+	return strings.TrimSpace(t.Command) != ""
+}
+
+// Transcribe runs the configured command with "{file}" replaced by path and
+// returns its trimmed stdout.
+func (t *Transcriber) Transcribe(ctx context.Context, path string) (string, error) {
+	fields := strings.Fields(t.Command)
+	if len(fields) == 0 {
+		return "", errors.New("no transcription command configured")
+	}
+
+	replaced := false
+	for i, f := range fields {
+		if strings.Contains(f, "{file}") {
+			fields[i] = strings.ReplaceAll(f, "{file}", path)
+			replaced = true
+		}
+	}
+	if !replaced {
+		fields = append(fields, path)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg != "" {
+			return "", fmt.Errorf("transcription failed: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("transcription failed: %w", err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
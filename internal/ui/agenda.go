@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/planner"
+)
+
+// agendaDays is how far ahead the agenda looks.
+const agendaDays = 7
+
+// agenda is the state of the weekly agenda panel.
+type agenda struct {
+	days []planner.AgendaDay
+	// tasks are the agenda's tasks in display order; cursor indexes it
+	tasks  []*models.Task
+	cursor int
+}
+
+type agendaReadyMsg struct {
+	days []planner.AgendaDay
+	// keep is the task to leave the cursor on, if still listed
+	keep models.TaskID
+	err  error
+}
+
+// openAgenda groups the open tasks by the day they are due this week
+func (m *NotesApp) openAgenda(keep models.TaskID) tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := m.storage.GetAllTasks(m.ctx)
+		if err != nil {
+			return agendaReadyMsg{err: err}
+		}
+		return agendaReadyMsg{days: planner.Agenda(tasks, time.Now(), agendaDays), keep: keep}
+	}
+}
+
+func (m *NotesApp) handleAgendaReady(msg agendaReadyMsg) {
+	if msg.err != nil {
+		m.fail("Couldn't load tasks: %v", msg.err)
+		return
+	}
+	a := &agenda{days: msg.days}
+	for _, day := range msg.days {
+		for _, task := range day.Tasks {
+			if task.ID == msg.keep {
+				a.cursor = len(a.tasks)
+			}
+			a.tasks = append(a.tasks, task)
+		}
+	}
+	m.agenda = a
+	m.activeView = "agenda"
+}
+
+// updateAgenda handles keys while the agenda is open
+func (m *NotesApp) updateAgenda(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	a := m.agenda
+	var task *models.Task
+	if len(a.tasks) > 0 {
+		task = a.tasks[a.cursor]
+	}
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+	case "esc", "A":
+		m.agenda = nil
+		m.activeView = "tasks"
+	case "up", "k":
+		if a.cursor > 0 {
+			a.cursor--
+		}
+	case "down", "j":
+		if a.cursor < len(a.tasks)-1 {
+			a.cursor++
+		}
+	case "left", "h":
+		a.cursor = a.dayStart(a.cursor - 1)
+	case "right", "l":
+		if next := a.dayEnd(a.cursor); next < len(a.tasks) {
+			a.cursor = next
+		}
+	case "+", "-":
+		if task == nil {
+			break
+		}
+		// Move the task a day later or earlier to even out the week
+		days := 1
+		if msg.String() == "-" {
+			days = -1
+		}
+		edited := task.Clone()
+		edited.Reschedule(task.DueDate.AddDate(0, 0, days))
+		return m, tea.Sequence(m.saveTask(edited), m.openAgenda(task.ID))
+	case "enter":
+		if task == nil {
+			break
+		}
+		// Open the task in the task list
+		m.agenda = nil
+		m.activeView = "tasks"
+		m.focusTask = task.ID
+		return m, m.loadTasks()
+	}
+	return m, nil
+}
+
+// dayStart is the index of the first task of the day holding index i
+func (a *agenda) dayStart(i int) int {
+	if i < 0 {
+		return 0
+	}
+	start := 0
+	for _, day := range a.days {
+		if i < start+len(day.Tasks) {
+			return start
+		}
+		start += len(day.Tasks)
+	}
+	return start
+}
+
+// dayEnd is the index just past the last task of the day holding index i
+func (a *agenda) dayEnd(i int) int {
+	end := 0
+	for _, day := range a.days {
+		end += len(day.Tasks)
+		if i < end {
+			return end
+		}
+	}
+	return end
+}
+
+// agendaView lists the week day by day, overdue tasks first
+func (m *NotesApp) agendaView() string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Agenda") + "\n")
+
+	now := time.Now()
+	i := 0
+	for _, day := range m.agenda.days {
+		header := agendaDayLabel(day, now)
+		if day.Overdue() {
+			b.WriteString("\n" + overBarStyle(header) + "\n")
+		} else {
+			b.WriteString("\n" + groupHeaderStyle(header) + "\n")
+		}
+		if len(day.Tasks) == 0 {
+			b.WriteString(helpStyle("  nothing due") + "\n")
+		}
+		for _, task := range day.Tasks {
+			line := fmt.Sprintf("%-40s %s", truncate(task.Title, 40), task.Priority)
+			if day.Overdue() {
+				line = fmt.Sprintf("%-40s %s, was due %s", truncate(task.Title, 40), task.Priority, task.DueDate.Format("Mon Jan 2"))
+			}
+			if i == m.agenda.cursor {
+				b.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+			} else {
+				b.WriteString(itemStyle.Render(line) + "\n")
+			}
+			i++
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(b.String())
+}
+
+func agendaDayLabel(day planner.AgendaDay, now time.Time) string {
+	switch {
+	case day.Overdue():
+		return fmt.Sprintf("Overdue (%d)", len(day.Tasks))
+	case day.Day.Format("2006-01-02") == now.Format("2006-01-02"):
+		return "Today, " + day.Day.Format("Mon Jan 2")
+	case day.Day.Format("2006-01-02") == now.AddDate(0, 0, 1).Format("2006-01-02"):
+		return "Tomorrow, " + day.Day.Format("Mon Jan 2")
+	}
+	return day.Day.Format("Monday Jan 2")
+}
@@ -0,0 +1,295 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// archiveEntry is a task or note shown in the archive panel.
+type archiveEntry struct {
+	task *models.Task
+	note *models.Note
+}
+
+func (e archiveEntry) id() string {
+	if e.task != nil {
+		return string(e.task.ID)
+	}
+	return string(e.note.ID)
+}
+
+func (e archiveEntry) title() string {
+	if e.task != nil {
+		return e.task.Title
+	}
+	return e.note.Title
+}
+
+func (e archiveEntry) archived() bool {
+	if e.task != nil {
+		return e.task.IsArchived()
+	}
+	return e.note.IsArchived()
+}
+
+// finished is when the entry was archived, or else completed
+func (e archiveEntry) finished() time.Time {
+	switch {
+	case e.task != nil && e.task.IsArchived():
+		return e.task.ArchivedAt
+	case e.task != nil && !e.task.CompletedAt.IsZero():
+		return e.task.CompletedAt
+	case e.task != nil:
+		return e.task.UpdatedAt
+	case e.note.IsArchived():
+		return e.note.ArchivedAt
+	}
+	return e.note.UpdatedAt
+}
+
+// archive is the state of the archive panel: archived items first, then
+// finished ones still in the main lists, each most recent first.
+type archive struct {
+	entries []archiveEntry
+	cursor  int
+}
+
+type archiveReadyMsg struct {
+	entries []archiveEntry
+	// keep is the entry to leave the cursor on
+	keep string
+	err  error
+}
+
+// openArchive loads the archived items and the finished ones
+func (m *NotesApp) openArchive(keep string) tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := m.storage.GetAllTasks(m.ctx)
+		if err != nil {
+			return archiveReadyMsg{err: err}
+		}
+		notes, err := m.storage.GetAllNotes(m.ctx)
+		if err != nil {
+			return archiveReadyMsg{err: err}
+		}
+
+		var entries []archiveEntry
+		for _, task := range tasks {
+			if task.IsArchived() || !task.IsOpen() {
+				entries = append(entries, archiveEntry{task: task})
+			}
+		}
+		for _, note := range notes {
+			if note.IsArchived() || note.IsCompleted {
+				entries = append(entries, archiveEntry{note: note})
+			}
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			if entries[i].archived() != entries[j].archived() {
+				return entries[i].archived()
+			}
+			return entries[i].finished().After(entries[j].finished())
+		})
+		return archiveReadyMsg{entries: entries, keep: keep}
+	}
+}
+
+func (m *NotesApp) handleArchiveReady(msg archiveReadyMsg) {
+	if msg.err != nil {
+		m.fail("Couldn't load the archive: %v", msg.err)
+		return
+	}
+	a := &archive{entries: msg.entries}
+	if m.archive != nil {
+		a.cursor = m.archive.cursor
+	}
+	for i, entry := range msg.entries {
+		if entry.id() == msg.keep {
+			a.cursor = i
+		}
+	}
+	a.cursor = min(a.cursor, max(len(a.entries)-1, 0))
+	m.archive = a
+	m.activeView = "archive"
+}
+
+// updateArchive handles keys while the archive is open
+func (m *NotesApp) updateArchive(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	a := m.archive
+	var entry *archiveEntry
+	if len(a.entries) > 0 {
+		entry = &a.entries[a.cursor]
+	}
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+	case "esc", "Y":
+		m.archive = nil
+		m.activeView = "tasks"
+		return m, tea.Batch(m.loadNotes(), m.loadTasks())
+	case "up", "k":
+		if a.cursor > 0 {
+			a.cursor--
+		}
+	case "down", "j":
+		if a.cursor < len(a.entries)-1 {
+			a.cursor++
+		}
+	case "r":
+		if entry == nil {
+			break
+		}
+		// Take the entry out of the archive, or reopen a finished one
+		return m, tea.Sequence(m.restoreEntry(*entry), m.openArchive(entry.id()))
+	case "a":
+		if entry == nil || entry.archived() {
+			break
+		}
+		m.status = fmt.Sprintf("Archived %q", entry.title())
+		return m, tea.Sequence(m.saveArchived([]archiveEntry{*entry}), m.openArchive(entry.id()))
+	case "A":
+		// Archive everything finished in one go
+		var finished []archiveEntry
+		for _, e := range a.entries {
+			if !e.archived() {
+				finished = append(finished, e)
+			}
+		}
+		if len(finished) == 0 {
+			m.status = "Nothing finished to archive"
+			break
+		}
+		m.status = fmt.Sprintf("Archived %d item(s)", len(finished))
+		return m, tea.Sequence(m.saveArchived(finished), m.openArchive(""))
+	case "D":
+		if entry == nil {
+			break
+		}
+		// Deleted items go to the trash, where they can still be
+		// restored
+		m.status = fmt.Sprintf("Moved %q to the trash (W)", entry.title())
+		if entry.task != nil {
+			return m, tea.Sequence(m.deleteTask(entry.task.ID), m.openArchive(""))
+		}
+		return m, tea.Sequence(m.deleteNote(entry.note.ID), m.openArchive(""))
+	}
+	return m, nil
+}
+
+// restoreEntry returns an archived entry to its list, or reopens a
+// finished one
+func (m *NotesApp) restoreEntry(entry archiveEntry) tea.Cmd {
+	if entry.task != nil {
+		task := entry.task.Clone()
+		if task.IsArchived() {
+			task.Unarchive()
+			m.status = fmt.Sprintf("Restored %q to the task list", task.Title)
+		} else if err := task.Reopen(); err != nil {
+			m.fail("%v", err)
+			return nil
+		} else {
+			m.status = fmt.Sprintf("Reopened %q", task.Title)
+		}
+		return m.saveTask(task)
+	}
+
+	note := entry.note.Clone()
+	if note.IsArchived() {
+		note.Unarchive()
+		m.status = fmt.Sprintf("Restored %q to the note list", note.Title)
+	} else {
+		note.IsCompleted = false
+		note.UpdatedAt = time.Now()
+		m.status = fmt.Sprintf("Reopened %q", note.Title)
+	}
+	return m.saveNote(note)
+}
+
+// saveArchived archives the finished entries
+func (m *NotesApp) saveArchived(entries []archiveEntry) tea.Cmd {
+	var tasks []*models.Task
+	var cmds []tea.Cmd
+	for _, entry := range entries {
+		if entry.task != nil {
+			task := entry.task.Clone()
+			if task.Archive() == nil {
+				tasks = append(tasks, task)
+			}
+			continue
+		}
+		note := entry.note.Clone()
+		note.Archive()
+		cmds = append(cmds, m.saveNote(note))
+	}
+	return tea.Sequence(append(cmds, m.saveTasksBatch(tasks))...)
+}
+
+// archiveSelected archives the selected note or finished task, taking it
+// out of the list
+func (m *NotesApp) archiveSelected() tea.Cmd {
+	if m.activeView == "notes" && m.selectedNote != nil {
+		selected := m.selectedNote
+		return m.withContent(func() tea.Cmd {
+			note := selected.Clone()
+			note.Archive()
+			m.status = fmt.Sprintf("Archived %q; Y shows the archive", note.Title)
+			return tea.Sequence(m.saveNote(note), m.loadNotes())
+		}, selected)
+	}
+	if m.activeView == "tasks" && m.selectedTask != nil {
+		task := m.selectedTask.Clone()
+		if err := task.Archive(); err != nil {
+			m.fail("%v", err)
+			return nil
+		}
+		m.status = fmt.Sprintf("Archived %q; Y shows the archive", task.Title)
+		return tea.Sequence(m.saveTask(task), m.loadTasks())
+	}
+	return nil
+}
+
+// archiveView lists the archived and finished items
+func (m *NotesApp) archiveView() string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Archive") + "\n")
+	if len(m.archive.entries) == 0 {
+		b.WriteString("\nNothing archived or finished yet.\n")
+	}
+
+	section := ""
+	for i, entry := range m.archive.entries {
+		header := "Finished, still listed"
+		if entry.archived() {
+			header = "Archived"
+		}
+		if header != section {
+			section = header
+			b.WriteString("\n" + groupHeaderStyle(header) + "\n")
+		}
+
+		kind := "note"
+		if entry.task != nil {
+			kind = "task"
+		}
+		line := fmt.Sprintf("%-40s %-4s  %s", truncate(entry.title(), 40), kind, entry.finished().Format("Jan 2 2006"))
+		if i == m.archive.cursor {
+			b.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+		} else {
+			b.WriteString(itemStyle.Render(line) + "\n")
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(b.String())
+}
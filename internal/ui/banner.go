@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+)
+
+var bannerStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("0")).
+	Background(lipgloss.Color("214")).
+	Padding(0, 1).
+	Render
+
+// bannerMsg is a reminder sent into the program by BannerNotifier
+type bannerMsg reminder.Event
+
+// BannerNotifier delivers reminders into the running TUI, which shows
+// them as a banner until dismissed. Printing them to the terminal, as the
+// console notifier does, would garble the full-screen UI.
+type BannerNotifier struct {
+	mu      sync.Mutex
+	program *tea.Program
+}
+
+// Attach routes reminders to the program; until then they fail.
+func (n *BannerNotifier) Attach(p *tea.Program) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.program = p
+}
+
+func (n *BannerNotifier) Notify(task *models.Task) error {
+	return n.send(bannerMsg{TaskID: task.ID, Title: task.Title, Due: task.DueDate})
+}
+
+func (n *BannerNotifier) NotifyDigest(d reminder.Digest) error {
+	return n.send(bannerMsg{Title: d.Title, Count: len(d.Tasks)})
+}
+
+func (n *BannerNotifier) send(msg bannerMsg) error {
+	n.mu.Lock()
+	p := n.program
+	n.mu.Unlock()
+	if p == nil {
+		return errors.New("the TUI isn't running")
+	}
+	p.Send(msg)
+	return nil
+}
+
+// showReminder adds a reminder to the banner. The session also echoes
+// reminders this process delivered, so a repeat of the latest is dropped.
+func (m *NotesApp) showReminder(e reminder.Event) {
+	if n := len(m.banner); n > 0 {
+		last := m.banner[n-1]
+		if last.TaskID == e.TaskID && last.Title == e.Title && last.Count == e.Count && last.Due.Equal(e.Due) {
+			return
+		}
+	}
+	m.banner = append(m.banner, e)
+}
+
+// bannerView shows the latest reminder and how many more are waiting, or
+// returns "" when there are none
+func (m *NotesApp) bannerView() string {
+	if len(m.banner) == 0 {
+		return ""
+	}
+	e := m.banner[len(m.banner)-1]
+	text := fmt.Sprintf("⏰ %s is due %s", e.Title, e.Due.Format(dueLayout))
+	if e.Count > 0 {
+		text = fmt.Sprintf("⏰ %s (%d tasks)", e.Title, e.Count)
+	} else if e.Due.IsZero() {
+		text = "⏰ " + e.Title
+	}
+	if more := len(m.banner) - 1; more > 0 {
+		text += fmt.Sprintf(" (+%d more)", more)
+	}
+	keys := " · x: dismiss"
+	if m.bannerTask() != nil {
+		keys = " · z: snooze" + keys
+	}
+	return bannerStyle(text+keys) + "\n\n"
+}
+
+// bannerTask is the task the latest reminder on the banner is for, or nil
+// for a digest or a task that has since been deleted
+func (m *NotesApp) bannerTask() *models.Task {
+	if len(m.banner) == 0 {
+		return nil
+	}
+	id := m.banner[len(m.banner)-1].TaskID
+	if id == "" {
+		return nil
+	}
+	return m.taskIndex[id]
+}
+
+// snoozeBanner asks how long to postpone the latest reminder on the
+// banner, and takes it down once snoozed. Saving the snooze re-arms the
+// reminder, which comes back when it is over.
+func (m *NotesApp) snoozeBanner() {
+	task := m.bannerTask()
+	m.openSnooze(task, func() {
+		for i := len(m.banner) - 1; i >= 0; i-- {
+			if m.banner[i].TaskID == task.ID {
+				m.banner = append(m.banner[:i], m.banner[i+1:]...)
+			}
+		}
+	})
+}
@@ -0,0 +1,241 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// boardColumn is a column of the board: the tasks in one workflow status,
+// or the open tasks past due.
+type boardColumn struct {
+	name    string
+	overdue bool
+	tasks   []*models.Task
+}
+
+// board is the state of the kanban board panel.
+type board struct {
+	columns []boardColumn
+	// col and row are the cursor; row is kept per column so moving across
+	// and back returns to the same card
+	col  int
+	rows []int
+}
+
+type boardReadyMsg struct {
+	tasks []*models.Task
+	// keep is the task to leave the cursor on, if still on the board
+	keep models.TaskID
+	err  error
+}
+
+// openBoard loads the tasks to lay out on the board
+func (m *NotesApp) openBoard(keep models.TaskID) tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := m.storage.GetAllTasks(m.ctx)
+		return boardReadyMsg{tasks: tasks, keep: keep, err: err}
+	}
+}
+
+func (m *NotesApp) handleBoardReady(msg boardReadyMsg) {
+	if msg.err != nil {
+		m.fail("Couldn't load tasks: %v", msg.err)
+		return
+	}
+	b := newBoard(msg.tasks, time.Now())
+	if m.board != nil && len(m.board.columns) == len(b.columns) {
+		b.col = m.board.col
+		copy(b.rows, m.board.rows)
+	}
+	for col, column := range b.columns {
+		for row, task := range column.tasks {
+			if task.ID == msg.keep {
+				b.col, b.rows[col] = col, row
+			}
+		}
+	}
+	for col, column := range b.columns {
+		b.rows[col] = min(b.rows[col], max(len(column.tasks)-1, 0))
+	}
+	m.board = b
+	m.activeView = "board"
+}
+
+// newBoard lays the tasks out in an Overdue column followed by a column
+// per workflow status. Archived tasks and those in a status the workflow
+// doesn't list, such as Cancelled by default, are left off.
+func newBoard(tasks []*models.Task, now time.Time) *board {
+	columns := []boardColumn{{name: models.TaskStatusOverdue.String(), overdue: true}}
+	for _, def := range models.DefaultWorkflow {
+		columns = append(columns, boardColumn{name: def.Name})
+	}
+
+	for _, task := range tasks {
+		if task.IsArchived() {
+			continue
+		}
+		name := task.StatusName(now)
+		for i := range columns {
+			if strings.EqualFold(columns[i].name, name) {
+				columns[i].tasks = append(columns[i].tasks, task)
+				break
+			}
+		}
+	}
+	for _, column := range columns {
+		sort.SliceStable(column.tasks, func(i, j int) bool {
+			a, b := column.tasks[i], column.tasks[j]
+			if a.DueDate.IsZero() != b.DueDate.IsZero() {
+				return b.DueDate.IsZero()
+			}
+			return a.DueDate.Before(b.DueDate)
+		})
+	}
+	return &board{columns: columns, rows: make([]int, len(columns))}
+}
+
+// selected is the card under the cursor, nil in an empty column
+func (b *board) selected() *models.Task {
+	column := b.columns[b.col]
+	if len(column.tasks) == 0 {
+		return nil
+	}
+	return column.tasks[b.rows[b.col]]
+}
+
+// updateBoard handles keys while the board is open
+func (m *NotesApp) updateBoard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	b := m.board
+	task := b.selected()
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+	case "esc", "K":
+		m.board = nil
+		m.activeView = "tasks"
+	case "left", "h":
+		if b.col > 0 {
+			b.col--
+		}
+	case "right", "l":
+		if b.col < len(b.columns)-1 {
+			b.col++
+		}
+	case "up", "k":
+		if b.rows[b.col] > 0 {
+			b.rows[b.col]--
+		}
+	case "down", "j":
+		if b.rows[b.col] < len(b.columns[b.col].tasks)-1 {
+			b.rows[b.col]++
+		}
+	case "<", ">", "shift+left", "shift+right":
+		if task == nil {
+			break
+		}
+		// Move the card to the neighbouring workflow status. Overdue
+		// cards move on from the status they are in.
+		step := 1
+		if msg.String() == "<" || msg.String() == "shift+left" {
+			step = -1
+		}
+		to, ok := neighbourStatus(task, step)
+		if !ok {
+			break
+		}
+		m.selectedTask = task
+		return m, tea.Sequence(m.setWorkflowStatus(to), m.openBoard(task.ID))
+	case "enter":
+		if task == nil {
+			break
+		}
+		// Open the task in the task list
+		m.board = nil
+		m.activeView = "tasks"
+		m.focusTask = task.ID
+		return m, m.loadTasks()
+	}
+	return m, nil
+}
+
+// neighbourStatus is the workflow status step places from the task's own,
+// if there is one
+func neighbourStatus(task *models.Task, step int) (models.StatusDef, bool) {
+	current := models.DefaultWorkflow.Def(task)
+	for i, def := range models.DefaultWorkflow {
+		if strings.EqualFold(def.Name, current.Name) {
+			next := i + step
+			if next < 0 || next >= len(models.DefaultWorkflow) {
+				return models.StatusDef{}, false
+			}
+			return models.DefaultWorkflow[next], true
+		}
+	}
+	return models.StatusDef{}, false
+}
+
+// boardView draws the columns side by side
+func (m *NotesApp) boardView() string {
+	b := m.board
+	// The panel's border and padding take six columns
+	width := max((m.width-6)/len(b.columns), 12)
+	// Cards take two lines; leave room for the title, column headers,
+	// help and borders
+	visible := max((m.height-12)/2, 3)
+
+	columns := make([]string, len(b.columns))
+	for col, column := range b.columns {
+		var s strings.Builder
+		header := fmt.Sprintf("%s (%d)", column.name, len(column.tasks))
+		if column.overdue {
+			s.WriteString(overBarStyle(header) + "\n")
+		} else {
+			s.WriteString(groupHeaderStyle(header) + "\n")
+		}
+
+		// Scroll the column so its cursor stays in view
+		start := max(b.rows[col]-visible+1, 0)
+		end := min(start+visible, len(column.tasks))
+		if start > 0 {
+			s.WriteString(helpStyle(fmt.Sprintf("  ↑ %d more", start)) + "\n")
+		}
+		for row := start; row < end; row++ {
+			task := column.tasks[row]
+			// Cards are indented two and marked with "> " when selected
+			line := truncate(task.Title, width-5)
+			if !task.DueDate.IsZero() {
+				line += "\n  " + helpStyle(task.DueDate.Format("Jan 2"))
+			}
+			if col == b.col && row == b.rows[col] {
+				s.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+			} else {
+				s.WriteString(itemStyle.Render(line) + "\n")
+			}
+		}
+		if end < len(column.tasks) {
+			s.WriteString(helpStyle(fmt.Sprintf("  ↓ %d more", len(column.tasks)-end)) + "\n")
+		}
+		if len(column.tasks) == 0 {
+			s.WriteString(helpStyle("  empty") + "\n")
+		}
+
+		style := lipgloss.NewStyle().Width(width).PaddingRight(1)
+		columns[col] = style.Render(s.String())
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(lipgloss.NewStyle().Bold(true).Render("Board") + "\n\n" +
+			lipgloss.JoinHorizontal(lipgloss.Top, columns...))
+}
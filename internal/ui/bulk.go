@@ -0,0 +1,320 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+const (
+	bulkDueDate = iota
+	bulkPriority
+	bulkTags
+)
+
+// bulkEdit is the open bulk-edit dialog. Blank fields leave the items
+// untouched.
+type bulkEdit struct {
+	inputs      []textinput.Model
+	activeInput int
+	count       int
+}
+
+// bulkChanges is the parsed form of a bulk edit.
+type bulkChanges struct {
+	setDue     bool
+	due        time.Time
+	priority   models.Priority
+	addTags    []string
+	removeTags []string
+}
+
+func newBulkEdit(count int) *bulkEdit {
+	inputs := make([]textinput.Model, 3)
+	for i := range inputs {
+		t := textinput.New()
+		t.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("170"))
+		t.CharLimit = 100
+
+		switch i {
+		case bulkDueDate:
+			t.Placeholder = "Due Date (YYYY-MM-DD, - to clear, blank to keep)"
+			t.Focus()
+		case bulkPriority:
+			t.Placeholder = "Priority (low, medium, high; blank to keep)"
+		case bulkTags:
+			t.Placeholder = "Tags (+add -remove)"
+		}
+
+		inputs[i] = t
+	}
+	return &bulkEdit{inputs: inputs, count: count}
+}
+
+func (b *bulkEdit) parse() (bulkChanges, error) {
+	var changes bulkChanges
+
+	switch due := strings.TrimSpace(b.inputs[bulkDueDate].Value()); due {
+	case "":
+	case "-":
+		changes.setDue = true
+	default:
+		dueDate, err := time.Parse("2006-01-02", due)
+		if err != nil {
+			return changes, fmt.Errorf("invalid due date %q (want YYYY-MM-DD or - to clear)", due)
+		}
+		changes.setDue = true
+		changes.due = dueDate
+	}
+
+	if p := strings.TrimSpace(b.inputs[bulkPriority].Value()); p != "" {
+		priority, err := models.ParsePriority(p)
+		if err != nil {
+			return changes, err
+		}
+		changes.priority = priority
+	}
+
+	tags := strings.FieldsFunc(b.inputs[bulkTags].Value(), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	for _, tag := range tags {
+		switch {
+		case strings.HasPrefix(tag, "-") && len(tag) > 1:
+			changes.removeTags = append(changes.removeTags, tag[1:])
+		case strings.HasPrefix(tag, "+") && len(tag) > 1:
+			changes.addTags = append(changes.addTags, tag[1:])
+		case tag != "+" && tag != "-":
+			changes.addTags = append(changes.addTags, tag)
+		}
+	}
+
+	return changes, nil
+}
+
+func (c bulkChanges) applyToTask(task *models.Task) {
+	if c.setDue {
+		task.Reschedule(c.due)
+	}
+	if c.priority != 0 {
+		task.SetPriority(c.priority)
+	}
+	for _, tag := range c.addTags {
+		task.AddTag(tag)
+	}
+	for _, tag := range c.removeTags {
+		task.RemoveTag(tag)
+	}
+}
+
+func (c bulkChanges) applyToNote(note *models.Note) {
+	if c.setDue {
+		note.SetDueDate(c.due)
+	}
+	if c.priority != 0 {
+		note.SetPriority(c.priority)
+	}
+	for _, tag := range c.addTags {
+		note.AddTag(tag)
+	}
+	for _, tag := range c.removeTags {
+		note.RemoveTag(tag)
+	}
+}
+
+// toggleMark adds or removes the selected item from the bulk selection
+func (m *NotesApp) toggleMark() {
+	var id string
+	if m.activeView == "notes" && m.selectedNote != nil {
+		id = string(m.selectedNote.ID)
+	} else if m.activeView == "tasks" && m.selectedTask != nil {
+		id = string(m.selectedTask.ID)
+	}
+	if id == "" {
+		return
+	}
+	if m.marked[id] {
+		delete(m.marked, id)
+	} else {
+		m.marked[id] = true
+	}
+}
+
+// markedNotes returns the marked notes, or the selected note if none are marked
+func (m *NotesApp) markedNotes() []*models.Note {
+	var notes []*models.Note
+	for _, item := range m.notesList.Items() {
+		if i, ok := item.(noteItem); ok && m.marked[string(i.note.ID)] {
+			notes = append(notes, i.note)
+		}
+	}
+	if len(notes) == 0 && m.selectedNote != nil {
+		notes = append(notes, m.selectedNote)
+	}
+	return notes
+}
+
+// markedTasks returns the marked tasks, or the selected task if none are marked
+func (m *NotesApp) markedTasks() []*models.Task {
+	var tasks []*models.Task
+	for _, item := range m.tasksList.Items() {
+		if i, ok := item.(taskItem); ok && m.marked[string(i.task.ID)] {
+			tasks = append(tasks, i.task)
+		}
+	}
+	if len(tasks) == 0 && m.selectedTask != nil {
+		tasks = append(tasks, m.selectedTask)
+	}
+	return tasks
+}
+
+// openBulkEdit starts a bulk edit of the marked items in the active view
+func (m *NotesApp) openBulkEdit() {
+	count := len(m.markedTasks())
+	if m.activeView == "notes" {
+		count = len(m.markedNotes())
+	}
+	if count > 0 {
+		m.bulk = newBulkEdit(count)
+		m.status = ""
+	}
+}
+
+// updateBulkEdit handles keys while the bulk-edit dialog is open
+func (m *NotesApp) updateBulkEdit(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	b := m.bulk
+	switch msg.String() {
+	case "esc":
+		m.bulk = nil
+		return m, nil
+
+	case "enter":
+		if b.activeInput < len(b.inputs)-1 {
+			b.focus(b.activeInput + 1)
+			return m, nil
+		}
+		changes, err := b.parse()
+		if err != nil {
+			m.fail("%v", err)
+			return m, nil
+		}
+		m.bulk = nil
+		return m, m.applyBulkEdit(changes)
+
+	case "tab", "down":
+		b.focus((b.activeInput + 1) % len(b.inputs))
+		return m, nil
+
+	case "shift+tab", "up":
+		b.focus((b.activeInput + len(b.inputs) - 1) % len(b.inputs))
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	b.inputs[b.activeInput], cmd = b.inputs[b.activeInput].Update(msg)
+	return m, cmd
+}
+
+func (b *bulkEdit) focus(i int) {
+	b.inputs[b.activeInput].Blur()
+	b.activeInput = i
+	b.inputs[b.activeInput].Focus()
+}
+
+// applyBulkEdit applies the changes to copies of the marked items, saves
+// them in one write and remembers the originals for undo
+func (m *NotesApp) applyBulkEdit(changes bulkChanges) tea.Cmd {
+	if m.activeView == "notes" {
+		originals := m.markedNotes()
+		clear(m.marked)
+		// The notes are saved whole, so their bodies are needed first
+		return m.withContent(func() tea.Cmd {
+			var before, after itemSet
+			edited := make([]*models.Note, len(originals))
+			for i, note := range originals {
+				before.notes = append(before.notes, note.Clone())
+				edited[i] = note.Clone()
+				changes.applyToNote(edited[i])
+			}
+			after.notes = edited
+			m.rememberEdit("the bulk edit", before, after)
+			m.status = fmt.Sprintf("Updated %d note(s) • u: undo", len(edited))
+			return tea.Sequence(m.saveNotesBatch(edited), m.loadNotes())
+		}, originals...)
+	}
+
+	var before, after itemSet
+	originals := m.markedTasks()
+	edited := make([]*models.Task, len(originals))
+	for i, task := range originals {
+		before.tasks = append(before.tasks, task.Clone())
+		edited[i] = task.Clone()
+		changes.applyToTask(edited[i])
+	}
+	after.tasks = edited
+	m.rememberEdit("the bulk edit", before, after)
+	m.status = fmt.Sprintf("Updated %d task(s) • u: undo", len(edited))
+	clear(m.marked)
+	return tea.Sequence(m.saveTasksBatch(edited), m.loadTasks())
+}
+
+// saveNotesBatch saves several notes in one storage write
+func (m *NotesApp) saveNotesBatch(notes []*models.Note) tea.Cmd {
+	return func() tea.Msg {
+		if len(notes) == 0 {
+			return nil
+		}
+		if err := m.storage.SaveNotesBatch(m.ctx, notes); err != nil {
+			return errMsg{action: "save notes", err: err}
+		}
+		return nil
+	}
+}
+
+// saveTasksBatch saves several tasks in one storage write
+func (m *NotesApp) saveTasksBatch(tasks []*models.Task) tea.Cmd {
+	return func() tea.Msg {
+		if len(tasks) == 0 {
+			return nil
+		}
+		if err := m.storage.SaveTasksBatch(m.ctx, tasks); err != nil {
+			return errMsg{action: "save tasks", err: err}
+		}
+		return nil
+	}
+}
+
+// bulkEditView displays the bulk-edit dialog
+func (m *NotesApp) bulkEditView() string {
+	kind := "Tasks"
+	if m.activeView == "notes" {
+		kind = "Notes"
+	}
+
+	form := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("170")).
+		Render(fmt.Sprintf("Bulk Edit %d %s", m.bulk.count, kind)) + "\n\n"
+
+	for i := range m.bulk.inputs {
+		form += m.bulk.inputs[i].View() + "\n"
+	}
+	if m.status != "" {
+		form += "\n" + statusStyle(m.status)
+	}
+
+	form += "\n" + helpStyle("enter: apply • tab: next field • esc: cancel")
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(form)
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// contentInput is the form field holding a note's content or a task's
+// description. It is edited in the content textarea rather than in
+// inputs, so notes can span several lines.
+const contentInput = 1
+
+// contentCharLimit caps the length of a note's content.
+const contentCharLimit = 20000
+
+// contentHeight is how many rows of content the form shows at once.
+const contentHeight = 6
+
+// newContentArea sets up the multi-line content field
+func newContentArea() textarea.Model {
+	t := textarea.New()
+	t.Placeholder = "Content/Description"
+	t.ShowLineNumbers = false
+	t.CharLimit = contentCharLimit
+	// Let notes run to any number of lines; the field scrolls
+	t.MaxHeight = 0
+	t.SetHeight(contentHeight)
+	t.FocusedStyle.CursorLine = lipgloss.NewStyle()
+	t.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("170"))
+	return t
+}
+
+// focusField focuses the form field at index
+func (m *NotesApp) focusField(index int) {
+	switch index {
+	case contentInput:
+		m.content.Focus()
+	case priorityInput:
+	default:
+		m.inputs[index].Focus()
+	}
+}
+
+// blurField takes the focus off the form field at index
+func (m *NotesApp) blurField(index int) {
+	switch index {
+	case contentInput:
+		m.content.Blur()
+	case priorityInput:
+	default:
+		m.inputs[index].Blur()
+	}
+}
+
+// setContent fills the content field, leaving the cursor at the start
+func (m *NotesApp) setContent(s string) {
+	m.content.SetValue(s)
+	for m.content.Line() > 0 {
+		m.content.CursorUp()
+	}
+	m.content.CursorStart()
+}
+
+// sizeContent fits the content field to the form's width
+func (m *NotesApp) sizeContent() {
+	// The form's border and padding take six columns
+	m.content.SetWidth(max(m.width-8, 20))
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// contextInput is the task form field naming where the task can be done.
+const contextInput = 7
+
+// newContextInput sets up the context field to suggest the contexts
+// already in use.
+func newContextInput(t textinput.Model) textinput.Model {
+	t.Placeholder = "Context (e.g., @home, @computer, @errands)"
+	t.ShowSuggestions = true
+	t.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
+	return t
+}
+
+// cycleContext narrows the task list to what can be done in the next
+// context, wrapping back to every task after the last one. Only open,
+// unblocked tasks are shown while a context is active.
+func (m *NotesApp) cycleContext() tea.Cmd {
+	contexts := models.Contexts(m.allTasks())
+	if len(contexts) == 0 {
+		m.status = "No open tasks have a context yet; set one in the task form"
+		m.activeContext = ""
+		return m.loadTasks()
+	}
+
+	next := 0
+	for i, c := range contexts {
+		if models.SameContext(c, m.activeContext) {
+			next = i + 1
+			break
+		}
+	}
+	if next >= len(contexts) {
+		m.activeContext = ""
+	} else {
+		m.activeContext = contexts[next]
+	}
+	return m.loadTasks()
+}
+
+// allTasks lists every loaded task, including ones hidden by filters
+func (m *NotesApp) allTasks() []*models.Task {
+	tasks := make([]*models.Task, 0, len(m.taskIndex))
+	for _, task := range m.taskIndex {
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+// setContextSuggestions offers the contexts in use in the task form
+func (m *NotesApp) setContextSuggestions(tasks []*models.Task) {
+	m.inputs[contextInput].SetSuggestions(models.Contexts(tasks))
+}
+
+// formatContext is the context line of the task detail view
+func formatContext(context string) string {
+	if context == "" {
+		return ""
+	}
+	return "\n\nContext: " + context
+}
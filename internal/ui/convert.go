@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// convertedMsg reports a finished note/task conversion
+type convertedMsg struct {
+	status string
+}
+
+// convertNote asks for a due date and turns the selected note into a task
+func (m *NotesApp) convertNote(note *models.Note) tea.Cmd {
+	m.prompt = newPrompt("Due date for the task (YYYY-MM-DD, blank for none):", time.Now().AddDate(0, 0, 1).Format("2006-01-02"), func(value string) tea.Cmd {
+		var due time.Time
+		if value = strings.TrimSpace(value); value != "" {
+			parsed, err := time.ParseInLocation("2006-01-02", value, time.Local)
+			if err != nil {
+				m.status = fmt.Sprintf("Invalid date %q", value)
+				return nil
+			}
+			due = parsed
+		}
+		return func() tea.Msg {
+			task, err := m.storage.ConvertNoteToTask(m.ctx, note.ID, due)
+			if err != nil {
+				return errMsg{action: "convert the note", err: err}
+			}
+			return convertedMsg{status: fmt.Sprintf("Created task %q from the note", task.Title)}
+		}
+	})
+	return nil
+}
+
+// convertTask turns the selected task into a note
+func (m *NotesApp) convertTask(task *models.Task) tea.Cmd {
+	return func() tea.Msg {
+		note, err := m.storage.ConvertTaskToNote(m.ctx, task.ID)
+		if err != nil {
+			return errMsg{action: "convert the task", err: err}
+		}
+		return convertedMsg{status: fmt.Sprintf("Created note %q from the task", note.Title)}
+	}
+}
+
+// formatLinkedNote names the note a task is linked to, or returns ""
+func (m *NotesApp) formatLinkedNote(task *models.Task) string {
+	note, ok := m.noteIndex[task.NoteID]
+	if task.NoteID == "" || !ok {
+		return ""
+	}
+	return "\n\nNote: " + note.Title
+}
+
+// formatLinkedTasks lists the tasks linked to a note, or returns ""
+func (m *NotesApp) formatLinkedTasks(note *models.Note) string {
+	var titles []string
+	for _, task := range m.taskIndex {
+		if task.NoteID == note.ID {
+			titles = append(titles, task.Title)
+		}
+	}
+	if len(titles) == 0 {
+		return ""
+	}
+	sort.Strings(titles)
+	return "\n\nTasks: " + strings.Join(titles, ", ")
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// countdownTickMsg redraws the relative due times at the turn of each
+// minute.
+type countdownTickMsg struct{}
+
+func countdownTick() tea.Cmd {
+	return tea.Every(time.Minute, func(time.Time) tea.Msg {
+		return countdownTickMsg{}
+	})
+}
+
+// dueCountdown says how long until an open task falls due, e.g. "due in
+// 2h 15m", or how long it has been overdue
+func dueCountdown(task *models.Task, now time.Time) string {
+	if task.DueDate.IsZero() || !task.IsOpen() {
+		return ""
+	}
+	d := task.DueDate.Sub(now)
+	switch {
+	case d >= time.Minute:
+		return "due in " + shortSpan(d)
+	case d > -time.Minute:
+		return "due now"
+	default:
+		return "overdue by " + shortSpan(-d)
+	}
+}
+
+// formatCountdown is the countdown in brackets after the due date in the
+// detail panel
+func formatCountdown(task *models.Task, now time.Time) string {
+	if countdown := dueCountdown(task, now); countdown != "" {
+		return " (" + countdown + ")"
+	}
+	return ""
+}
+
+// shortSpan writes d in its two largest units, e.g. "3d 4h" or "2h 15m",
+// or days alone once it is a week or more
+func shortSpan(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	minutes := int(d/time.Minute) % 60
+	switch {
+	case days >= 7:
+		return fmt.Sprintf("%dd", days)
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dueInput is the form field holding a task's due date.
+const dueInput = 2
+
+// Parts of the date picker that the arrow keys change.
+const (
+	pickDate = iota
+	pickHour
+	pickMinute
+)
+
+// pickerMinuteStep is how far the minute moves per key press.
+const pickerMinuteStep = 5
+
+// datePicker is a month calendar for choosing the due date, with an
+// optional time of day. It writes its choice back into the due field, so
+// the field can still be typed in directly.
+type datePicker struct {
+	// day is the chosen day; its clock holds the chosen time
+	day  time.Time
+	part int
+	// withTime is set once a time of day has been picked
+	withTime bool
+	// zone is kept from the field so picking doesn't drop it
+	zone string
+}
+
+// openDatePicker starts the picker on the date in the due field, or on
+// today if the field is empty or can't be read
+func (m *NotesApp) openDatePicker() {
+	p := &datePicker{day: startOfDay(time.Now())}
+	if due, zone, withTime, err := parseDueInput(m.inputs[dueInput].Value()); err == nil {
+		p.day, p.zone, p.withTime = due, zone, withTime
+	}
+	m.datePicker = p
+}
+
+// updateDatePicker handles keys while the date picker is open
+func (m *NotesApp) updateDatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.datePicker
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+	case "esc":
+		m.datePicker = nil
+	case "enter":
+		m.inputs[dueInput].SetValue(p.value())
+		m.inputs[dueInput].CursorEnd()
+		delete(m.formErrors, dueInput)
+		m.datePicker = nil
+	case "tab":
+		p.part = (p.part + 1) % 3
+		if p.part != pickDate {
+			p.withTime = true
+		}
+	case "shift+tab":
+		p.part = (p.part + 2) % 3
+		if p.part != pickDate {
+			p.withTime = true
+		}
+	case "t":
+		// Jump back to today, keeping the time
+		today := time.Now()
+		p.day = time.Date(today.Year(), today.Month(), today.Day(), p.day.Hour(), p.day.Minute(), 0, 0, p.day.Location())
+	case "backspace", "x":
+		// Due on the day, with no time of day
+		p.withTime = false
+		p.part = pickDate
+		p.day = startOfDay(p.day)
+	case "pgup", "[":
+		p.day = p.day.AddDate(0, -1, 0)
+	case "pgdown", "]":
+		p.day = p.day.AddDate(0, 1, 0)
+	case "left", "h":
+		p.move(-1, 0)
+	case "right", "l":
+		p.move(1, 0)
+	case "up", "k":
+		p.move(-7, 1)
+	case "down", "j":
+		p.move(7, -1)
+	}
+	return m, nil
+}
+
+// move steps the part being picked: days moves the date, while step
+// turns the hour or minute
+func (p *datePicker) move(days, step int) {
+	switch p.part {
+	case pickDate:
+		p.day = p.day.AddDate(0, 0, days)
+	case pickHour:
+		if step == 0 {
+			return
+		}
+		h := (p.day.Hour() + step + 24) % 24
+		p.day = time.Date(p.day.Year(), p.day.Month(), p.day.Day(), h, p.day.Minute(), 0, 0, p.day.Location())
+	case pickMinute:
+		if step == 0 {
+			return
+		}
+		minute := (p.day.Minute()/pickerMinuteStep*pickerMinuteStep + step*pickerMinuteStep + 60) % 60
+		p.day = time.Date(p.day.Year(), p.day.Month(), p.day.Day(), p.day.Hour(), minute, 0, 0, p.day.Location())
+	}
+}
+
+// value is the due field text for the picked date
+func (p *datePicker) value() string {
+	text := p.day.Format("2006-01-02")
+	if p.withTime {
+		text += " " + p.day.Format("15:04")
+	}
+	if p.zone != "" {
+		text += " " + p.zone
+	}
+	return text
+}
+
+// View draws the month around the picked day, Monday first
+func (p *datePicker) View() string {
+	selected := lipgloss.NewStyle().Reverse(true)
+	today := lipgloss.NewStyle().Underline(true)
+	picking := lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(p.day.Format("January 2006")) + "\n")
+	b.WriteString(helpStyle("Mo Tu We Th Fr Sa Su") + "\n")
+
+	first := time.Date(p.day.Year(), p.day.Month(), 1, 0, 0, 0, 0, p.day.Location())
+	offset := (int(first.Weekday()) + 6) % 7
+	b.WriteString(strings.Repeat("   ", offset))
+	now := time.Now()
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		cell := fmt.Sprintf("%2d", day.Day())
+		switch {
+		case day.Day() == p.day.Day():
+			cell = selected.Render(cell)
+		case sameDay(day, now):
+			cell = today.Render(cell)
+		}
+		b.WriteString(cell)
+		if (offset+day.Day())%7 == 0 || day.AddDate(0, 0, 1).Month() != first.Month() {
+			b.WriteString("\n")
+		} else {
+			b.WriteString(" ")
+		}
+	}
+
+	b.WriteString("Time: ")
+	if !p.withTime && p.part == pickDate {
+		b.WriteString(helpStyle("none"))
+	} else {
+		hour, minute := p.day.Format("15"), p.day.Format("04")
+		if p.part == pickHour {
+			hour = picking.Render(hour)
+		}
+		if p.part == pickMinute {
+			minute = picking.Render(minute)
+		}
+		b.WriteString(hour + ":" + minute)
+	}
+	if p.zone != "" {
+		b.WriteString(" " + p.zone)
+	}
+
+	help := "←/→/↑/↓: day • [/]: month • t: today • tab: set time • x: no time • enter: use • esc: type instead"
+	if p.part != pickDate {
+		help = "↑/↓: change • tab: next • x: no time • enter: use • esc: type instead"
+	}
+	b.WriteString("\n" + helpStyle(help))
+	return b.String()
+}
+
+// sameDay reports whether a and b fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// startOfDay is midnight at the start of t's day
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
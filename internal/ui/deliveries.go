@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+)
+
+// deliveryDays is how far back the delivery panel looks.
+const deliveryDays = 7
+
+type deliveriesReadyMsg struct {
+	report      reminder.DeliveryReport
+	deadLetters []reminder.DeadLetter
+	err         error
+}
+
+// openDeliveries loads the reminder delivery log
+func (m *NotesApp) openDeliveries() tea.Cmd {
+	return func() tea.Msg {
+		since := time.Now().AddDate(0, 0, -deliveryDays)
+		deliveries, err := reminder.LoadDeliveries(reminder.DeliveryPath(m.dataDir), since)
+		if err != nil {
+			return deliveriesReadyMsg{err: err}
+		}
+		letters, err := reminder.LoadDeadLetters(reminder.DeadLetterPath(m.dataDir))
+		if err != nil {
+			return deliveriesReadyMsg{err: err}
+		}
+		return deliveriesReadyMsg{report: reminder.SummarizeDeliveries(deliveries, since), deadLetters: letters}
+	}
+}
+
+func (m *NotesApp) handleDeliveriesReady(msg deliveriesReadyMsg) {
+	if msg.err != nil {
+		m.fail("Couldn't load deliveries: %v", msg.err)
+		return
+	}
+	m.deliveries = &msg.report
+	m.deadLetters = msg.deadLetters
+	m.deliveriesFrom = m.activeView
+	m.activeView = "deliveries"
+}
+
+// updateDeliveries handles keys while the delivery panel is open
+func (m *NotesApp) updateDeliveries(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+	case "esc", "N":
+		m.deliveries = nil
+		m.activeView = m.deliveriesFrom
+	case "c":
+		if len(m.deadLetters) == 0 {
+			break
+		}
+		if err := reminder.ClearDeadLetters(reminder.DeadLetterPath(m.dataDir)); err != nil {
+			m.fail("Couldn't clear undelivered reminders: %v", err)
+			break
+		}
+		m.status = fmt.Sprintf("Cleared %d undelivered reminder(s)", len(m.deadLetters))
+		m.deadLetters = nil
+	}
+	return m, nil
+}
+
+// deliveriesView shows how promptly reminders went out, with the late and
+// failed ones
+func (m *NotesApp) deliveriesView() string {
+	title := lipgloss.NewStyle().Bold(true).Render("Reminder deliveries")
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(title + "\n" + m.deliveries.Text() + formatDeadLetters(m.deadLetters))
+}
+
+// formatDeadLetters lists the reminders given up on, most recent first
+func formatDeadLetters(letters []reminder.DeadLetter) string {
+	if len(letters) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\nGave up on\n")
+	for i := len(letters) - 1; i >= 0; i-- {
+		d := letters[i]
+		fmt.Fprintf(&b, "%s  %-8s %s\n", d.GaveUp.Format("Jan 2 15:04"), d.Channel, d.Title)
+		fmt.Fprintf(&b, "    %d attempts, last: %s\n", d.Attempts, d.Error)
+	}
+	return b.String()
+}
+
+// reportDeadLetters points out reminders that couldn't be delivered
+// since they were last cleared
+func (m *NotesApp) reportDeadLetters() {
+	letters, err := reminder.LoadDeadLetters(reminder.DeadLetterPath(m.dataDir))
+	if err != nil || len(letters) == 0 || m.status != "" {
+		return
+	}
+	m.status = fmt.Sprintf("%d reminder(s) couldn't be delivered; N: reminder deliveries", len(letters))
+}
+
+// loadLastReminders reads each task's most recent reminder from the
+// delivery log. A log that can't be read leaves the detail without them.
+func (m *NotesApp) loadLastReminders(now time.Time) map[models.TaskID][]reminder.Delivery {
+	since := now.AddDate(0, 0, -deliveryDays)
+	deliveries, err := reminder.LoadDeliveries(reminder.DeliveryPath(m.dataDir), since)
+	if err != nil {
+		return nil
+	}
+	return reminder.LastReminders(deliveries)
+}
+
+// formatLastReminder shows how the task's latest reminder went through
+// each channel and whether it was acknowledged, telling a reminder that
+// was ignored from one that never arrived
+func formatLastReminder(task *models.Task, deliveries []reminder.Delivery, deadLetters []reminder.DeadLetter, now time.Time) string {
+	var lines []string
+	for _, d := range deadLetters {
+		if d.TaskID == task.ID {
+			lines = append(lines, fmt.Sprintf("Undelivered via %s after %d attempts: %s", d.Channel, d.Attempts, d.Error))
+		}
+	}
+	if len(deliveries) > 0 {
+		lines = append(lines, "Last reminder: "+deliveries[0].Scheduled.Format("Jan 2 15:04"))
+		for _, d := range deliveries {
+			if d.Failed() {
+				lines = append(lines, fmt.Sprintf("  %s: failed (%s)", d.Channel, d.Error))
+			} else {
+				lines = append(lines, fmt.Sprintf("  %s: delivered %s", d.Channel, d.Delivered.Format("Jan 2 15:04")))
+			}
+		}
+	} else if due := reminderDue(task, now); !due.IsZero() && task.IsOpen() &&
+		now.Sub(due) > reminder.LateAfter && due.After(now.AddDate(0, 0, -deliveryDays)) {
+		lines = append(lines, "Last reminder: "+due.Format("Jan 2 15:04")+", never delivered")
+	}
+
+	switch {
+	case task.ReminderAcknowledged() && task.AcknowledgedVia != "":
+		lines = append(lines, fmt.Sprintf("Acknowledged via %s %s", task.AcknowledgedVia, task.AcknowledgedAt.Format("Jan 2 15:04")))
+	case task.ReminderAcknowledged():
+		lines = append(lines, "Acknowledged "+task.AcknowledgedAt.Format("Jan 2 15:04"))
+	case len(deliveries) > 0:
+		lines = append(lines, "Not acknowledged")
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n\n" + strings.Join(lines, "\n")
+}
+
+// reminderDue is when the task's current reminder was due to go out
+func reminderDue(task *models.Task, now time.Time) time.Time {
+	due := task.ReminderAt
+	if task.Cron != "" {
+		due = task.CronReminder(now)
+	}
+	if task.SnoozedUntil.After(due) {
+		due = task.SnoozedUntil
+	}
+	return due
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// density is how much of each item the lists show.
+type density int
+
+const (
+	// densityStandard shows the title and one line of dates and status.
+	densityStandard density = iota
+	// densityDetailed adds a description preview and the tags.
+	densityDetailed
+	// densityMinimal shows titles only.
+	densityMinimal
+)
+
+func (d density) String() string {
+	switch d {
+	case densityDetailed:
+		return "detailed"
+	case densityMinimal:
+		return "minimal"
+	default:
+		return "standard"
+	}
+}
+
+func (d density) next() density {
+	return (d + 1) % 3
+}
+
+// delegate returns a list delegate sized for the density
+func (d density) delegate() list.DefaultDelegate {
+	delegate := list.NewDefaultDelegate()
+	switch d {
+	case densityMinimal:
+		delegate.ShowDescription = false
+		delegate.SetSpacing(0)
+	case densityDetailed:
+		delegate.SetHeight(4)
+	}
+	return delegate
+}
+
+// cycleDensity switches both lists to the next density
+func (m *NotesApp) cycleDensity() {
+	m.density = m.density.next()
+	m.notesList.SetDelegate(m.density.delegate())
+	m.tasksList.SetDelegate(newTaskRowDelegate(m.density.delegate()))
+	m.status = fmt.Sprintf("List density: %s", m.density)
+}
+
+// preview returns the first line of text, or a placeholder when it's empty
+func preview(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "(no description)"
+	}
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i]
+	}
+	return text
+}
+
+// tagLine lists tags for the detailed density
+func tagLine(tags []string) string {
+	if len(tags) == 0 {
+		return "no tags"
+	}
+	return "#" + strings.Join(tags, " #")
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// setDependencies makes the selected task wait on the marked tasks, or
+// clears its dependencies when nothing is marked
+func (m *NotesApp) setDependencies() tea.Cmd {
+	task := m.selectedTask
+	if task == nil {
+		return nil
+	}
+	edited := task.Clone()
+
+	var deps []models.TaskID
+	for id := range m.marked {
+		if _, ok := m.taskIndex[models.TaskID(id)]; ok && models.TaskID(id) != task.ID {
+			deps = append(deps, models.TaskID(id))
+		}
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i] < deps[j] })
+
+	if len(deps) == 0 {
+		if len(task.DependsOn) == 0 {
+			m.status = "Mark the tasks this one depends on, then press D"
+			return nil
+		}
+		for _, id := range task.DependsOn {
+			edited.RemoveDependency(id)
+		}
+		m.status = "Dependencies cleared"
+	} else {
+		for _, id := range deps {
+			if dep := m.taskIndex[id]; dep.DependsOnTask(task.ID, m.taskIndex) {
+				m.status = fmt.Sprintf("Can't wait on %q: it already depends on this task", dep.Title)
+				return nil
+			}
+			edited.AddDependency(id)
+		}
+		for id := range m.marked {
+			delete(m.marked, id)
+		}
+		m.status = fmt.Sprintf("Waiting on %d task(s)", len(edited.DependsOn))
+	}
+
+	return tea.Sequence(m.saveTask(edited), m.loadTasks())
+}
+
+// blockedStatus explains why a task can't be completed yet, or returns ""
+// when nothing blocks it
+func (m *NotesApp) blockedStatus(task *models.Task) string {
+	blockers := task.Blockers(m.taskIndex)
+	if len(blockers) == 0 {
+		return ""
+	}
+	titles := make([]string, len(blockers))
+	for i, b := range blockers {
+		titles[i] = b.Title
+	}
+	return "Blocked by: " + strings.Join(titles, ", ")
+}
+
+// formatParent names the task this one was split off from, or returns ""
+func formatParent(task *models.Task, index map[models.TaskID]*models.Task) string {
+	if task.ParentID == "" {
+		return ""
+	}
+	if parent, ok := index[task.ParentID]; ok {
+		return "\n\nSplit from: " + parent.Title
+	}
+	return "\n\nSplit from: " + string(task.ParentID) + " (deleted)"
+}
+
+// formatDependencies lists a task's dependencies with their state
+func formatDependencies(task *models.Task, index map[models.TaskID]*models.Task) string {
+	if len(task.DependsOn) == 0 {
+		return "none"
+	}
+	var b strings.Builder
+	for _, id := range task.DependsOn {
+		dep, ok := index[id]
+		switch {
+		case !ok:
+			b.WriteString("\n  [-] " + string(id) + " (deleted)")
+		case dep.Status == models.TaskStatusCompleted:
+			b.WriteString("\n  [x] " + dep.Title)
+		case dep.Status == models.TaskStatusCancelled:
+			b.WriteString("\n  [-] " + dep.Title + " (cancelled)")
+		default:
+			b.WriteString("\n  [ ] " + dep.Title)
+		}
+	}
+	return b.String()
+}
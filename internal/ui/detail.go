@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// detail scrolls the detail panel beside the lists, so long content isn't
+// cut off by the panel height.
+type detail struct {
+	viewport viewport.Model
+	// shown is the item in the panel; scrolling starts over at the top
+	// when it changes
+	shown string
+}
+
+// sizeDetail fits the viewport inside the panel's border and padding,
+// as tall as the list beside it less the scroll line
+func (m *NotesApp) sizeDetail() {
+	m.detail.viewport.Width = m.paneWidth() - 2
+	m.detail.viewport.Height = max(m.paneHeight()-1, 2)
+}
+
+// scrolledDetail shows text for the item named by key, scrolled to where
+// it was left while the same item stays selected
+func (m *NotesApp) scrolledDetail(key, text string) string {
+	d := &m.detail
+	// Wrap here, since the viewport would cut long lines off
+	d.viewport.SetContent(lipgloss.NewStyle().Width(d.viewport.Width).Render(text))
+	if key != d.shown {
+		d.shown = key
+		d.viewport.GotoTop()
+	}
+	if d.viewport.TotalLineCount() <= d.viewport.Height {
+		return d.viewport.View()
+	}
+	return d.viewport.View() + "\n" + helpStyle(fmt.Sprintf("%3.f%% • pgup/pgdn: scroll", d.viewport.ScrollPercent()*100))
+}
+
+// scrollDetail moves the detail panel a page up or down
+func (m *NotesApp) scrollDetail(key string) {
+	if key == "pgup" {
+		m.detail.viewport.PageUp()
+	} else {
+		m.detail.viewport.PageDown()
+	}
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// editorDoneMsg carries the text written in the external editor back to
+// the note, or to the form's content field when note is nil.
+type editorDoneMsg struct {
+	note    *models.Note
+	content string
+	err     error
+}
+
+// editorCommand builds the command opening path in $VISUAL or $EDITOR,
+// falling back to the platform's usual editor
+func editorCommand(path string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+		if runtime.GOOS == "windows" {
+			editor = "notepad"
+		}
+	}
+	// The variable may carry flags, e.g. "code --wait"
+	fields := strings.Fields(editor)
+	return exec.Command(fields[0], append(fields[1:], path)...)
+}
+
+// openEditor suspends the TUI and edits content in the external editor,
+// reporting the result with an editorDoneMsg for note
+func (m *NotesApp) openEditor(note *models.Note, content string) tea.Cmd {
+	f, err := os.CreateTemp("", "note-*.md")
+	if err != nil {
+		m.fail("Couldn't open the editor: %v", err)
+		return nil
+	}
+	path := f.Name()
+	_, err = f.WriteString(content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		m.fail("Couldn't open the editor: %v", err)
+		return nil
+	}
+
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return editorDoneMsg{note: note, err: err}
+		}
+		data, err := os.ReadFile(path)
+		return editorDoneMsg{note: note, content: string(data), err: err}
+	})
+}
+
+// editSelectedNote opens the selected note's content in the editor
+func (m *NotesApp) editSelectedNote() tea.Cmd {
+	note := m.selectedNote
+	if note == nil {
+		return nil
+	}
+	return m.withContent(func() tea.Cmd { return m.openEditor(note, note.Content) }, note)
+}
+
+func (m *NotesApp) handleEditorDone(msg editorDoneMsg) tea.Cmd {
+	if msg.err != nil {
+		m.fail("Editor failed: %v", msg.err)
+		return nil
+	}
+	if msg.note == nil {
+		m.setContent(msg.content)
+		return nil
+	}
+	if msg.content == msg.note.Content {
+		m.status = "No changes"
+		return nil
+	}
+	note := msg.note.Clone()
+	note.Content = msg.content
+	note.UpdatedAt = time.Now()
+	m.status = fmt.Sprintf("Saved %q", note.Title)
+	return tea.Sequence(m.saveNote(note), m.loadNotes())
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// toggleStarted moves the selected task in and out of progress. The actual
+// effort runs from the first start until completion
+func (m *NotesApp) toggleStarted() tea.Cmd {
+	task := m.selectedTask
+	switch {
+	case !task.IsOpen():
+		m.status = fmt.Sprintf("Task is already %s", task.Status)
+		return nil
+	case task.Status == models.TaskStatusInProgress:
+		cmd := m.setTaskStatus(models.TaskStatusPending)
+		m.status = fmt.Sprintf("Paused %q", task.Title)
+		return cmd
+	default:
+		cmd := m.setTaskStatus(models.TaskStatusInProgress)
+		if cmd != nil {
+			m.status = fmt.Sprintf("Started %q", task.Title)
+		}
+		return cmd
+	}
+}
+
+// formatEffort shows the estimate next to the time actually spent
+func formatEffort(task *models.Task, now time.Time) string {
+	estimate := "no estimate"
+	if task.Estimate > 0 {
+		estimate = models.FormatEffort(task.Estimate) + " estimated"
+	}
+	if task.StartedAt.IsZero() {
+		return estimate
+	}
+	return fmt.Sprintf("%s, %s spent", estimate, models.FormatEffort(task.Actual(now)))
+}
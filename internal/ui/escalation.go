@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/config"
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/reminder"
+)
+
+// openEscalation asks which escalation policy the selected task's
+// reminders follow
+func (m *NotesApp) openEscalation() {
+	task := m.selectedTask
+	if task == nil {
+		return
+	}
+
+	names := append([]string{reminder.DefaultEscalationName}, m.escalations...)
+	placeholder := strings.Join(names, ", ")
+
+	m.prompt = newPrompt("Escalation:", placeholder, func(value string) tea.Cmd {
+		if value == reminder.DefaultEscalationName {
+			value = ""
+		}
+		if value != "" && !slices.Contains(m.escalations, value) {
+			m.status = fmt.Sprintf("No escalation policy named %q; use one of %s", value, placeholder)
+			return nil
+		}
+		edited := task.Clone()
+		edited.Escalation = value
+		edited.UpdatedAt = time.Now()
+		m.status = fmt.Sprintf("%q now follows the %s escalation policy", task.Title, escalationName(edited))
+		return tea.Sequence(m.saveTask(edited), m.loadTasks())
+	})
+}
+
+// acknowledgeBanner marks the tasks on the banner as seen so their
+// reminders stop repeating, and takes the banner down
+func (m *NotesApp) acknowledgeBanner() tea.Cmd {
+	var acknowledged []*models.Task
+	for _, e := range m.banner {
+		if task := m.taskIndex[e.TaskID]; task != nil && e.TaskID != "" && !task.ReminderAcknowledged() {
+			task = task.Clone()
+			task.Acknowledge("tui")
+			acknowledged = append(acknowledged, task)
+		}
+	}
+	m.banner = nil
+	if len(acknowledged) == 0 {
+		return nil
+	}
+	return tea.Sequence(m.saveTasksBatch(acknowledged), m.loadTasks())
+}
+
+func escalationName(task *models.Task) string {
+	if task.Escalation == "" {
+		return reminder.DefaultEscalationName
+	}
+	return task.Escalation
+}
+
+// formatEscalation shows the escalation policy when the task picks one
+func formatEscalation(task *models.Task) string {
+	if task.Escalation == "" {
+		return ""
+	}
+	return "\n\nEscalation: " + task.Escalation
+}
+
+// escalationNames lists the configured policies other than the default
+func escalationNames(cfg *config.Config) []string {
+	var names []string
+	for name := range cfg.Notification.Escalation {
+		if name != reminder.DefaultEscalationName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+type storageEventMsg storage.Event
+
+// subscribe forwards storage events into the program so lists refresh when
+// other components (the reminder service, imports) write data.
+func (m *NotesApp) subscribe() tea.Cmd {
+	m.unsubscribe = m.storage.Events().Subscribe(func(e storage.Event) {
+		select {
+		case m.storageEvents <- e:
+		case <-m.ctx.Done():
+		}
+	})
+	return m.waitForStorageEvent()
+}
+
+func (m *NotesApp) waitForStorageEvent() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case e := <-m.storageEvents:
+			return storageEventMsg(e)
+		case <-m.ctx.Done():
+			return nil
+		}
+	}
+}
+
+// handleStorageEvent reloads the list affected by an event and waits for
+// the next one.
+func (m *NotesApp) handleStorageEvent(e storageEventMsg) tea.Cmd {
+	switch e.Kind {
+	case storage.NoteSaved, storage.NoteDeleted, storage.NotesReloaded:
+		return tea.Batch(m.loadNotes(), m.waitForStorageEvent())
+	default:
+		return tea.Batch(m.loadTasks(), m.waitForStorageEvent())
+	}
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+)
+
+// formatFields lists a task's custom fields for the detail panel, or
+// returns "" when it has none
+func formatFields(fields map[string]string) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString("\n\nFields:")
+	for _, name := range names {
+		b.WriteString("\n  " + name + ": " + fields[name])
+	}
+	return b.String()
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/planner"
+)
+
+// forecastDays is how far ahead the forecast panel looks.
+const forecastDays = 14
+
+var (
+	barStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("62")).Render
+	overBarStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true).Render
+)
+
+type forecastReadyMsg struct {
+	loads []planner.DayLoad
+	err   error
+}
+
+// openForecast counts the tasks due over the coming days
+func (m *NotesApp) openForecast() tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := m.storage.GetAllTasks(m.ctx)
+		if err != nil {
+			return forecastReadyMsg{err: err}
+		}
+		return forecastReadyMsg{loads: planner.Forecast(tasks, time.Now(), forecastDays)}
+	}
+}
+
+func (m *NotesApp) handleForecastReady(msg forecastReadyMsg) {
+	if msg.err != nil {
+		m.fail("Couldn't load tasks: %v", msg.err)
+		return
+	}
+	m.forecast = msg.loads
+	m.activeView = "forecast"
+}
+
+// updateForecast handles keys while the forecast panel is open
+func (m *NotesApp) updateForecast(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+	case "esc", "F":
+		m.forecast = nil
+		m.activeView = "tasks"
+	}
+	return m, nil
+}
+
+// forecastView draws one bar per day, flagging days over capacity
+func (m *NotesApp) forecastView() string {
+	capacity := m.dailyCapacity
+	if capacity <= 0 {
+		capacity = planner.DefaultDailyCapacity
+	}
+
+	peak := capacity
+	for _, load := range m.forecast {
+		if load.Count > peak {
+			peak = load.Count
+		}
+	}
+	width := m.width - 40
+	if width < 10 {
+		width = 10
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Due-load forecast") + "\n")
+	b.WriteString(helpStyle(fmt.Sprintf("Capacity: %d task(s) per day", capacity)) + "\n\n")
+
+	crunch := 0
+	for _, load := range m.forecast {
+		bar := strings.Repeat("█", load.Count*width/peak)
+		estimate := ""
+		if load.Estimate > 0 {
+			estimate = models.FormatEffort(load.Estimate)
+		}
+		label := fmt.Sprintf("%-10s %3d %7s ", load.Day.Format("Mon Jan 2"), load.Count, estimate)
+		if load.Over(capacity) {
+			crunch++
+			b.WriteString(overBarStyle(label+bar+" !") + "\n")
+		} else {
+			b.WriteString(itemStyle.Render(label) + barStyle(bar) + "\n")
+		}
+	}
+
+	b.WriteString("\n")
+	if crunch > 0 {
+		b.WriteString(overBarStyle(fmt.Sprintf("%d day(s) over capacity", crunch)))
+	} else {
+		b.WriteString(helpStyle("No day is over capacity"))
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(b.String())
+}
@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// jumpResultLimit caps how many matches the switcher lists.
+const jumpResultLimit = 15
+
+// jump is the state of the quick switcher, which fuzzy-matches the
+// titles of every note and task and opens the chosen one in its view.
+type jump struct {
+	input textinput.Model
+	items []jumpItem
+	// titles are the items' titles, in the same order, to match against
+	titles  []string
+	matches []list.Rank
+	cursor  int
+	// from is the view to return to
+	from string
+}
+
+// jumpItem is a note or a task the switcher can jump to.
+type jumpItem struct {
+	note *models.Note
+	task *models.Task
+}
+
+func (j jumpItem) title() string {
+	if j.task != nil {
+		return j.task.Title
+	}
+	return j.note.Title
+}
+
+type jumpItemsMsg struct {
+	items []jumpItem
+	err   error
+}
+
+// openJump shows the quick switcher and loads the items to choose from
+func (m *NotesApp) openJump() tea.Cmd {
+	t := textinput.New()
+	t.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("170"))
+	t.Placeholder = "part of a title"
+	t.CharLimit = 200
+	t.Focus()
+	m.jump = &jump{input: t, from: m.activeView}
+	m.activeView = "jump"
+	return m.loadJumpItems()
+}
+
+// loadJumpItems reads every note and task that isn't archived, leaving
+// note bodies on disk
+func (m *NotesApp) loadJumpItems() tea.Cmd {
+	return func() tea.Msg {
+		notes, err := m.storage.GetNoteMeta(m.ctx)
+		if err != nil {
+			return jumpItemsMsg{err: err}
+		}
+		tasks, err := m.storage.GetAllTasks(m.ctx)
+		if err != nil {
+			return jumpItemsMsg{err: err}
+		}
+		items := make([]jumpItem, 0, len(notes)+len(tasks))
+		for _, task := range tasks {
+			if !task.IsArchived() {
+				items = append(items, jumpItem{task: task})
+			}
+		}
+		for _, note := range notes {
+			if !note.IsArchived() {
+				items = append(items, jumpItem{note: note})
+			}
+		}
+		return jumpItemsMsg{items: items}
+	}
+}
+
+func (m *NotesApp) handleJumpItems(msg jumpItemsMsg) {
+	j := m.jump
+	if j == nil {
+		return
+	}
+	if msg.err != nil {
+		m.fail("Couldn't load items to jump to: %v", msg.err)
+		return
+	}
+	j.items = msg.items
+	j.titles = make([]string, len(msg.items))
+	for i, item := range msg.items {
+		j.titles[i] = item.title()
+	}
+	j.match()
+}
+
+// match ranks the items against the query, best first. With no query
+// every item is listed in order.
+func (j *jump) match() {
+	j.cursor = 0
+	query := strings.TrimSpace(j.input.Value())
+	if query == "" {
+		j.matches = make([]list.Rank, 0, len(j.items))
+		for i := range j.items {
+			j.matches = append(j.matches, list.Rank{Index: i})
+		}
+	} else {
+		j.matches = list.DefaultFilter(query, j.titles)
+	}
+	if len(j.matches) > jumpResultLimit {
+		j.matches = j.matches[:jumpResultLimit]
+	}
+}
+
+// updateJump handles keys while the quick switcher is open
+func (m *NotesApp) updateJump(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	j := m.jump
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+	case "esc", "ctrl+p":
+		m.jump = nil
+		m.activeView = j.from
+		return m, nil
+	case "up":
+		if j.cursor > 0 {
+			j.cursor--
+		}
+		return m, nil
+	case "down", "ctrl+n":
+		if j.cursor < len(j.matches)-1 {
+			j.cursor++
+		}
+		return m, nil
+	case "enter":
+		if len(j.matches) == 0 {
+			return m, nil
+		}
+		item := j.items[j.matches[j.cursor].Index]
+		m.jump = nil
+		if item.task != nil {
+			m.focusTask = item.task.ID
+			m.activeView = "tasks"
+			return m, m.loadTasks()
+		}
+		m.focusNote = item.note.ID
+		m.activeView = "notes"
+		return m, m.loadNotes()
+	}
+
+	before := j.input.Value()
+	var cmd tea.Cmd
+	j.input, cmd = j.input.Update(msg)
+	if j.input.Value() != before {
+		j.match()
+	}
+	return m, cmd
+}
+
+// jumpView shows the query above the best matches, with the matched
+// letters highlighted
+func (m *NotesApp) jumpView() string {
+	j := m.jump
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Jump to") + " " + j.input.View() + "\n\n")
+
+	switch {
+	case j.items == nil:
+		b.WriteString(helpStyle("Loading…"))
+	case len(j.matches) == 0:
+		b.WriteString(helpStyle("No matches"))
+	}
+
+	for i, r := range j.matches {
+		item := j.items[r.Index]
+		kind := "note"
+		if item.task != nil {
+			kind = "task"
+		}
+		title := j.titles[r.Index]
+		if len(r.MatchedIndexes) > 0 {
+			title = lipgloss.StyleRunes(title, r.MatchedIndexes, matchStyle, lipgloss.NewStyle())
+		}
+		line := fmt.Sprintf("%s  %s", kind, title)
+		if i == j.cursor {
+			b.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+		} else {
+			b.WriteString(itemStyle.Render(line) + "\n")
+		}
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(b.String())
+}
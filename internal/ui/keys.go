@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpGroup is one titled column of the help overlay.
+type helpGroup struct {
+	title string
+	keys  []key.Binding
+}
+
+func bind(keys, desc string) key.Binding {
+	return key.NewBinding(key.WithKeys(strings.Split(keys, "/")...), key.WithHelp(keys, desc))
+}
+
+// Bindings shared by the notes and tasks views.
+var (
+	itemKeys = []key.Binding{
+		bind("n", "new"),
+		bind("+", "quick-add task"),
+		bind("e", "edit"),
+		bind("d", "delete"),
+		bind("c", "toggle completion"),
+		bind("u", "undo"),
+		bind("ctrl+r", "redo"),
+		bind("*", "pin"),
+		bind("C", "convert note/task"),
+		bind("H", "archive"),
+		bind("space", "mark"),
+		bind("B", "bulk edit marked"),
+	}
+	findKeys = []key.Binding{
+		bind("/", "search everything"),
+		bind("ctrl+p", "jump to item"),
+		bind("#", "browse tags"),
+		bind("o", "cycle sort order"),
+		bind("v", "hide completed"),
+		bind("!", "only overdue"),
+		bind("=", "only one tag"),
+		bind("p", "cycle projects"),
+		bind("L", "cycle smart lists"),
+		bind("V", "cycle density"),
+		bind("pgup/pgdown", "scroll details"),
+		bind("i", "list/details when narrow"),
+	}
+	panelKeys = []key.Binding{
+		bind("O", "overdue triage"),
+		bind("F", "forecast"),
+		bind("A", "agenda"),
+		bind("K", "board"),
+		bind("M", "matrix"),
+		bind("Y", "archived items"),
+		bind("W", "trash"),
+		bind("R", "review changes"),
+		bind("N", "reminder deliveries"),
+	}
+	appKeys = []key.Binding{
+		bind("tab", "switch notes/tasks"),
+		bind("P", "pause reminders"),
+		bind("x", "dismiss reminder banner"),
+		bind("z", "snooze banner reminder"),
+		bind("ctrl+g", "tour"),
+		bind("?", "this help"),
+		bind("q", "quit"),
+	}
+)
+
+var (
+	noteKeys = []key.Binding{
+		bind("enter", "read full screen"),
+		bind("ctrl+o", "edit in $EDITOR"),
+		bind("f", "follow link"),
+		bind("a", "attach audio"),
+	}
+	taskKeys = []key.Binding{
+		bind("enter", "subtasks"),
+		bind("T", "new from template"),
+		bind("t", "cycle status"),
+		bind("X", "cancel/reopen"),
+		bind("s", "start/stop timer"),
+		bind("z", "snooze reminders"),
+		bind("E", "escalation"),
+		bind("D", "depend on marked"),
+		bind("@", "next context"),
+		bind("1/2/3/0", "today/upcoming/someday/all"),
+	}
+)
+
+// helpGroups lists every binding of the notes or tasks view
+func helpGroups(view string) []helpGroup {
+	own := helpGroup{title: "Notes", keys: noteKeys}
+	if view == "tasks" {
+		own = helpGroup{title: "Tasks", keys: taskKeys}
+	}
+	return []helpGroup{
+		{title: "Items", keys: itemKeys},
+		own,
+		{title: "Find & show", keys: findKeys},
+		{title: "Panels", keys: panelKeys},
+		{title: "App", keys: appKeys},
+	}
+}
+
+// keyHelp is the state of the help overlay.
+type keyHelp struct {
+	// from is the view whose keys are shown, and the one to return to
+	from string
+}
+
+// openKeyHelp shows every binding of the current view
+func (m *NotesApp) openKeyHelp() {
+	m.keyHelp = &keyHelp{from: m.activeView}
+	m.activeView = "help"
+}
+
+// updateKeyHelp handles keys while the help overlay is open
+func (m *NotesApp) updateKeyHelp(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+	case "?", "esc", "q":
+		m.activeView = m.keyHelp.from
+		m.keyHelp = nil
+	}
+	return m, nil
+}
+
+// keyHelpView lays the binding groups out in columns, wrapping onto
+// another row when the window is too narrow for them all
+func (m *NotesApp) keyHelpView() string {
+	h := help.New()
+	title := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("170"))
+	width := m.width - 8
+
+	var rows, row []string
+	for _, g := range helpGroups(m.keyHelp.from) {
+		column := title.Render(g.title) + "\n" + h.FullHelpView([][]key.Binding{g.keys})
+		column = lipgloss.NewStyle().PaddingRight(4).PaddingBottom(1).Render(column)
+		if len(row) > 0 && lipgloss.Width(lipgloss.JoinHorizontal(lipgloss.Top, append(row, column)...)) > width {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row = nil
+		}
+		row = append(row, column)
+	}
+	rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+
+	heading := lipgloss.NewStyle().Bold(true).Render("Keys: " + m.keyHelp.from)
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(heading + "\n\n" + strings.Join(rows, "\n"))
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+)
+
+// narrowWidth is the narrowest window that fits a list and its detail
+// panel side by side. Narrower windows show one of them at a time.
+const narrowWidth = 100
+
+func (m *NotesApp) narrow() bool {
+	return m.width < narrowWidth
+}
+
+// paneWidth is the width of a list or detail panel inside its border
+func (m *NotesApp) paneWidth() int {
+	if m.narrow() {
+		return max(m.width-4, 20)
+	}
+	return max(m.width/2-4, 20)
+}
+
+// paneHeight is the height of a list or detail panel inside its border
+// and padding. A narrow window gives a row to the hint below the panel.
+func (m *NotesApp) paneHeight() int {
+	if m.narrow() {
+		return max(m.height-11, 3)
+	}
+	return max(m.height-10, 3)
+}
+
+// sizeLists fits the lists inside their panels' padding
+func (m *NotesApp) sizeLists() {
+	width, height := m.paneWidth()-2, m.paneHeight()
+	m.notesList.SetSize(width, height)
+	m.tasksList.SetSize(width, height)
+	m.reviewList.SetSize(width, height)
+}
+
+// panes shows a list beside its detail panel, or in a narrow window
+// whichever of the two was toggled to
+func (m *NotesApp) panes(list, detail string) string {
+	panel := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.paneWidth())
+
+	if m.narrow() {
+		if m.showDetail {
+			return panel.Render(detail) + "\n" + helpStyle("i: back to the list")
+		}
+		return panel.Render(list) + "\n" + helpStyle("i: details")
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, panel.Render(list), panel.Render(detail))
+}
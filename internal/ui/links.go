@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// linkedNotes returns the notes the selected note links to followed by
+// the ones linking back to it, in the order the detail panel numbers them
+func (m *NotesApp) linkedNotes(note *models.Note) []*models.Note {
+	var linked []*models.Note
+	for _, id := range note.Links {
+		if target, ok := m.noteIndex[id]; ok {
+			linked = append(linked, target)
+		}
+	}
+	all := make([]*models.Note, 0, len(m.noteIndex))
+	for _, n := range m.noteIndex {
+		all = append(all, n)
+	}
+	backlinks := models.Backlinks(note.ID, all)
+	sort.Slice(backlinks, func(i, j int) bool { return backlinks[i].Title < backlinks[j].Title })
+	return append(linked, backlinks...)
+}
+
+// formatLinks lists links and backlinks with the numbers used to follow
+// them, or returns "" when there are none
+func (m *NotesApp) formatLinks(note *models.Note) string {
+	linked := m.linkedNotes(note)
+	if len(linked) == 0 {
+		return ""
+	}
+	outgoing := 0
+	for _, id := range note.Links {
+		if _, ok := m.noteIndex[id]; ok {
+			outgoing++
+		}
+	}
+
+	var b strings.Builder
+	for i, target := range linked {
+		if i == 0 && outgoing > 0 {
+			b.WriteString("\n\nLinks:")
+		}
+		if i == outgoing {
+			b.WriteString("\n\nBacklinks:")
+		}
+		b.WriteString(fmt.Sprintf("\n  %d. %s", i+1, target.Title))
+	}
+	return b.String()
+}
+
+// followLink jumps to a linked note, asking which one when there are
+// several
+func (m *NotesApp) followLink() tea.Cmd {
+	linked := m.linkedNotes(m.selectedNote)
+	switch len(linked) {
+	case 0:
+		m.status = "No links to follow"
+		return nil
+	case 1:
+		m.jumpToNote(linked[0])
+		return nil
+	}
+	m.prompt = newPrompt(fmt.Sprintf("Follow link (1-%d):", len(linked)), "1", func(value string) tea.Cmd {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 || n > len(linked) {
+			m.status = fmt.Sprintf("No link %q", value)
+			return nil
+		}
+		m.jumpToNote(linked[n-1])
+		return nil
+	})
+	return nil
+}
+
+// jumpToNote selects a note in the notes list
+func (m *NotesApp) jumpToNote(target *models.Note) {
+	for i, item := range m.notesList.Items() {
+		if ni, ok := item.(noteItem); ok && ni.note.ID == target.ID {
+			m.notesList.Select(i)
+			m.selectedNote = ni.note
+			m.status = ""
+			return
+		}
+	}
+	m.status = fmt.Sprintf("%q is hidden by the current smart list", target.Title)
+}
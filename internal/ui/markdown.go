@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+)
+
+// markdownCacheSize caps how many rendered texts are kept; the detail
+// panel redraws on every key press, and rendering is not cheap.
+const markdownCacheSize = 64
+
+// markdown renders note content and task descriptions for the detail
+// panel and the reader, with one renderer per wrap width.
+type markdown struct {
+	style    string
+	width    int
+	renderer *glamour.TermRenderer
+	cache    map[string]string
+}
+
+// newMarkdown uses the dark style unless $GLAMOUR_STYLE names another
+// standard one. The terminal isn't asked for its background, since not
+// every terminal answers and startup would stall waiting.
+func newMarkdown() *markdown {
+	style := styles.DarkStyle
+	if env := os.Getenv("GLAMOUR_STYLE"); styles.DefaultStyles[env] != nil {
+		style = env
+	}
+	return &markdown{style: style}
+}
+
+// render formats text wrapped to width, falling back to the text as
+// written if it can't be rendered
+func (md *markdown) render(text string, width int) string {
+	if strings.TrimSpace(text) == "" {
+		return text
+	}
+	if md.renderer == nil || md.width != width {
+		r, err := glamour.NewTermRenderer(
+			glamour.WithStandardStyle(md.style),
+			glamour.WithWordWrap(width),
+		)
+		if err != nil {
+			return text
+		}
+		md.renderer, md.width = r, width
+		md.cache = make(map[string]string)
+	}
+
+	if out, ok := md.cache[text]; ok {
+		return out
+	}
+	out, err := md.renderer.Render(text)
+	if err != nil {
+		return text
+	}
+	out = strings.Trim(out, "\n")
+	if len(md.cache) >= markdownCacheSize {
+		md.cache = make(map[string]string)
+	}
+	md.cache[text] = out
+	return out
+}
+
+// detailWidth is the width of text in the detail panel, inside its
+// border and padding
+func (m *NotesApp) detailWidth() int {
+	return m.paneWidth() - 2
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/planner"
+)
+
+// matrix is the state of the Eisenhower matrix panel.
+type matrix struct {
+	cells [4][]*models.Task
+	// cell is the quadrant with the cursor; rows keeps a row per quadrant
+	cell planner.Quadrant
+	rows [4]int
+}
+
+type matrixReadyMsg struct {
+	tasks []*models.Task
+	// keep is the task to leave the cursor on
+	keep models.TaskID
+	err  error
+}
+
+// openMatrix loads the tasks to sort into quadrants
+func (m *NotesApp) openMatrix(keep models.TaskID) tea.Cmd {
+	return func() tea.Msg {
+		tasks, err := m.storage.GetAllTasks(m.ctx)
+		return matrixReadyMsg{tasks: tasks, keep: keep, err: err}
+	}
+}
+
+func (m *NotesApp) handleMatrixReady(msg matrixReadyMsg) {
+	if msg.err != nil {
+		m.fail("Couldn't load tasks: %v", msg.err)
+		return
+	}
+	x := &matrix{cells: planner.Matrix(msg.tasks, time.Now())}
+	if m.matrix != nil {
+		x.cell, x.rows = m.matrix.cell, m.matrix.rows
+	}
+	for q, cell := range x.cells {
+		for row, task := range cell {
+			if task.ID == msg.keep {
+				x.cell, x.rows[q] = planner.Quadrant(q), row
+			}
+		}
+		x.rows[q] = min(x.rows[q], max(len(cell)-1, 0))
+	}
+	m.matrix = x
+	m.activeView = "matrix"
+}
+
+// selected is the task under the cursor, nil in an empty quadrant
+func (x *matrix) selected() *models.Task {
+	cell := x.cells[x.cell]
+	if len(cell) == 0 {
+		return nil
+	}
+	return cell[x.rows[x.cell]]
+}
+
+// updateMatrix handles keys while the matrix is open
+func (m *NotesApp) updateMatrix(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	x := m.matrix
+	task := x.selected()
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+	case "esc", "M":
+		m.matrix = nil
+		m.activeView = "tasks"
+	case "tab":
+		x.cell = (x.cell + 1) % 4
+	case "shift+tab":
+		x.cell = (x.cell + 3) % 4
+	case "left", "h", "right", "l":
+		// Quadrants pair up across the rows: Do and Schedule, Delegate
+		// and Drop
+		x.cell ^= 1
+	case "up", "k":
+		if x.rows[x.cell] > 0 {
+			x.rows[x.cell]--
+		}
+	case "down", "j":
+		if x.rows[x.cell] < len(x.cells[x.cell])-1 {
+			x.rows[x.cell]++
+		}
+	case "1", "2", "3", "4":
+		if task == nil {
+			break
+		}
+		// Move the task to another quadrant by changing its priority
+		// or due date
+		to := planner.Quadrant(msg.String()[0] - '1')
+		if to == x.cell {
+			break
+		}
+		edited := task.Clone()
+		planner.MoveToQuadrant(edited, to, time.Now())
+		m.status = fmt.Sprintf("%q moved to %s: %s priority, %s", task.Title, to, edited.Priority, formatMatrixDue(edited))
+		return m, tea.Sequence(m.saveTask(edited), m.openMatrix(task.ID))
+	case "enter":
+		if task == nil {
+			break
+		}
+		// Open the task in the task list
+		m.matrix = nil
+		m.activeView = "tasks"
+		m.focusTask = task.ID
+		return m, m.loadTasks()
+	}
+	return m, nil
+}
+
+func formatMatrixDue(task *models.Task) string {
+	if task.DueDate.IsZero() {
+		return "no due date"
+	}
+	return "due " + task.DueDate.Format("Mon Jan 2")
+}
+
+// matrixView draws the quadrants in a 2x2 grid, urgent on the left and
+// important on top
+func (m *NotesApp) matrixView() string {
+	x := m.matrix
+	// The panel's border and padding take six columns
+	width := max((m.width-6)/2, 20)
+	// Leave room for the title, headers, help and borders
+	visible := max((m.height-16)/2, 2)
+
+	cells := make([]string, 4)
+	for q, cell := range x.cells {
+		quadrant := planner.Quadrant(q)
+		var s strings.Builder
+		header := fmt.Sprintf("%d %s (%d)", q+1, quadrant, len(cell))
+		if quadrant == planner.QuadrantDo {
+			s.WriteString(overBarStyle(header) + "\n")
+		} else {
+			s.WriteString(groupHeaderStyle(header) + "\n")
+		}
+
+		// Scroll the quadrant so its cursor stays in view
+		start := max(x.rows[q]-visible+1, 0)
+		end := min(start+visible, len(cell))
+		for row := start; row < end; row++ {
+			task := cell[row]
+			line := truncate(task.Title, width-16)
+			if !task.DueDate.IsZero() {
+				line = fmt.Sprintf("%-*s %s", width-16, line, task.DueDate.Format("Jan 2"))
+			}
+			if quadrant == x.cell && row == x.rows[q] {
+				s.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+			} else {
+				s.WriteString(itemStyle.Render(line) + "\n")
+			}
+		}
+		if end < len(cell) {
+			s.WriteString(helpStyle(fmt.Sprintf("  ↓ %d more", len(cell)-end)) + "\n")
+		}
+		if len(cell) == 0 {
+			s.WriteString(helpStyle("  empty") + "\n")
+		}
+		cells[q] = lipgloss.NewStyle().Width(width).PaddingBottom(1).Render(s.String())
+	}
+
+	grid := lipgloss.JoinVertical(lipgloss.Left,
+		lipgloss.JoinHorizontal(lipgloss.Top, cells[planner.QuadrantDo], cells[planner.QuadrantSchedule]),
+		lipgloss.JoinHorizontal(lipgloss.Top, cells[planner.QuadrantDelegate], cells[planner.QuadrantDrop]),
+	)
+	title := lipgloss.NewStyle().Bold(true).Render("Eisenhower matrix") +
+		helpStyle("  urgent: due within 2 days • important: high priority")
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(title + "\n\n" + grid)
+}
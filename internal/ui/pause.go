@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/reminder"
+)
+
+// togglePause resumes paused reminders, or asks how long to pause them
+func (m *NotesApp) togglePause() {
+	if m.pause.Active(time.Now()) {
+		pause, err := reminder.Resume(m.pausePath)
+		if err != nil {
+			m.fail("Couldn't resume reminders: %v", err)
+			return
+		}
+		m.pause = pause
+		m.status = "Reminders resumed"
+		if len(pause.Missed) > 0 {
+			m.status += fmt.Sprintf("; a digest of %d missed reminder(s) is on its way", len(pause.Missed))
+		}
+		return
+	}
+
+	m.prompt = newPrompt("Pause reminders until:", "YYYY-MM-DD, 3d or 4h (critical ones still ring)", func(value string) tea.Cmd {
+		until, err := reminder.ParsePauseUntil(value, time.Now())
+		if err != nil {
+			m.fail("%v", err)
+			return nil
+		}
+		pause, err := reminder.PauseUntil(m.pausePath, until)
+		if err != nil {
+			m.fail("Couldn't pause reminders: %v", err)
+			return nil
+		}
+		m.pause = pause
+		m.status = "Reminders paused until " + until.Format("Mon Jan 2 15:04")
+		return nil
+	})
+}
+
+// pauseBanner is shown in the header while reminders are paused
+func (m *NotesApp) pauseBanner() string {
+	if !m.pause.Active(time.Now()) {
+		return ""
+	}
+	return "⏸ reminders paused until " + m.pause.Until.Format("Mon Jan 2")
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// pinGlyph marks pinned items at the top of their list
+var pinGlyph = lipgloss.NewStyle().Foreground(lipgloss.Color("220")).Render("★")
+
+// togglePinned pins the selected note or task to the top of its list, or
+// unpins it
+func (m *NotesApp) togglePinned() tea.Cmd {
+	if m.activeView == "notes" && m.selectedNote != nil {
+		selected := m.selectedNote
+		return m.withContent(func() tea.Cmd {
+			edited := selected.Clone()
+			edited.SetPinned(!edited.Pinned)
+			m.status = pinnedStatus(edited.Title, edited.Pinned)
+			return tea.Sequence(m.saveNote(edited), m.loadNotes())
+		}, selected)
+	}
+	if m.activeView == "tasks" && m.selectedTask != nil {
+		edited := m.selectedTask.Clone()
+		edited.SetPinned(!edited.Pinned)
+		m.status = pinnedStatus(edited.Title, edited.Pinned)
+		return tea.Sequence(m.saveTask(edited), m.loadTasks())
+	}
+	return nil
+}
+
+func pinnedStatus(title string, pinned bool) string {
+	if pinned {
+		return fmt.Sprintf("Pinned %q", title)
+	}
+	return fmt.Sprintf("Unpinned %q", title)
+}
+
+// pinNotesFirst moves pinned notes ahead of the rest, keeping the order
+// within each group
+func pinNotesFirst(notes []*models.Note) {
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].Pinned && !notes[j].Pinned
+	})
+}
+
+func pinTasksFirst(tasks []*models.Task) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Pinned && !tasks[j].Pinned
+	})
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// priorityInput is the form field choosing the item's priority. It is a
+// selector rather than one of the text inputs, so it sits past their
+// indexes.
+const priorityInput = 9
+
+// priorityColors shows each priority at a glance in the lists and form
+var priorityColors = map[models.Priority]lipgloss.Color{
+	models.LowPriority:    lipgloss.Color("39"),
+	models.MediumPriority: lipgloss.Color("214"),
+	models.HighPriority:   lipgloss.Color("203"),
+}
+
+var priorityGlyphs = map[models.Priority]string{
+	models.LowPriority:    "▼",
+	models.MediumPriority: "◆",
+	models.HighPriority:   "▲",
+}
+
+// priorityBadge is the colored marker for p following a title in the
+// lists
+func priorityBadge(p models.Priority) string {
+	glyph, ok := priorityGlyphs[p]
+	if !ok {
+		return ""
+	}
+	return " " + lipgloss.NewStyle().Foreground(priorityColors[p]).Render(glyph)
+}
+
+// setFormPriority fills the priority field, treating items saved before
+// priorities existed as medium
+func (m *NotesApp) setFormPriority(p models.Priority) {
+	if _, ok := priorityGlyphs[p]; !ok {
+		p = models.MediumPriority
+	}
+	m.formPriority = p
+}
+
+// updatePriority cycles the priority field with the arrow keys, or sets
+// it straight away with l, m or h
+func (m *NotesApp) updatePriority(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "left":
+		if m.formPriority > models.LowPriority {
+			m.formPriority--
+		}
+	case "right":
+		if m.formPriority < models.HighPriority {
+			m.formPriority++
+		}
+	case "l":
+		m.formPriority = models.LowPriority
+	case "m":
+		m.formPriority = models.MediumPriority
+	case "h":
+		m.formPriority = models.HighPriority
+	}
+}
+
+// priorityField draws the priority selector, highlighted when focused
+func (m *NotesApp) priorityField() string {
+	value := lipgloss.NewStyle().Foreground(priorityColors[m.formPriority]).Render(priorityGlyphs[m.formPriority] + " " + m.formPriority.String())
+	if m.activeInput != priorityInput {
+		return "> Priority: " + value
+	}
+	arrow := lipgloss.NewStyle().Foreground(lipgloss.Color("170"))
+	return "> Priority: " + arrow.Render("◂ ") + value + arrow.Render(" ▸")
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// projectInput is the form field naming the item's project.
+const projectInput = 6
+
+// newProjectInput sets up the project field to suggest existing project
+// names; right arrow accepts a suggestion since tab moves between fields.
+func newProjectInput(t textinput.Model) textinput.Model {
+	t.Placeholder = "Project (e.g., Work, Home)"
+	t.ShowSuggestions = true
+	t.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
+	return t
+}
+
+// formInputs lists the inputs shown for the item being edited. Notes only
+// have a title, content, priority, tags and project.
+func (m *NotesApp) formInputs() []int {
+	if m.creatingTask {
+		indexes := []int{0, contentInput, priorityInput, tagsInput}
+		for i := dueInput; i <= contextInput; i++ {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	}
+	return []int{0, contentInput, priorityInput, tagsInput, projectInput}
+}
+
+// cycleProject narrows both lists to the next project, wrapping back to
+// every item after the last one
+func (m *NotesApp) cycleProject() tea.Cmd {
+	if len(m.projects) == 0 {
+		m.status = "No projects yet; name one in the form or run `notes project add <name>`"
+		return nil
+	}
+
+	next := 0
+	for i, p := range m.projects {
+		if p.ID == m.activeProject {
+			next = i + 1
+			break
+		}
+	}
+	if next >= len(m.projects) {
+		m.activeProject = ""
+	} else {
+		m.activeProject = m.projects[next].ID
+	}
+	return tea.Batch(m.loadNotes(), m.loadTasks())
+}
+
+// activeProjectName is the name of the project being shown, if any
+func (m *NotesApp) activeProjectName() string {
+	return models.ProjectName(m.projects, m.activeProject)
+}
+
+// resolveProject finds the project named in the form, creating it if it
+// is new. A blank name means no project.
+func (m *NotesApp) resolveProject(name string) (models.ProjectID, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", nil
+	}
+	if p := models.FindProject(m.projects, name); p != nil {
+		return p.ID, nil
+	}
+
+	p := models.NewProject(name)
+	if err := m.storage.SaveProject(m.ctx, p); err != nil {
+		return "", fmt.Errorf("couldn't create project %q: %w", name, err)
+	}
+	m.projects = append(m.projects, p)
+	m.setProjectSuggestions()
+	return p.ID, nil
+}
+
+// setProjectSuggestions offers the known project names in the form
+func (m *NotesApp) setProjectSuggestions() {
+	names := make([]string, len(m.projects))
+	for i, p := range m.projects {
+		names[i] = p.Name
+	}
+	m.inputs[projectInput].SetSuggestions(names)
+}
+
+type projectsLoadedMsg struct {
+	projects []*models.Project
+	err      error
+}
+
+// loadProjects loads the projects from storage
+func (m *NotesApp) loadProjects() tea.Cmd {
+	return func() tea.Msg {
+		projects, err := m.storage.GetProjects(m.ctx)
+		return projectsLoadedMsg{projects: projects, err: err}
+	}
+}
+
+func (m *NotesApp) handleProjectsLoaded(msg projectsLoadedMsg) {
+	if msg.err != nil {
+		m.fail("Couldn't load projects: %v", msg.err)
+		return
+	}
+	m.projects = msg.projects
+	m.setProjectSuggestions()
+}
+
+// formatProject is the project line of the detail views
+func (m *NotesApp) formatProject(id models.ProjectID) string {
+	name := models.ProjectName(m.projects, id)
+	if name == "" {
+		return ""
+	}
+	return "\n\nProject: " + name
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// prompt is a one-line input shown above the help text. The trimmed value
+// is handed to onSubmit when the user presses enter.
+type prompt struct {
+	label    string
+	input    textinput.Model
+	onSubmit func(value string) tea.Cmd
+}
+
+func newPrompt(label, placeholder string, onSubmit func(value string) tea.Cmd) *prompt {
+	t := textinput.New()
+	t.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("170"))
+	t.Placeholder = placeholder
+	t.CharLimit = 500
+	t.Focus()
+
+	return &prompt{
+		label:    label,
+		input:    t,
+		onSubmit: onSubmit,
+	}
+}
+
+func (p *prompt) View() string {
+	return lipgloss.NewStyle().Bold(true).Render(p.label) + " " + p.input.View()
+}
+
+// updatePrompt routes key presses to the open prompt.
+func (m *NotesApp) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.prompt = nil
+		return m, nil
+	case "enter":
+		p := m.prompt
+		m.prompt = nil
+		return m, p.onSubmit(strings.TrimSpace(p.input.Value()))
+	}
+
+	var cmd tea.Cmd
+	m.prompt.input, cmd = m.prompt.input.Update(msg)
+	return m, cmd
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// openQuickAdd asks for a task on one line, with its due date, tags,
+// priority and reminder written inline
+func (m *NotesApp) openQuickAdd() {
+	m.prompt = newPrompt("Quick add:", "pay rent tomorrow 9am #finance !high remind 1d", func(value string) tea.Cmd {
+		if value == "" {
+			return nil
+		}
+		now := time.Now()
+		q, err := models.ParseQuickAdd(value, now)
+		if err != nil {
+			m.fail("%v", err)
+			return nil
+		}
+
+		// Undated tasks fall due tomorrow, as in the form
+		due := q.Due
+		switch {
+		case due.IsZero():
+			due = now.Add(24 * time.Hour)
+		case !q.Timed:
+			due = m.dueTime.On(due)
+		}
+		task := models.NewTask(q.Title, "", due)
+		remind := q.Remind
+		if remind == 0 {
+			remind = time.Hour
+		}
+		task.SetReminderPeriod(remind)
+		if q.Priority != 0 {
+			task.SetPriority(q.Priority)
+		}
+		task.Tags = q.Tags
+		task.ProjectID = m.activeProject
+		task.Context = m.activeContext
+
+		m.status = fmt.Sprintf("Added %q, due %s", task.Title, due.Format(dueLayout))
+		m.focusTask = task.ID
+		m.activeView = "tasks"
+		return tea.Sequence(m.saveTask(task), m.loadTasks())
+	})
+}
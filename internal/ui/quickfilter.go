@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+var chipStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("230")).
+	Background(lipgloss.Color("62")).
+	Padding(0, 1)
+
+// quickFilters narrow both lists further, on top of the smart list,
+// project and scope. Each one is toggled with a single key.
+type quickFilters struct {
+	hideDone bool
+	overdue  bool
+	// tag keeps only items carrying it, when set
+	tag string
+}
+
+func (q quickFilters) matchTask(t *models.Task) bool {
+	if q.hideDone && !t.IsOpen() {
+		return false
+	}
+	if q.overdue && !t.IsOverDue() {
+		return false
+	}
+	return q.tag == "" || hasTag(t.Tags, q.tag)
+}
+
+func (q quickFilters) matchNote(n *models.Note, now time.Time) bool {
+	if q.hideDone && n.IsCompleted {
+		return false
+	}
+	if q.overdue && (n.IsCompleted || n.DueDate.IsZero() || !n.DueDate.Before(now)) {
+		return false
+	}
+	return q.tag == "" || hasTag(n.Tags, q.tag)
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleHideDone hides or shows completed items
+func (m *NotesApp) toggleHideDone() tea.Cmd {
+	m.quick.hideDone = !m.quick.hideDone
+	return tea.Batch(m.loadNotes(), m.loadTasks())
+}
+
+// toggleOverdue shows only overdue items, or everything again
+func (m *NotesApp) toggleOverdue() tea.Cmd {
+	m.quick.overdue = !m.quick.overdue
+	return tea.Batch(m.loadNotes(), m.loadTasks())
+}
+
+// toggleQuickTag clears the tag filter, or asks for the tag to keep,
+// suggesting the first tag of the selected item
+func (m *NotesApp) toggleQuickTag() tea.Cmd {
+	if m.quick.tag != "" {
+		m.quick.tag = ""
+		return tea.Batch(m.loadNotes(), m.loadTasks())
+	}
+
+	var tags []string
+	if m.activeView == "tasks" && m.selectedTask != nil {
+		tags = m.selectedTask.Tags
+	} else if m.activeView == "notes" && m.selectedNote != nil {
+		tags = m.selectedNote.Tags
+	}
+	m.prompt = newPrompt("Only tag:", "work", func(value string) tea.Cmd {
+		tag := strings.TrimPrefix(strings.TrimSpace(value), "#")
+		if tag == "" {
+			return nil
+		}
+		m.quick.tag = tag
+		return tea.Batch(m.loadNotes(), m.loadTasks())
+	})
+	if len(tags) > 0 {
+		m.prompt.input.SetValue(tags[0])
+		m.prompt.input.CursorEnd()
+	}
+	return nil
+}
+
+// chipsView shows the quick filters in effect, with the key that turns
+// each one off
+func (m *NotesApp) chipsView() string {
+	var chips []string
+	if m.quick.hideDone {
+		chips = append(chips, chipStyle.Render("hide done ✕v"))
+	}
+	if m.quick.overdue {
+		chips = append(chips, chipStyle.Render("overdue ✕!"))
+	}
+	if m.quick.tag != "" {
+		chips = append(chips, chipStyle.Render("#"+m.quick.tag+" ✕="))
+	}
+	return strings.Join(chips, " ")
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// reader shows one note full screen, scrolled with a viewport.
+type reader struct {
+	note     *models.Note
+	viewport viewport.Model
+}
+
+// openReader expands the selected note to fill the screen
+func (m *NotesApp) openReader() tea.Cmd {
+	note := m.selectedNote
+	return m.withContent(func() tea.Cmd {
+		m.reader = &reader{note: note}
+		m.sizeReader()
+		m.activeView = "reader"
+		return nil
+	}, note)
+}
+
+// sizeReader fits the viewport to the window and rewraps the note
+func (m *NotesApp) sizeReader() {
+	// The border and padding take six columns and four rows; the header
+	// and help lines take five more rows
+	width := max(m.width-8, 20)
+	height := max(m.height-10, 3)
+	r := m.reader
+	offset := r.viewport.YOffset
+	r.viewport = viewport.New(width, height)
+	r.viewport.SetContent(m.readerContent(width))
+	r.viewport.SetYOffset(offset)
+}
+
+func (m *NotesApp) readerContent(width int) string {
+	note := m.reader.note
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(note.Title) + "\n")
+	b.WriteString(helpStyle(noteStats(note, time.Now())) + "\n")
+	if len(note.Tags) > 0 {
+		b.WriteString(helpStyle("#"+strings.Join(note.Tags, " #")) + "\n")
+	}
+	b.WriteString("\n" + m.markdown.render(note.Content, width))
+	b.WriteString(m.formatProject(note.ProjectID) + m.formatLinkedTasks(note) + m.formatLinks(note))
+	return lipgloss.NewStyle().Width(width).Render(b.String())
+}
+
+// updateReader handles keys while a note is open full screen; the
+// viewport scrolls with the arrow keys, j/k, pgup/pgdown and space
+func (m *NotesApp) updateReader(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+	case "esc", "enter":
+		m.reader = nil
+		m.activeView = "notes"
+		return m, nil
+	case "g", "home":
+		m.reader.viewport.GotoTop()
+		return m, nil
+	case "G", "end":
+		m.reader.viewport.GotoBottom()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.reader.viewport, cmd = m.reader.viewport.Update(msg)
+	return m, cmd
+}
+
+// readerView draws the open note
+func (m *NotesApp) readerView() string {
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(m.reader.viewport.View())
+}
+
+// readerPosition is how far the open note is scrolled
+func (m *NotesApp) readerPosition() string {
+	v := m.reader.viewport
+	if v.TotalLineCount() <= v.Height {
+		return "all"
+	}
+	return fmt.Sprintf("%.f%%", v.ScrollPercent()*100)
+}
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/reminder"
+)
+
+type reminderEventMsg reminder.Event
+
+// SetReminderEvents shows reminders delivered by the reminder session,
+// whichever process it runs in, in the banner.
+func (m *NotesApp) SetReminderEvents(events <-chan reminder.Event) {
+	m.reminderEvents = events
+}
+
+func (m *NotesApp) waitForReminder() tea.Cmd {
+	if m.reminderEvents == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		select {
+		case e, ok := <-m.reminderEvents:
+			if !ok {
+				return nil
+			}
+			return reminderEventMsg(e)
+		case <-m.ctx.Done():
+			return nil
+		}
+	}
+}
+
+// handleReminder shows a delivered reminder and waits for the next one
+func (m *NotesApp) handleReminder(e reminderEventMsg) tea.Cmd {
+	m.showReminder(reminder.Event(e))
+	return m.waitForReminder()
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+var (
+	diffOldStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("160")).Render
+	diffNewStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("34")).Render
+)
+
+type changeItem struct {
+	change *models.Change
+	isNew  bool
+	diffs  []models.FieldDiff
+}
+
+func (i changeItem) Title() string {
+	kind := "note"
+	if i.change.Task != nil {
+		kind = "task"
+	}
+	return fmt.Sprintf("[%s] %s", kind, i.change.Title())
+}
+
+func (i changeItem) Description() string {
+	return fmt.Sprintf("From %s, %s", i.change.Source, i.change.ReceivedAt.Format("Jan 2, 2006 15:04"))
+}
+
+func (i changeItem) FilterValue() string { return i.change.Title() }
+
+func newReviewList() list.Model {
+	reviewList := list.New([]list.Item{}, list.NewDefaultDelegate(), 0, 0)
+	reviewList.Title = "Review Queue"
+	reviewList.SetShowHelp(false)
+	return reviewList
+}
+
+// updateReview handles keys while the review queue is open.
+func (m *NotesApp) updateReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+
+	case "esc", "R":
+		m.activeView = "notes"
+		return m, nil
+
+	case "i":
+		if m.narrow() {
+			// Switch between the list and the change's diff
+			m.showDetail = !m.showDetail
+		}
+		return m, nil
+
+	case "a", "x":
+		i, ok := m.reviewList.SelectedItem().(changeItem)
+		if !ok {
+			return m, nil
+		}
+		if msg.String() == "a" {
+			return m, tea.Sequence(
+				m.acceptChange(i.change.ID),
+				tea.Batch(m.loadChanges(), m.loadNotes(), m.loadTasks()),
+			)
+		}
+		return m, tea.Sequence(
+			m.rejectChange(i.change.ID),
+			m.loadChanges(),
+		)
+	}
+
+	var cmd tea.Cmd
+	m.reviewList, cmd = m.reviewList.Update(msg)
+	return m, cmd
+}
+
+// reviewView shows pending changes on the left and the selected change's
+// field diff on the right.
+func (m *NotesApp) reviewView() string {
+	detailView := "No changes waiting for review"
+	if i, ok := m.reviewList.SelectedItem().(changeItem); ok {
+		kind := "note"
+		if i.change.Task != nil {
+			kind = "task"
+		}
+		if i.isNew {
+			detailView = fmt.Sprintf("New %s from %s\n\n", kind, i.change.Source)
+		} else {
+			detailView = fmt.Sprintf("Changes to existing %s from %s\n\n", kind, i.change.Source)
+		}
+		for _, d := range i.diffs {
+			detailView += d.Field + "\n"
+			if d.Old != "" {
+				detailView += diffOldStyle("  - "+d.Old) + "\n"
+			}
+			if d.New != "" {
+				detailView += diffNewStyle("  + "+d.New) + "\n"
+			}
+		}
+	}
+
+	return m.panes(m.reviewList.View(), detailView)
+}
+
+type changesLoadedMsg struct {
+	items []list.Item
+	err   error
+}
+
+// loadChanges loads the review queue from storage along with each change's
+// diff against the local copy
+func (m *NotesApp) loadChanges() tea.Cmd {
+	return func() tea.Msg {
+		changes, err := m.storage.GetPendingChanges(m.ctx)
+		if err != nil {
+			return changesLoadedMsg{err: err}
+		}
+
+		items := make([]list.Item, len(changes))
+		for i, change := range changes {
+			item := changeItem{change: change}
+			// Only a missing item is new; accepting a change shown as new
+			// by mistake would overwrite the local copy
+			if change.Note != nil {
+				local, err := m.storage.GetNote(m.ctx, change.Note.ID)
+				if err != nil && !errors.Is(err, storage.ErrNotFound) {
+					return changesLoadedMsg{err: fmt.Errorf("failed to look up note %s: %w", change.Note.ID, err)}
+				}
+				item.isNew = local == nil
+				item.diffs = models.DiffNotes(local, change.Note)
+			} else if change.Task != nil {
+				local, err := m.storage.GetTask(m.ctx, change.Task.ID)
+				if err != nil && !errors.Is(err, storage.ErrNotFound) {
+					return changesLoadedMsg{err: fmt.Errorf("failed to look up task %s: %w", change.Task.ID, err)}
+				}
+				item.isNew = local == nil
+				item.diffs = models.DiffTasks(local, change.Task)
+			}
+			items[i] = item
+		}
+		return changesLoadedMsg{items: items}
+	}
+}
+
+func (m *NotesApp) handleChangesLoaded(msg changesLoadedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.fail("Couldn't load changes to review: %v", msg.err)
+		return nil
+	}
+	return m.reviewList.SetItems(msg.items)
+}
+
+// acceptChange applies a staged change to local data
+func (m *NotesApp) acceptChange(id models.ChangeID) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.storage.AcceptChange(m.ctx, id); err != nil {
+			return errMsg{action: "accept change", err: err}
+		}
+		return nil
+	}
+}
+
+// rejectChange drops a staged change
+func (m *NotesApp) rejectChange(id models.ChangeID) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.storage.RejectChange(m.ctx, id); err != nil {
+			return errMsg{action: "reject change", err: err}
+		}
+		return nil
+	}
+}
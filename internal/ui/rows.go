@@ -0,0 +1,66 @@
+package ui
+
+import (
+	"io"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// Row colors for task states.
+var (
+	overdueColor  = lipgloss.Color("203")
+	dueTodayColor = lipgloss.Color("220")
+	doneColor     = lipgloss.Color("241")
+)
+
+// taskRowDelegate draws task rows colored by state: red when overdue,
+// yellow when due today and dim once finished.
+type taskRowDelegate struct {
+	list.DefaultDelegate
+}
+
+func newTaskRowDelegate(d list.DefaultDelegate) taskRowDelegate {
+	return taskRowDelegate{DefaultDelegate: d}
+}
+
+func (d taskRowDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	ti, ok := item.(taskItem)
+	if !ok {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+	color, ok := rowColor(ti.task, time.Now())
+	if !ok {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+
+	// The delegate is a copy, so restyling it affects this row alone
+	s := &d.DefaultDelegate.Styles
+	s.NormalTitle = s.NormalTitle.Foreground(color)
+	s.SelectedTitle = s.SelectedTitle.Foreground(color)
+	if color == doneColor {
+		s.NormalDesc = s.NormalDesc.Foreground(color)
+		s.SelectedDesc = s.SelectedDesc.Foreground(color)
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
+
+// rowColor is the color of the task's row, if its state has one
+func rowColor(task *models.Task, now time.Time) (lipgloss.Color, bool) {
+	switch {
+	case !task.IsOpen():
+		return doneColor, true
+	case task.DueDate.IsZero():
+		return "", false
+	case task.DueDate.Before(now):
+		return overdueColor, true
+	case sameDay(task.DueDate, now):
+		return dueTodayColor, true
+	}
+	return "", false
+}
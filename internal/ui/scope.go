@@ -0,0 +1,17 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// setScope limits the task list to one of the built-in views; choosing
+// the view already shown goes back to all tasks
+func (m *NotesApp) setScope(scope models.Scope) tea.Cmd {
+	if scope == m.scope {
+		scope = models.ScopeAll
+	}
+	m.scope = scope
+	return m.loadTasks()
+}
@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// searchResultLimit caps how many matches are listed.
+const searchResultLimit = 20
+
+// snippetWidth is how much of the body is shown around a match.
+const snippetWidth = 60
+
+var matchStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("212")).Bold(true)
+
+// search is the state of the search panel, which looks through the
+// titles, tags and bodies of notes and tasks together as the query is
+// typed.
+type search struct {
+	input   textinput.Model
+	results []storage.SearchResult
+	cursor  int
+	// from is the view to return to
+	from string
+}
+
+type searchResultsMsg struct {
+	query   string
+	results []storage.SearchResult
+	err     error
+}
+
+// openSearch shows the search panel with an empty query
+func (m *NotesApp) openSearch() {
+	t := textinput.New()
+	t.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("170"))
+	t.Placeholder = "words to find in notes and tasks"
+	t.CharLimit = 200
+	t.Focus()
+	m.search = &search{input: t, from: m.activeView}
+	m.activeView = "search"
+}
+
+// runSearch looks up query in the background
+func (m *NotesApp) runSearch(query string) tea.Cmd {
+	return func() tea.Msg {
+		results, err := m.storage.SearchAll(m.ctx, query)
+		return searchResultsMsg{query: query, results: results, err: err}
+	}
+}
+
+func (m *NotesApp) handleSearchResults(msg searchResultsMsg) {
+	s := m.search
+	// Drop results for a query that has since been typed over
+	if s == nil || s.input.Value() != msg.query {
+		return
+	}
+	if msg.err != nil {
+		m.fail("Couldn't search: %v", msg.err)
+		return
+	}
+	if len(msg.results) > searchResultLimit {
+		msg.results = msg.results[:searchResultLimit]
+	}
+	s.results = msg.results
+	s.cursor = 0
+}
+
+// updateSearch handles keys while the search panel is open
+func (m *NotesApp) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	s := m.search
+	switch msg.String() {
+	case "ctrl+c":
+		return m, m.quit()
+	case "esc":
+		m.search = nil
+		m.activeView = s.from
+		return m, nil
+	case "up", "ctrl+p":
+		if s.cursor > 0 {
+			s.cursor--
+		}
+		return m, nil
+	case "down", "ctrl+n":
+		if s.cursor < len(s.results)-1 {
+			s.cursor++
+		}
+		return m, nil
+	case "enter":
+		if len(s.results) == 0 {
+			return m, nil
+		}
+		r := s.results[s.cursor]
+		m.search = nil
+		if r.Task != nil {
+			m.focusTask = r.Task.ID
+			m.activeView = "tasks"
+			return m, m.loadTasks()
+		}
+		m.focusNote = r.Note.ID
+		m.activeView = "notes"
+		return m, m.loadNotes()
+	}
+
+	before := s.input.Value()
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	query := s.input.Value()
+	if query == before {
+		return m, cmd
+	}
+	if strings.TrimSpace(query) == "" {
+		s.results = nil
+		s.cursor = 0
+		return m, cmd
+	}
+	return m, tea.Batch(cmd, m.runSearch(query))
+}
+
+// searchView shows the query above the matches, with the matched words
+// highlighted and a line of the body where they were found
+func (m *NotesApp) searchView() string {
+	s := m.search
+	terms := strings.Fields(strings.ToLower(s.input.Value()))
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Search") + " " + s.input.View() + "\n\n")
+
+	switch {
+	case len(terms) == 0:
+		b.WriteString(helpStyle("Type to search titles, tags and content of notes and tasks"))
+	case len(s.results) == 0:
+		b.WriteString(helpStyle("No matches"))
+	}
+
+	for i, r := range s.results {
+		kind, body, tags := "note", "", []string(nil)
+		if r.Task != nil {
+			kind, body, tags = "task", r.Task.Description, r.Task.Tags
+		} else {
+			body, tags = r.Note.Content, r.Note.Tags
+		}
+
+		line := fmt.Sprintf("%s  %s", kind, highlightTerms(truncate(r.Title(), 50), terms))
+		if len(tags) > 0 {
+			line += "  " + highlightTerms("#"+strings.Join(tags, " #"), terms)
+		}
+		if i == s.cursor {
+			b.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+		} else {
+			b.WriteString(itemStyle.Render(line) + "\n")
+		}
+		if snippet := matchSnippet(body, terms); snippet != "" {
+			b.WriteString(itemStyle.Render("      "+helpStyle("…")+highlightTerms(snippet, terms)+helpStyle("…")) + "\n")
+		}
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(b.String())
+}
+
+// matchSnippet is the part of body around the first match of any term,
+// on one line, or "" if none of them is in it
+func matchSnippet(body string, terms []string) string {
+	lower := strings.ToLower(body)
+	at := -1
+	for _, term := range terms {
+		if i := strings.Index(lower, term); i >= 0 && (at < 0 || i < at) {
+			at = i
+		}
+	}
+	if at < 0 {
+		return ""
+	}
+
+	start := strings.LastIndex(body[:at], "\n") + 1
+	end := len(body)
+	if i := strings.Index(body[at:], "\n"); i >= 0 {
+		end = at + i
+	}
+	line := []rune(body[start:end])
+	// Keep the match in view on long lines
+	offset := len([]rune(body[start:at]))
+	from := max(offset-snippetWidth/3, 0)
+	to := min(from+snippetWidth, len(line))
+	return strings.TrimSpace(string(line[from:to]))
+}
+
+// highlightTerms marks every case-insensitive occurrence of the terms in
+// text
+func highlightTerms(text string, terms []string) string {
+	lower := strings.ToLower(text)
+	// Lowercasing can change byte lengths outside ASCII; highlight
+	// nothing rather than cut a rune in half
+	if len(lower) != len(text) {
+		return text
+	}
+	marked := make([]bool, len(text))
+	for _, term := range terms {
+		for from := 0; term != ""; {
+			i := strings.Index(lower[from:], term)
+			if i < 0 {
+				break
+			}
+			for j := from + i; j < from+i+len(term); j++ {
+				marked[j] = true
+			}
+			from += i + len(term)
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		j := i
+		for j < len(text) && marked[j] == marked[i] {
+			j++
+		}
+		if marked[i] {
+			b.WriteString(matchStyle.Render(text[i:j]))
+		} else {
+			b.WriteString(text[i:j])
+		}
+		i = j
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// cycleSmartList switches to the next saved smart list, wrapping back to
+// the unfiltered lists after the last one
+func (m *NotesApp) cycleSmartList() tea.Cmd {
+	if len(m.smartLists) == 0 {
+		m.status = "No smart lists yet; add one with `notes smartlist add <name> <query>`"
+		return nil
+	}
+
+	m.activeList++
+	if m.activeList >= len(m.smartLists) {
+		m.activeList = -1
+		m.activeFilter = models.Filter{}
+		return tea.Batch(m.loadNotes(), m.loadTasks())
+	}
+
+	filter, err := m.smartLists[m.activeList].Filter()
+	if err != nil {
+		m.fail("Smart list %q is invalid: %v", m.smartLists[m.activeList].Name, err)
+		m.activeList = -1
+		m.activeFilter = models.Filter{}
+	} else {
+		m.activeFilter = filter
+	}
+	return tea.Batch(m.loadNotes(), m.loadTasks())
+}
+
+// activeListName is the name of the smart list being shown, if any
+func (m *NotesApp) activeListName() string {
+	if m.activeList < 0 || m.activeList >= len(m.smartLists) {
+		if !m.activeFilter.IsEmpty() && m.filterQuery != "" {
+			return m.filterQuery
+		}
+		return ""
+	}
+	return m.smartLists[m.activeList].Name
+}
+
+type smartListsLoadedMsg struct {
+	lists []*models.SmartList
+	err   error
+}
+
+// loadSmartLists loads the saved smart lists from storage
+func (m *NotesApp) loadSmartLists() tea.Cmd {
+	return func() tea.Msg {
+		lists, err := m.storage.GetSmartLists(m.ctx)
+		return smartListsLoadedMsg{lists: lists, err: err}
+	}
+}
+
+func (m *NotesApp) handleSmartListsLoaded(msg smartListsLoadedMsg) {
+	if msg.err != nil {
+		m.fail("Couldn't load smart lists: %v", msg.err)
+		return
+	}
+	m.smartLists = msg.lists
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// snoozePresets are offered by number in the snooze prompt.
+var snoozePresets = []struct {
+	label string
+	until func(now time.Time) time.Time
+}{
+	{"10m", func(now time.Time) time.Time { return now.Add(10 * time.Minute) }},
+	{"1h", func(now time.Time) time.Time { return now.Add(time.Hour) }},
+	{"tomorrow", func(now time.Time) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+1, 9, 0, 0, 0, now.Location())
+	}},
+}
+
+// openSnooze asks how long to snooze the task's reminders. snoozed, if
+// set, runs once the snooze is chosen.
+func (m *NotesApp) openSnooze(task *models.Task, snoozed func()) {
+
+	choices := make([]string, len(snoozePresets))
+	for i, p := range snoozePresets {
+		choices[i] = fmt.Sprintf("%d: %s", i+1, p.label)
+	}
+	placeholder := strings.Join(choices, " • ") + " • or a duration, off to clear"
+
+	m.prompt = newPrompt("Snooze:", placeholder, func(value string) tea.Cmd {
+		until, err := parseSnooze(value, time.Now())
+		if err != nil {
+			m.fail("%v", err)
+			return nil
+		}
+		if snoozed != nil {
+			snoozed()
+		}
+		edited := task.Clone()
+		edited.SnoozeUntil(until)
+		if until.IsZero() {
+			m.status = fmt.Sprintf("%q is no longer snoozed", task.Title)
+		} else {
+			m.status = fmt.Sprintf("%q snoozed until %s", task.Title, until.Format("Mon Jan 2 15:04"))
+		}
+		return tea.Sequence(m.saveTask(edited), m.loadTasks())
+	})
+}
+
+// parseSnooze accepts a preset number or name, a duration such as 2h or
+// 1d, or "off"
+func parseSnooze(value string, now time.Time) (time.Time, error) {
+	value = strings.ToLower(value)
+	if value == "off" || value == "0" {
+		return time.Time{}, nil
+	}
+	for i, p := range snoozePresets {
+		if value == fmt.Sprint(i+1) || value == p.label {
+			return p.until(now), nil
+		}
+	}
+	d, err := models.ParseQuickDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("can't snooze for %q", value)
+	}
+	return now.Add(d), nil
+}
+
+// snoozeLabel describes an active snooze for the task list
+func snoozeLabel(task *models.Task, now time.Time) string {
+	if !task.IsSnoozed(now) {
+		return ""
+	}
+	if task.SnoozedUntil.YearDay() == now.YearDay() && task.SnoozedUntil.Year() == now.Year() {
+		return "snoozed until " + task.SnoozedUntil.Format("15:04")
+	}
+	return "snoozed until " + task.SnoozedUntil.Format("Mon 15:04")
+}
+
+// formatReminder shows when the task's reminder fires; a cron schedule is
+// shown with its next firing
+func formatReminder(task *models.Task, now time.Time) string {
+	if task.Cron == "" {
+		return formatTaskTime(task, task.ReminderAt, "Jan 2, 2006 15:04")
+	}
+	if _, err := models.ParseCron(task.Cron); err != nil {
+		return task.Cron + " (invalid)"
+	}
+	next := task.NextCronReminder(now)
+	if next.IsZero() {
+		return task.Cron
+	}
+	return fmt.Sprintf("%s (next %s)", task.Cron, formatTaskTime(task, next, "Mon Jan 2 15:04"))
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/config"
+)
+
+// cycleSort switches the shown list to its next order and keeps the
+// choice for the next session
+func (m *NotesApp) cycleSort() tea.Cmd {
+	if m.activeView == "tasks" {
+		m.taskSort = m.taskSort.Next()
+		m.status = fmt.Sprintf("Tasks sorted by %s", m.taskSort)
+		return tea.Batch(m.loadTasks(), m.saveSort("tasks.sort", string(m.taskSort)))
+	}
+	m.noteSort = m.noteSort.Next()
+	m.status = fmt.Sprintf("Notes sorted by %s", m.noteSort)
+	return tea.Batch(m.loadNotes(), m.saveSort("notes.sort", string(m.noteSort)))
+}
+
+// sortSaves coalesces the background writes of the sort orders, so the
+// order chosen last is the one kept however the writes interleave
+type sortSaves struct {
+	mu sync.Mutex
+	// latest counts the saves asked for, by config key
+	latest map[string]int
+}
+
+// saveSort writes the sort order to the device-local overlay in the
+// background; how lists are sorted is a preference of this device
+func (m *NotesApp) saveSort(key, value string) tea.Cmd {
+	saves := &m.sortSaves
+	saves.mu.Lock()
+	if saves.latest == nil {
+		saves.latest = make(map[string]int)
+	}
+	saves.latest[key]++
+	seq := saves.latest[key]
+	saves.mu.Unlock()
+
+	return func() tea.Msg {
+		saves.mu.Lock()
+		defer saves.mu.Unlock()
+		if saves.latest[key] != seq {
+			// A later press has an order of its own to save
+			return nil
+		}
+		path, err := config.LocalPath()
+		if err == nil {
+			err = config.SetValue(path, key, value)
+		}
+		if err != nil {
+			return errMsg{action: "save the sort order", err: err}
+		}
+		return nil
+	}
+}
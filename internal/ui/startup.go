@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// StartViews are the views the TUI can be opened in.
+var StartViews = []string{"notes", "tasks", "review", "triage", "forecast", "agenda", "board", "matrix", "archive", "trash"}
+
+// StartOptions picks where the TUI opens, so launchers, scripts and
+// notifications can jump straight to what they are about.
+type StartOptions struct {
+	// View is one of StartViews; empty opens the notes.
+	View string
+	// Filter is a smart-list query applied to both lists.
+	Filter string
+	// Task selects a task by ID and implies the tasks view.
+	Task models.TaskID
+}
+
+// Open applies the start options before the program runs.
+func (m *NotesApp) Open(opts StartOptions) error {
+	m.startTourIfNew()
+	m.reportDeadLetters()
+
+	if opts.Filter != "" {
+		filter, err := models.ParseFilter(opts.Filter)
+		if err != nil {
+			return fmt.Errorf("invalid filter: %w", err)
+		}
+		m.activeFilter = filter
+		m.filterQuery = opts.Filter
+	}
+
+	if opts.Task != "" {
+		if _, err := m.storage.GetTask(m.ctx, opts.Task); err != nil {
+			return err
+		}
+		if opts.View != "" && opts.View != "tasks" {
+			return fmt.Errorf("--task opens the tasks view, not %q", opts.View)
+		}
+		opts.View = "tasks"
+		m.focusTask = opts.Task
+	}
+
+	switch opts.View {
+	case "", "notes", "tasks", "review":
+		if opts.View != "" {
+			m.activeView = opts.View
+		}
+	case "triage":
+		m.startCmd = m.openTriage()
+	case "forecast":
+		m.startCmd = m.openForecast()
+	case "agenda":
+		m.startCmd = m.openAgenda("")
+	case "board":
+		m.startCmd = m.openBoard("")
+	case "matrix":
+		m.startCmd = m.openMatrix("")
+	case "archive":
+		m.startCmd = m.openArchive("")
+	case "trash":
+		m.startCmd = m.openTrash()
+	default:
+		return fmt.Errorf("unknown view %q (want %s)", opts.View, strings.Join(StartViews, ", "))
+	}
+	return nil
+}
+
+// selectTask moves the task list cursor to the task with the given ID and
+// reports whether it is listed
+func (m *NotesApp) selectTask(id models.TaskID) bool {
+	for i, item := range m.tasksList.Items() {
+		if ti, ok := item.(taskItem); ok && ti.task.ID == id {
+			m.tasksList.Select(i)
+			m.selectedTask = ti.task
+			return true
+		}
+	}
+	return false
+}
+
+// selectNote moves the note list cursor to the note with the given ID and
+// reports whether it is listed
+func (m *NotesApp) selectNote(id models.NoteID) bool {
+	for i, item := range m.notesList.Items() {
+		if ni, ok := item.(noteItem); ok && ni.note.ID == id {
+			m.notesList.Select(i)
+			m.selectedNote = ni.note
+			return true
+		}
+	}
+	return false
+}
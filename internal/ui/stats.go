@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// noteStats renders the word count, reading time and last edit for the
+// note detail header.
+func noteStats(note *models.Note, now time.Time) string {
+	words := note.WordCount()
+	unit := "words"
+	if words == 1 {
+		unit = "word"
+	}
+	return fmt.Sprintf("%d %s · %d min read · edited %s",
+		words, unit, int(note.ReadingTime().Minutes()), relativeTime(note.UpdatedAt, now))
+}
+
+// relativeTime describes how long ago t was, falling back to a date once it
+// is more than a week old.
+func relativeTime(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 7*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	default:
+		return t.Format("Jan 2, 2006")
+	}
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// setTaskStatus moves the selected task to a built-in status
+func (m *NotesApp) setTaskStatus(to models.TaskStatus) tea.Cmd {
+	return m.setWorkflowStatus(models.BuiltinStatus(to))
+}
+
+// setWorkflowStatus moves the selected task to a workflow status. Starting
+// or completing a task waits for its dependencies, and completing the last
+// task split off from a parent completes the parent too
+func (m *NotesApp) setWorkflowStatus(to models.StatusDef) tea.Cmd {
+	task := m.selectedTask
+	if to.Base == models.TaskStatusInProgress || to.Base == models.TaskStatusCompleted {
+		if blocked := m.blockedStatus(task); blocked != "" {
+			m.status = blocked
+			return nil
+		}
+	}
+	edited := task.Clone()
+	if err := edited.SetStatus(to); err != nil {
+		m.fail("%v", err)
+		return nil
+	}
+	m.status = fmt.Sprintf("%q is now %s", task.Title, to.Name)
+	label := fmt.Sprintf("making %q %s", task.Title, to.Name)
+
+	if parent := edited.ParentToComplete(m.taskIndex); parent != nil {
+		before := parent.Clone()
+		parent = parent.Clone()
+		if err := parent.Complete(); err == nil {
+			m.status += fmt.Sprintf(", completing %q", parent.Title)
+			m.rememberEdit(label, itemSet{tasks: []*models.Task{task.Clone(), before}}, itemSet{tasks: []*models.Task{edited.Clone(), parent.Clone()}})
+			return tea.Sequence(m.saveTasksBatch([]*models.Task{edited, parent}), m.loadTasks())
+		}
+	}
+	m.rememberEdit(label, itemSet{tasks: []*models.Task{task.Clone()}}, itemSet{tasks: []*models.Task{edited.Clone()}})
+	return tea.Sequence(m.saveTask(edited), m.loadTasks())
+}
+
+// cycleStatus steps the selected task through the workflow, by default
+// pending, in progress and completed
+func (m *NotesApp) cycleStatus() tea.Cmd {
+	return m.setWorkflowStatus(models.DefaultWorkflow.Next(m.selectedTask))
+}
+
+// statusGlyph is the task's workflow glyph in its configured color
+func statusGlyph(def models.StatusDef) string {
+	if def.Color == "" {
+		return def.Glyph
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(def.Color)).Render(def.Glyph)
+}
+
+// toggleCancelled cancels the selected task, or reopens a cancelled one
+func (m *NotesApp) toggleCancelled() tea.Cmd {
+	if m.selectedTask.Status == models.TaskStatusCancelled {
+		return m.setTaskStatus(models.TaskStatusPending)
+	}
+	return m.setTaskStatus(models.TaskStatusCancelled)
+}
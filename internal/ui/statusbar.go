@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statusTimeout is how long a message stays in the status bar. Errors
+// stay until another message replaces them.
+const statusTimeout = 5 * time.Second
+
+var errorBarStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("231")).
+	Background(lipgloss.Color("160")).
+	Padding(0, 1)
+
+// statusExpiredMsg clears the status bar unless a newer message was shown
+// since the timer started.
+type statusExpiredMsg struct {
+	seq int
+}
+
+// fail shows an error in the status bar
+func (m *NotesApp) fail(format string, args ...interface{}) {
+	m.status = fmt.Sprintf(format, args...)
+	m.statusErr = true
+}
+
+// trackStatus runs after each update. A new message marks the bar as an
+// error only if it was set with fail, and anything else is cleared once
+// statusTimeout has passed.
+func (m *NotesApp) trackStatus(before string, errBefore bool) tea.Cmd {
+	if m.status == before {
+		m.statusErr = m.statusErr || errBefore
+		return nil
+	}
+	m.statusSeq++
+	if m.status == "" || m.statusErr {
+		return nil
+	}
+	seq := m.statusSeq
+	return tea.Tick(statusTimeout, func(time.Time) tea.Msg {
+		return statusExpiredMsg{seq: seq}
+	})
+}
+
+func (m *NotesApp) handleStatusExpired(msg statusExpiredMsg) {
+	if msg.seq == m.statusSeq {
+		m.status = ""
+	}
+}
+
+// statusBar draws the status message, errors on a red bar the width of
+// the window
+func (m *NotesApp) statusBar() string {
+	if m.statusErr {
+		return errorBarStyle.Width(m.width).Render("✗ " + m.status)
+	}
+	return statusStyle(m.status)
+}
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+var cursorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Render
+
+// checklist is the state of an expanded task whose subtasks are being
+// checked off. It edits its own copy of the task and saves after each change.
+type checklist struct {
+	task   *models.Task
+	cursor int
+}
+
+func (m *NotesApp) openChecklist() {
+	if m.selectedTask == nil {
+		return
+	}
+	m.checklist = &checklist{task: m.selectedTask.Clone()}
+}
+
+// updateChecklist handles keys while a task is expanded
+func (m *NotesApp) updateChecklist(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	c := m.checklist
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+	case "esc", "enter":
+		m.checklist = nil
+	case "up", "k":
+		if c.cursor > 0 {
+			c.cursor--
+		}
+	case "down", "j":
+		if c.cursor < len(c.task.Subtasks)-1 {
+			c.cursor++
+		}
+	case " ", "x":
+		if len(c.task.Subtasks) > 0 {
+			was := c.task.Status
+			c.task.ToggleSubtask(c.task.Subtasks[c.cursor].ID)
+			if c.task.Status != was {
+				m.status = fmt.Sprintf("%q is now %s", c.task.Title, c.task.Status)
+			}
+			return m, m.saveChecklist()
+		}
+	case "m":
+		c.task.SubtaskPolicy = c.task.NextSubtaskPolicy()
+		m.status = "Completion: " + subtaskPolicyLabel(c.task)
+		return m, m.saveChecklist()
+	case "a":
+		m.prompt = newPrompt("New subtask:", "", func(title string) tea.Cmd {
+			if strings.TrimSpace(title) == "" || m.checklist != c {
+				return nil
+			}
+			c.task.AddSubtask(strings.TrimSpace(title))
+			c.cursor = len(c.task.Subtasks) - 1
+			return m.saveChecklist()
+		})
+	case "d":
+		if len(c.task.Subtasks) > 0 {
+			c.task.RemoveSubtask(c.task.Subtasks[c.cursor].ID)
+			if c.cursor >= len(c.task.Subtasks) && c.cursor > 0 {
+				c.cursor--
+			}
+			return m, m.saveChecklist()
+		}
+	case "p":
+		if len(c.task.Subtasks) > 0 {
+			return m, m.promoteSubtask()
+		}
+	case "S":
+		return m, m.splitTask()
+	}
+	return m, nil
+}
+
+// promoteSubtask turns the item under the cursor into a task of its own
+// that the expanded task waits on
+func (m *NotesApp) promoteSubtask() tea.Cmd {
+	c := m.checklist
+	child, err := c.task.PromoteSubtask(c.task.Subtasks[c.cursor].ID)
+	if err != nil {
+		m.fail("%v", err)
+		return nil
+	}
+	if c.cursor >= len(c.task.Subtasks) && c.cursor > 0 {
+		c.cursor--
+	}
+	m.status = fmt.Sprintf("Promoted %q to a task", child.Title)
+	return tea.Sequence(m.saveTasksBatch([]*models.Task{child, c.task.Clone()}), m.loadTasks())
+}
+
+// splitTask promotes every unchecked subtask of the expanded task and
+// collapses it
+func (m *NotesApp) splitTask() tea.Cmd {
+	parent := m.checklist.task
+	children := parent.Split()
+	if len(children) == 0 {
+		m.status = "No open subtasks to split off"
+		return nil
+	}
+	m.checklist = nil
+	m.status = fmt.Sprintf("Split %q into %d task(s)", parent.Title, len(children))
+	return tea.Sequence(m.saveTasksBatch(append(children, parent)), m.loadTasks())
+}
+
+func (m *NotesApp) saveChecklist() tea.Cmd {
+	return tea.Sequence(m.saveTask(m.checklist.task.Clone()), m.loadTasks())
+}
+
+// subtaskPolicyLabel describes how the task's completion follows its
+// checklist
+func subtaskPolicyLabel(task *models.Task) string {
+	var label string
+	switch task.EffectiveSubtaskPolicy() {
+	case models.SubtaskPolicyAuto:
+		label = "completes with the last subtask"
+	case models.SubtaskPolicyBlock:
+		label = "waits for all subtasks"
+	default:
+		label = "manual"
+	}
+	if task.SubtaskPolicy == models.SubtaskPolicyDefault {
+		label += " (default)"
+	}
+	return label
+}
+
+// formatSubtasks renders a task's checklist, highlighting the cursor when
+// the task is expanded.
+func formatSubtasks(subtasks []models.Subtask, cursor int) string {
+	if len(subtasks) == 0 {
+		return "none"
+	}
+	var b strings.Builder
+	for i, s := range subtasks {
+		check := " "
+		if s.Done {
+			check = "x"
+		}
+		line := fmt.Sprintf("[%s] %s", check, s.Title)
+		if i == cursor {
+			line = cursorStyle("> " + line)
+		} else {
+			line = "  " + line
+		}
+		b.WriteString("\n" + line)
+	}
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// tagsInput is the form field listing the item's tags.
+const tagsInput = 8
+
+// newTagsInput sets up the tags field to complete the tag being typed
+// from the tags already in use.
+func newTagsInput(t textinput.Model) textinput.Model {
+	t.Placeholder = "Tags (e.g., work, urgent)"
+	t.CharLimit = 200
+	t.ShowSuggestions = true
+	t.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("right"))
+	return t
+}
+
+// loadTagNames fetches the tags in use for completing the tags field
+func (m *NotesApp) loadTagNames() {
+	counts, err := m.storage.ListAllTags(m.ctx)
+	if err != nil {
+		// Completion is a convenience; the field still takes any tag
+		return
+	}
+	m.tagNames = make([]string, len(counts))
+	for i, c := range counts {
+		m.tagNames[i] = c.Tag
+	}
+	m.setTagSuggestions()
+}
+
+// setTagSuggestions offers completions for the last tag in the field. The
+// input matches suggestions against its whole value, so each one repeats
+// the tags already typed.
+func (m *NotesApp) setTagSuggestions() {
+	value := m.inputs[tagsInput].Value()
+	head := value[:strings.LastIndex(value, ",")+1]
+	rest := value[len(head):]
+	head += rest[:len(rest)-len(strings.TrimLeft(rest, " "))]
+
+	typed := make(map[string]bool)
+	for _, tag := range models.ParseTags(head) {
+		typed[tag] = true
+	}
+	var suggestions []string
+	for _, tag := range m.tagNames {
+		if !typed[tag] {
+			suggestions = append(suggestions, head+tag)
+		}
+	}
+	m.inputs[tagsInput].SetSuggestions(suggestions)
+}
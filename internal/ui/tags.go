@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// tagPreviewLimit caps how many items of the highlighted tag are listed.
+const tagPreviewLimit = 10
+
+// tagBrowser is the state of the tag browser panel: every tag with its
+// counts, and the notes and tasks carrying the highlighted one.
+type tagBrowser struct {
+	tags   []storage.TagCount
+	cursor int
+	notes  []*models.Note
+	tasks  []*models.Task
+	// from is the view to return to
+	from string
+}
+
+type tagsReadyMsg struct {
+	tags []storage.TagCount
+	err  error
+}
+
+type tagItemsMsg struct {
+	tag   string
+	notes []*models.Note
+	tasks []*models.Task
+	err   error
+}
+
+// openTags loads the tags with their counts
+func (m *NotesApp) openTags() tea.Cmd {
+	return func() tea.Msg {
+		tags, err := m.storage.ListAllTags(m.ctx)
+		return tagsReadyMsg{tags: tags, err: err}
+	}
+}
+
+func (m *NotesApp) handleTagsReady(msg tagsReadyMsg) tea.Cmd {
+	if msg.err != nil {
+		m.fail("Couldn't load tags: %v", msg.err)
+		return nil
+	}
+	if len(msg.tags) == 0 {
+		m.status = "Nothing is tagged yet"
+		return nil
+	}
+	m.tags = &tagBrowser{tags: msg.tags, from: m.activeView}
+	m.activeView = "tags"
+	return m.loadTagItems(msg.tags[0].Tag)
+}
+
+// loadTagItems fetches the notes and tasks carrying tag for the preview
+func (m *NotesApp) loadTagItems(tag string) tea.Cmd {
+	return func() tea.Msg {
+		notes, err := m.storage.GetNotesByTag(m.ctx, tag)
+		if err != nil {
+			return tagItemsMsg{tag: tag, err: err}
+		}
+		tasks, err := m.storage.GetTaskByTag(m.ctx, tag)
+		return tagItemsMsg{tag: tag, notes: notes, tasks: tasks, err: err}
+	}
+}
+
+func (m *NotesApp) handleTagItems(msg tagItemsMsg) {
+	b := m.tags
+	// Drop previews that arrive after the cursor moved on
+	if b == nil || b.tags[b.cursor].Tag != msg.tag {
+		return
+	}
+	if msg.err != nil {
+		m.fail("Couldn't load items tagged %q: %v", msg.tag, msg.err)
+	}
+	b.notes, b.tasks = msg.notes, msg.tasks
+}
+
+// updateTags handles keys while the tag browser is open
+func (m *NotesApp) updateTags(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	b := m.tags
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+	case "esc", "#":
+		m.tags = nil
+		m.activeView = b.from
+	case "up", "k":
+		if b.cursor > 0 {
+			b.cursor--
+			return m, m.loadTagItems(b.tags[b.cursor].Tag)
+		}
+	case "down", "j":
+		if b.cursor < len(b.tags)-1 {
+			b.cursor++
+			return m, m.loadTagItems(b.tags[b.cursor].Tag)
+		}
+	case "enter":
+		// Filter both lists to the tag, or clear the filter if it is
+		// the tag already shown
+		tag := b.tags[b.cursor].Tag
+		m.tags = nil
+		m.activeView = b.from
+		m.activeList = -1
+		if m.filterQuery == "#"+tag {
+			m.activeFilter = models.Filter{}
+			m.filterQuery = ""
+		} else {
+			m.activeFilter = models.Filter{Tags: []string{tag}}
+			m.filterQuery = "#" + tag
+		}
+		return m, tea.Batch(m.loadNotes(), m.loadTasks())
+	}
+	return m, nil
+}
+
+// tagsView lists the tags beside the items carrying the highlighted one
+func (m *NotesApp) tagsView() string {
+	b := m.tags
+	var left strings.Builder
+	left.WriteString(lipgloss.NewStyle().Bold(true).Render("Tags") + "\n\n")
+	for i, c := range b.tags {
+		line := fmt.Sprintf("#%-20s %3d notes %3d tasks", truncate(c.Tag, 20), c.Notes, c.Tasks)
+		if i == b.cursor {
+			left.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+		} else {
+			left.WriteString(itemStyle.Render(line) + "\n")
+		}
+	}
+
+	var right strings.Builder
+	right.WriteString(groupHeaderStyle("#"+b.tags[b.cursor].Tag) + "\n")
+	shown := 0
+	for _, task := range b.tasks {
+		if shown == tagPreviewLimit {
+			break
+		}
+		right.WriteString(fmt.Sprintf("  task  %s\n", truncate(task.Title, 36)))
+		shown++
+	}
+	for _, note := range b.notes {
+		if shown == tagPreviewLimit {
+			break
+		}
+		right.WriteString(fmt.Sprintf("  note  %s\n", truncate(note.Title, 36)))
+		shown++
+	}
+	if more := len(b.tasks) + len(b.notes) - shown; more > 0 {
+		right.WriteString(helpStyle(fmt.Sprintf("  and %d more", more)) + "\n")
+	}
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().PaddingRight(4).Render(left.String()),
+		"\n\n"+right.String(),
+	)
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(body)
+}
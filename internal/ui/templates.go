@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/templates"
+)
+
+// templatePicker lists note or task templates; choosing one calls use
+// with its index.
+type templatePicker struct {
+	names  []string
+	cursor int
+	use    func(i int) tea.Cmd
+}
+
+// openTemplatePicker offers the note templates while the create form is
+// open
+func (m *NotesApp) openTemplatePicker() {
+	list, err := templates.Load(templates.NotesDir(m.dataDir))
+	if err != nil {
+		m.fail("Couldn't load templates: %v", err)
+		return
+	}
+	if len(list) == 0 {
+		m.status = "No templates in " + templates.NotesDir(m.dataDir)
+		return
+	}
+	names := make([]string, len(list))
+	for i, t := range list {
+		names[i] = t.Name
+	}
+	m.templatePicker = &templatePicker{names: names, use: func(i int) tea.Cmd {
+		title, body := list[i].Apply(time.Now())
+		m.inputs[0].SetValue(title)
+		m.setContent(body)
+		return nil
+	}}
+}
+
+// openTaskTemplatePicker offers the task templates; choosing one creates
+// the task straight away in the active project and context.
+func (m *NotesApp) openTaskTemplatePicker() {
+	list, err := templates.LoadTasks(templates.TasksDir(m.dataDir))
+	if err != nil {
+		m.fail("Couldn't load templates: %v", err)
+		return
+	}
+	if len(list) == 0 {
+		m.status = "No templates in " + templates.TasksDir(m.dataDir)
+		return
+	}
+	names := make([]string, len(list))
+	for i, t := range list {
+		names[i] = t.Name
+	}
+	m.templatePicker = &templatePicker{names: names, use: func(i int) tea.Cmd {
+		task, err := list[i].NewTask(time.Now())
+		if err != nil {
+			m.fail("%v", err)
+			return nil
+		}
+		task.ProjectID = m.activeProject
+		task.Context = m.activeContext
+		m.status = fmt.Sprintf("Created %q from %s", task.Title, list[i].Name)
+		return tea.Sequence(m.saveTask(task), m.loadTasks())
+	}}
+}
+
+// updateTemplatePicker routes key presses to the open picker
+func (m *NotesApp) updateTemplatePicker(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.templatePicker
+	switch msg.String() {
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.names)-1 {
+			p.cursor++
+		}
+	case "enter":
+		m.templatePicker = nil
+		return m, p.use(p.cursor)
+	case "esc":
+		m.templatePicker = nil
+	}
+	return m, nil
+}
+
+func (p *templatePicker) View() string {
+	lines := []string{lipgloss.NewStyle().Bold(true).Render("Template:")}
+	for i, name := range p.names {
+		cursor := "  "
+		if i == p.cursor {
+			cursor = "> "
+		}
+		lines = append(lines, cursor+name)
+	}
+	lines = append(lines, helpStyle("↑/↓: choose • enter: use • esc: cancel"))
+	return strings.Join(lines, "\n")
+}
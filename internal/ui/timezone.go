@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+var errUnknownZone = errors.New("unknown timezone")
+
+// dueLayout is how due dates are shown wherever the time of day matters
+const dueLayout = "Mon Jan 2, 2006 15:04"
+
+// parseDueInput reads the due date field: a date, optionally followed by
+// a time of day and the IANA zone it is meant in, e.g.
+// "2024-05-06 14:30 Europe/Berlin". Without a zone the date is local.
+// withTime reports whether a time was given.
+func parseDueInput(value string) (due time.Time, zone string, withTime bool, err error) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 || len(fields) > 3 {
+		return time.Time{}, "", false, fmt.Errorf("invalid due date %q", value)
+	}
+	layout := "2006-01-02"
+	if len(fields) > 1 {
+		if _, err := time.Parse("15:04", fields[1]); err == nil {
+			layout += " 15:04"
+			withTime = true
+			fields = append([]string{fields[0] + " " + fields[1]}, fields[2:]...)
+		}
+	}
+	if len(fields) > 2 {
+		return time.Time{}, "", false, fmt.Errorf("invalid due date %q", value)
+	}
+	if len(fields) == 2 {
+		if _, err := time.LoadLocation(fields[1]); err != nil {
+			return time.Time{}, "", false, fmt.Errorf("%w %q", errUnknownZone, fields[1])
+		}
+		zone = fields[1]
+	}
+	if zone == "" {
+		due, err = time.ParseInLocation(layout, fields[0], time.Local)
+		return due, "", withTime, err
+	}
+	loc, _ := time.LoadLocation(zone)
+	due, err = time.ParseInLocation(layout, fields[0], loc)
+	return due, zone, withTime, err
+}
+
+// formatDueInput fills the due date field for editing the task, with the
+// time of day unless it is due at midnight
+func formatDueInput(task *models.Task) string {
+	due := task.DueDate.In(task.Location())
+	text := due.Format("2006-01-02")
+	if !due.Equal(startOfDay(due)) {
+		text += " " + due.Format("15:04")
+	}
+	if task.Timezone != "" {
+		text += " " + task.Timezone
+	}
+	return text
+}
+
+// formatTaskTime shows ts in the task's timezone. When the task has its
+// own zone, the zone is named and, if it differs, the local time added.
+func formatTaskTime(task *models.Task, ts time.Time, layout string) string {
+	if task.Timezone == "" || ts.IsZero() {
+		return ts.Format(layout)
+	}
+	there := ts.In(task.Location())
+	here := ts.In(time.Local)
+	text := there.Format(layout) + " " + task.Timezone
+	_, offThere := there.Zone()
+	_, offHere := here.Zone()
+	if offThere != offHere {
+		text += fmt.Sprintf(" (%s here)", here.Format("15:04"))
+	}
+	return text
+}
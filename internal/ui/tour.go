@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tourStep is one stop of the onboarding tour. The tour moves on by
+// itself once done reports that the user has tried it; the last step has
+// no done and stays until the tour is closed.
+type tourStep struct {
+	title string
+	body  string
+	done  func(m *NotesApp) bool
+}
+
+var tourSteps = []tourStep{
+	{
+		title: "Switch views",
+		body:  "Notes and tasks live in separate lists. Press tab to switch to your tasks.",
+		done:  func(m *NotesApp) bool { return m.activeView == "tasks" },
+	},
+	{
+		title: "Create a task",
+		body:  "Press n to open the task form and type a title. tab moves between the fields.",
+		done:  func(m *NotesApp) bool { return m.creating && m.creatingTask },
+	},
+	{
+		title: "Set a reminder",
+		body:  "Give a due date (YYYY-MM-DD) and how long before it to remind you, e.g. 30m or 2h. Press enter on the last field to save.",
+		done:  func(m *NotesApp) bool { return !m.creating && !m.editing },
+	},
+	{
+		title: "Search",
+		body:  "Press / and type to search every note and task. enter opens the highlighted match, esc goes back.",
+		done:  func(m *NotesApp) bool { return m.search != nil && m.search.input.Value() != "" },
+	},
+	{
+		title: "You're set",
+		body:  "The help line at the bottom lists every key. Press ctrl+g to close this guide, and again whenever you want it back.",
+	},
+}
+
+// tour tracks the onboarding tour while it is open
+type tour struct {
+	step int
+}
+
+// tourPath marks that the tour has been offered for the data directory
+func tourPath(dataDir string) string {
+	return filepath.Join(dataDir, "onboarded")
+}
+
+// startTourIfNew opens the tour on the first launch. A data directory that
+// already holds notes or tasks is taken as not new.
+func (m *NotesApp) startTourIfNew() {
+	path := tourPath(m.dataDir)
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return
+	}
+	notes, _ := m.storage.GetNoteMeta(m.ctx)
+	tasks, _ := m.storage.GetAllTasks(m.ctx)
+	if len(notes) == 0 && len(tasks) == 0 {
+		m.tour = &tour{}
+	}
+	// Offer the tour once; ctrl+g brings it back
+	os.WriteFile(path, nil, 0644)
+}
+
+// toggleTour opens the tour from the start or closes it
+func (m *NotesApp) toggleTour() {
+	if m.tour != nil {
+		m.tour = nil
+		return
+	}
+	m.tour = &tour{}
+}
+
+// advanceTour moves past every step the user has already done
+func (m *NotesApp) advanceTour() {
+	if m.tour == nil {
+		return
+	}
+	for m.tour.step < len(tourSteps)-1 && tourSteps[m.tour.step].done(m) {
+		m.tour.step++
+	}
+}
+
+// shownList is the list shown in the current view
+func (m *NotesApp) shownList() *list.Model {
+	if m.activeView == "notes" {
+		return &m.notesList
+	}
+	return &m.tasksList
+}
+
+func (t *tour) View() string {
+	step := tourSteps[t.step]
+	title := lipgloss.NewStyle().Bold(true).Render(fmt.Sprintf("Guide %d/%d: %s", t.step+1, len(tourSteps), step.title))
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("170")).
+		Padding(0, 1).
+		Render(strings.Join([]string{title, step.body, helpStyle("ctrl+g: close guide")}, "\n"))
+}
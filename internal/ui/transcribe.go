@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+type transcriptionTickMsg struct{}
+
+type transcriptionDoneMsg struct {
+	path string
+	err  error
+}
+
+// attachAudio attaches an audio file to the note and, when a transcription
+// command is configured, transcribes it in the background.
+func (m *NotesApp) attachAudio(note *models.Note, path string) tea.Cmd {
+	if path == "" {
+		return nil
+	}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if _, err := os.Stat(path); err != nil {
+		m.fail("Couldn't attach %s: %v", path, err)
+		return nil
+	}
+
+	note.Attach(path)
+	if !m.transcriber.Enabled() {
+		m.status = fmt.Sprintf("Attached %s (no transcription command configured)", filepath.Base(path))
+		return tea.Sequence(
+			m.saveNote(note),
+			m.loadNotes(),
+		)
+	}
+
+	m.transcriptions[path] = time.Now()
+	m.status = transcriptionStatus(m.transcriptions)
+	return tea.Batch(
+		tea.Sequence(m.saveNote(note), m.transcribe(note.ID, path)),
+		transcriptionTick(),
+	)
+}
+
+// transcribe runs the transcription command and appends its output to the
+// stored note.
+func (m *NotesApp) transcribe(id models.NoteID, path string) tea.Cmd {
+	return func() tea.Msg {
+		text, err := m.transcriber.Transcribe(m.ctx, path)
+		if err != nil {
+			return transcriptionDoneMsg{path: path, err: err}
+		}
+
+		note, err := m.storage.GetNote(m.ctx, id)
+		if err != nil {
+			return transcriptionDoneMsg{path: path, err: err}
+		}
+		note.AppendTranscript(path, text)
+		return transcriptionDoneMsg{path: path, err: m.storage.SaveNote(m.ctx, note)}
+	}
+}
+
+func transcriptionTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return transcriptionTickMsg{}
+	})
+}
+
+func transcriptionStatus(running map[string]time.Time) string {
+	if len(running) > 1 {
+		return fmt.Sprintf("Transcribing %d files…", len(running))
+	}
+	for path, started := range running {
+		return fmt.Sprintf("Transcribing %s… %ds", filepath.Base(path), int(time.Since(started).Seconds()))
+	}
+	return ""
+}
+
+func formatAttachments(attachments []models.Attachment) string {
+	if len(attachments) == 0 {
+		return "none"
+	}
+	names := make([]string, len(attachments))
+	for i, a := range attachments {
+		names[i] = filepath.Base(a.Path)
+		if a.Transcribed {
+			names[i] += " (transcribed)"
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// trashEntry is a trashed note or task.
+type trashEntry struct {
+	note      *storage.TrashedNote
+	task      *storage.TrashedTask
+	deletedAt time.Time
+}
+
+func (e trashEntry) title() string {
+	if e.task != nil {
+		return e.task.Task.Title
+	}
+	return e.note.Note.Title
+}
+
+// trash is the state of the trash panel, most recently deleted first.
+type trash struct {
+	entries []trashEntry
+	cursor  int
+	// confirmEmpty is set by the first E, which asks for a second
+	confirmEmpty bool
+}
+
+type trashReadyMsg struct {
+	entries []trashEntry
+	err     error
+}
+
+// openTrash loads the trashed notes and tasks
+func (m *NotesApp) openTrash() tea.Cmd {
+	return func() tea.Msg {
+		t, err := m.storage.GetTrash(m.ctx)
+		if err != nil {
+			return trashReadyMsg{err: err}
+		}
+		var entries []trashEntry
+		for _, note := range t.Notes {
+			entries = append(entries, trashEntry{note: note, deletedAt: note.DeletedAt})
+		}
+		for _, task := range t.Tasks {
+			entries = append(entries, trashEntry{task: task, deletedAt: task.DeletedAt})
+		}
+		sort.SliceStable(entries, func(i, j int) bool {
+			return entries[i].deletedAt.After(entries[j].deletedAt)
+		})
+		return trashReadyMsg{entries: entries}
+	}
+}
+
+func (m *NotesApp) handleTrashReady(msg trashReadyMsg) {
+	if msg.err != nil {
+		m.fail("Couldn't load the trash: %v", msg.err)
+		return
+	}
+	t := &trash{entries: msg.entries}
+	if m.trash != nil {
+		t.cursor = min(m.trash.cursor, max(len(t.entries)-1, 0))
+	}
+	m.trash = t
+	m.activeView = "trash"
+}
+
+// updateTrash handles keys while the trash is open
+func (m *NotesApp) updateTrash(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	t := m.trash
+	confirmed := t.confirmEmpty
+	t.confirmEmpty = false
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+	case "esc", "W":
+		m.trash = nil
+		m.activeView = "tasks"
+		return m, tea.Batch(m.loadNotes(), m.loadTasks())
+	case "up", "k":
+		if t.cursor > 0 {
+			t.cursor--
+		}
+	case "down", "j":
+		if t.cursor < len(t.entries)-1 {
+			t.cursor++
+		}
+	case "r":
+		if len(t.entries) == 0 {
+			break
+		}
+		// Put the entry back in its list
+		entry := t.entries[t.cursor]
+		m.status = fmt.Sprintf("Restored %q", entry.title())
+		return m, tea.Sequence(m.restoreTrashed(entry), m.openTrash())
+	case "E":
+		if len(t.entries) == 0 {
+			m.status = "The trash is already empty"
+			break
+		}
+		if !confirmed {
+			t.confirmEmpty = true
+			m.status = fmt.Sprintf("Press E again to delete %d item(s) for good", len(t.entries))
+			break
+		}
+		m.status = fmt.Sprintf("Deleted %d item(s) for good", len(t.entries))
+		return m, tea.Sequence(m.emptyTrash(), m.openTrash())
+	}
+	return m, nil
+}
+
+func (m *NotesApp) restoreTrashed(entry trashEntry) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		if entry.task != nil {
+			err = m.storage.RestoreTask(m.ctx, entry.task.Task.ID)
+		} else {
+			err = m.storage.RestoreNote(m.ctx, entry.note.Note.ID)
+		}
+		if err != nil {
+			return errMsg{action: fmt.Sprintf("restore %q", entry.title()), err: err}
+		}
+		return nil
+	}
+}
+
+func (m *NotesApp) emptyTrash() tea.Cmd {
+	return func() tea.Msg {
+		if err := m.storage.EmptyTrash(m.ctx); err != nil {
+			return errMsg{action: "empty the trash", err: err}
+		}
+		return nil
+	}
+}
+
+// trashView lists the trashed notes and tasks
+func (m *NotesApp) trashView() string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Trash") + "\n\n")
+	if len(m.trash.entries) == 0 {
+		b.WriteString("The trash is empty.\n")
+	}
+
+	for i, entry := range m.trash.entries {
+		kind := "note"
+		if entry.task != nil {
+			kind = "task"
+		}
+		line := fmt.Sprintf("%-40s %-4s  deleted %s", truncate(entry.title(), 40), kind, entry.deletedAt.Format("Jan 2 15:04"))
+		if i == m.trash.cursor {
+			b.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+		} else {
+			b.WriteString(itemStyle.Render(line) + "\n")
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(b.String())
+}
@@ -0,0 +1,192 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/planner"
+)
+
+var groupHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")).Render
+
+// triageRow is one overdue task with its proposed new due date.
+type triageRow struct {
+	group    string
+	task     *models.Task
+	newDue   time.Time
+	accepted bool
+}
+
+// triage is the state of the overdue triage wizard.
+type triage struct {
+	rows   []*triageRow
+	cursor int
+}
+
+type triageReadyMsg struct {
+	rows []*triageRow
+	err  error
+}
+
+// openTriage loads all tasks and builds reschedule proposals for the
+// overdue ones
+func (m *NotesApp) openTriage() tea.Cmd {
+	capacity := m.dailyCapacity
+	return func() tea.Msg {
+		tasks, err := m.storage.GetAllTasks(m.ctx)
+		if err != nil {
+			return triageReadyMsg{err: err}
+		}
+
+		now := time.Now()
+		proposed := make(map[models.TaskID]time.Time)
+		for _, p := range planner.ProposeDates(tasks, now, capacity) {
+			proposed[p.Task.ID] = p.NewDue
+		}
+
+		var rows []*triageRow
+		for _, group := range planner.GroupOverdue(tasks, now) {
+			for _, task := range group.Tasks {
+				rows = append(rows, &triageRow{
+					group:    group.Label,
+					task:     task,
+					newDue:   proposed[task.ID],
+					accepted: true,
+				})
+			}
+		}
+		return triageReadyMsg{rows: rows}
+	}
+}
+
+func (m *NotesApp) handleTriageReady(msg triageReadyMsg) {
+	if msg.err != nil {
+		m.fail("Couldn't load tasks: %v", msg.err)
+		return
+	}
+	if len(msg.rows) == 0 {
+		m.status = "Nothing is overdue"
+		return
+	}
+	m.triage = &triage{rows: msg.rows}
+	m.activeView = "triage"
+}
+
+// updateTriage handles keys while the triage wizard is open
+func (m *NotesApp) updateTriage(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	t := m.triage
+	row := t.rows[t.cursor]
+
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, m.quit()
+	case "esc":
+		m.closeTriage()
+	case "up", "k":
+		if t.cursor > 0 {
+			t.cursor--
+		}
+	case "down", "j":
+		if t.cursor < len(t.rows)-1 {
+			t.cursor++
+		}
+	case " ":
+		row.accepted = !row.accepted
+	case "a":
+		all := true
+		for _, r := range t.rows {
+			all = all && r.accepted
+		}
+		for _, r := range t.rows {
+			r.accepted = !all
+		}
+	case "+", "right", "l":
+		row.newDue = row.newDue.AddDate(0, 0, 1)
+	case "-", "left", "h":
+		if row.newDue.AddDate(0, 0, -1).After(time.Now()) {
+			row.newDue = row.newDue.AddDate(0, 0, -1)
+		}
+	case "enter":
+		return m, m.applyTriage()
+	}
+	return m, nil
+}
+
+// applyTriage reschedules the accepted tasks in one write; u undoes it
+func (m *NotesApp) applyTriage() tea.Cmd {
+	var before []*models.Task
+	var edited []*models.Task
+	for _, row := range m.triage.rows {
+		if !row.accepted {
+			continue
+		}
+		before = append(before, row.task.Clone())
+		task := row.task.Clone()
+		task.Reschedule(row.newDue)
+		edited = append(edited, task)
+	}
+
+	m.closeTriage()
+	if len(edited) == 0 {
+		return nil
+	}
+
+	m.rememberEdit("the triage", itemSet{tasks: before}, itemSet{tasks: edited})
+	m.status = fmt.Sprintf("Rescheduled %d task(s) • u: undo", len(edited))
+	return tea.Sequence(m.saveTasksBatch(edited), m.loadTasks())
+}
+
+func (m *NotesApp) closeTriage() {
+	m.triage = nil
+	m.activeView = "tasks"
+}
+
+// triageView lists overdue tasks by age with their proposed dates
+func (m *NotesApp) triageView() string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Overdue triage") + "\n")
+
+	group := ""
+	for i, row := range m.triage.rows {
+		if row.group != group {
+			group = row.group
+			b.WriteString("\n" + groupHeaderStyle(group) + "\n")
+		}
+
+		check := " "
+		if row.accepted {
+			check = "x"
+		}
+		line := fmt.Sprintf("[%s] %-30s %s → %s",
+			check,
+			truncate(row.task.Title, 30),
+			row.task.DueDate.Format("Mon Jan 2"),
+			row.newDue.Format("Mon Jan 2 15:04"),
+		)
+		if i == m.triage.cursor {
+			b.WriteString(selectedItemStyle.Render("> "+line) + "\n")
+		} else {
+			b.WriteString(itemStyle.Render(line) + "\n")
+		}
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1).
+		Width(m.width - 4).
+		Render(b.String())
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -1,17 +1,24 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"path/filepath"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/san-kum/reminder-tui/internal/config"
 	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/planner"
+	"github.com/san-kum/reminder-tui/internal/reminder"
 	"github.com/san-kum/reminder-tui/internal/storage"
+	"github.com/san-kum/reminder-tui/internal/transcribe"
 )
 
 var (
@@ -19,26 +26,188 @@ var (
 	itemStyle         = lipgloss.NewStyle().PaddingLeft(4)
 	selectedItemStyle = lipgloss.NewStyle().PaddingLeft(2).Foreground(lipgloss.Color("170"))
 	helpStyle         = lipgloss.NewStyle().Foreground(lipgloss.Color("241")).Render
+	statusStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render
 )
 
 type NotesApp struct {
+	// ctx is cancelled on quit so in-flight storage calls are abandoned.
+	ctx    context.Context
+	cancel context.CancelFunc
+
 	storage       storage.Storage
 	notesList     list.Model
 	tasksList     list.Model
+	reviewList    list.Model
 	activeView    string
 	err           error
 	activeInput   int
 	inputs        []textinput.Model
+	content       textarea.Model
+	formPriority  models.Priority
+	markdown      *markdown
 	creating      bool
 	creatingTask  bool
 	editing       bool
 	selectedNote  *models.Note
 	selectedTask  *models.Task
 	width, height int
+
+	prompt *prompt
+	status string
+
+	// templatePicker is open while choosing a note or task template
+	templatePicker *templatePicker
+	// datePicker is open while choosing a due date from the calendar
+	datePicker *datePicker
+	// tagNames are the tags in use, for completing the tags field
+	tagNames []string
+	// formErrors are the problems found in the form's fields, by index,
+	// when it was last submitted
+	formErrors map[int]string
+	dataDir    string
+
+	// tour is the onboarding guide, nil once closed
+	tour *tour
+
+	// marked holds the IDs of items selected for bulk editing
+	marked map[string]bool
+	bulk   *bulkEdit
+
+	// history holds recent deletes, completions and edits for undo
+	history history
+
+	smartLists   []*models.SmartList
+	activeList   int
+	activeFilter models.Filter
+	// filterQuery names a filter given on the command line rather than
+	// by a smart list
+	filterQuery string
+
+	// projects are the known projects; activeProject, when set, limits
+	// both lists to one of them
+	projects      []*models.Project
+	activeProject models.ProjectID
+	// activeContext limits the task list to what can be done there
+	activeContext string
+	// scope limits the task list to today, upcoming or someday tasks;
+	// upcomingDays is how far ahead Upcoming looks
+	scope        models.Scope
+	upcomingDays int
+	// dueTime is when tasks given only a due date fall due
+	dueTime models.TimeOfDay
+
+	// startCmd opens the view asked for on the command line, and
+	// focusTask and focusNote are selected once their lists have loaded
+	startCmd  tea.Cmd
+	focusTask models.TaskID
+	focusNote models.NoteID
+
+	// search holds the query and matches while the search panel is open
+	search *search
+	// jump holds the quick switcher while it is open
+	jump *jump
+
+	triage        *triage
+	dailyCapacity int
+
+	// forecast holds the due-load chart while the forecast panel is open
+	forecast []planner.DayLoad
+
+	// agenda holds the week's tasks by day while the agenda is open
+	agenda *agenda
+
+	// board holds the kanban columns while the board is open
+	board *board
+
+	// matrix holds the Eisenhower quadrants while the matrix is open
+	matrix *matrix
+
+	// archive holds the archived and finished items while the archive is
+	// open
+	archive *archive
+
+	// trash holds the deleted items while the trash is open
+	trash *trash
+
+	// tags holds the tag browser's state while it is open
+	tags *tagBrowser
+
+	// reader holds the note open full screen
+	reader *reader
+
+	// deliveries holds the reminder delivery report while its panel is
+	// open; deliveriesFrom is the view to return to
+	deliveries     *reminder.DeliveryReport
+	deliveriesFrom string
+
+	// lastReminders holds each task's most recent reminder deliveries,
+	// shown in the task detail
+	lastReminders map[models.TaskID][]reminder.Delivery
+	// deadLetters are reminders given up on after every retry failed
+	deadLetters []reminder.DeadLetter
+
+	// checklist is set while the selected task is expanded
+	checklist *checklist
+
+	// pause mirrors the reminder pause file for the header
+	pausePath string
+	pause     *reminder.Pause
+
+	// taskIndex holds every task by ID, including ones hidden by a smart
+	// list, for resolving dependencies
+	taskIndex map[models.TaskID]*models.Task
+	// noteIndex does the same for notes, for links and backlinks
+	noteIndex map[models.NoteID]*models.Note
+
+	// statusErr is set while the status line shows an error, and
+	// statusSeq counts the messages shown so an old one's timer doesn't
+	// clear a newer one
+	statusErr bool
+	statusSeq int
+
+	// detail scrolls the detail panel of the notes and tasks views, and
+	// showDetail puts it in place of the list in a narrow window
+	detail     detail
+	showDetail bool
+
+	// keyHelp is set while the help overlay is open
+	keyHelp *keyHelp
+
+	// quick holds the quick filters toggled on
+	quick quickFilters
+
+	// taskSort and noteSort are the orders of the lists
+	taskSort models.TaskSort
+	noteSort models.NoteSort
+	// sortSaves keeps the sort orders saved in the background in order
+	sortSaves sortSaves
+
+	// density is how much of each item both lists show
+	density density
+
+	// fetchingContent tracks which listed notes have had their body asked
+	// for; the list itself only loads metadata.
+	fetchingContent map[*models.Note]bool
+
+	storageEvents chan storage.Event
+	unsubscribe   func()
+
+	// reminderEvents carries reminders from the reminder session
+	reminderEvents <-chan reminder.Event
+	// banner holds reminders shown until dismissed, oldest first
+	banner []reminder.Event
+	// escalations names the configured escalation policies other than
+	// the default
+	escalations []string
+
+	transcriber    *transcribe.Transcriber
+	transcriptions map[string]time.Time
 }
 
 type noteItem struct {
-	note *models.Note
+	note    *models.Note
+	marked  map[string]bool
+	density density
 }
 
 func (i noteItem) Title() string {
@@ -46,41 +215,77 @@ func (i noteItem) Title() string {
 	if i.note.IsCompleted {
 		status = "✓"
 	}
-	return fmt.Sprintf("[%s] %s", status, i.note.Title)
+	title := fmt.Sprintf("[%s] %s%s", status, i.note.Title, priorityBadge(i.note.Priority))
+	if i.note.Pinned {
+		title = pinGlyph + " " + title
+	}
+	if i.marked[string(i.note.ID)] {
+		return "● " + title
+	}
+	return title
 }
 
 func (i noteItem) Description() string {
-	return fmt.Sprintf("Created: %s", i.note.CreatedAt.Format("Jan 2, 2006"))
+	desc := fmt.Sprintf("Created: %s", i.note.CreatedAt.Format("Jan 2, 2006"))
+	if i.density == densityDetailed {
+		desc += "\nUpdated " + relativeTime(i.note.UpdatedAt, time.Now())
+		desc += "\n" + tagLine(i.note.Tags)
+	}
+	return desc
 }
 
 func (i noteItem) FilterValue() string { return i.note.Title }
 
 type taskItem struct {
-	task *models.Task
+	task    *models.Task
+	marked  map[string]bool
+	blocked bool
+	density density
 }
 
 func (i taskItem) Title() string {
-	var status string
-	switch i.task.Status {
-	case models.TaskStatusCompleted:
-		status = "✓"
-	case models.TaskStatusOverdue:
+	def := models.DefaultWorkflow.Def(i.task)
+	status := statusGlyph(def)
+	if i.task.EffectiveStatus(time.Now()) == models.TaskStatusOverdue && !def.Custom() {
 		status = "!"
-	case models.TaskStatusInProgress:
-		status = "►"
-	default:
-		status = " "
 	}
-	return fmt.Sprintf("[%s] %s", status, i.task.Title)
+	if i.blocked && i.task.IsOpen() {
+		status = "⊘"
+	}
+	title := fmt.Sprintf("[%s] %s%s", status, i.task.Title, priorityBadge(i.task.Priority))
+	if i.task.Pinned {
+		title = pinGlyph + " " + title
+	}
+	if i.marked[string(i.task.ID)] {
+		return "● " + title
+	}
+	return title
 }
 
 func (i taskItem) Description() string {
-	return fmt.Sprintf("Due: %s", i.task.DueDate.Format("Jan 2, 2006 at 3:04 PM"))
+	desc := fmt.Sprintf("Due: %s", formatTaskTime(i.task, i.task.DueDate, dueLayout))
+	if countdown := dueCountdown(i.task, time.Now()); countdown != "" {
+		desc += " • " + countdown
+	}
+	if done, total := i.task.SubtaskProgress(); total > 0 {
+		desc += fmt.Sprintf(" • %d/%d", done, total)
+	}
+	if i.blocked && i.task.IsOpen() {
+		desc += " • blocked"
+	}
+	if snoozed := snoozeLabel(i.task, time.Now()); snoozed != "" {
+		desc += " • " + snoozed
+	}
+	if i.density == densityDetailed {
+		desc += "\n" + preview(i.task.Description)
+		desc += "\n" + tagLine(i.task.Tags)
+	}
+	return desc
 }
 
 func (i taskItem) FilterValue() string { return i.task.Title }
 
-func NewNotesApp(s storage.Storage) *NotesApp {
+func NewNotesApp(s storage.Storage, cfg *config.Config, dataDir string) *NotesApp {
 	// Set up note list
 	noteDelegate := list.NewDefaultDelegate()
 	noteItems := []list.Item{}
@@ -89,14 +294,14 @@ func NewNotesApp(s storage.Storage) *NotesApp {
 	notesList.SetShowHelp(false)
 
 	// Set up task list
-	taskDelegate := list.NewDefaultDelegate()
+	taskDelegate := newTaskRowDelegate(list.NewDefaultDelegate())
 	taskItems := []list.Item{}
 	tasksList := list.New(taskItems, taskDelegate, 0, 0)
 	tasksList.Title = "Tasks"
 	tasksList.SetShowHelp(false)
 
 	// Initialize inputs for creating/editing notes and tasks
-	inputs := make([]textinput.Model, 4)
+	inputs := make([]textinput.Model, 9)
 	for i := range inputs {
 		t := textinput.New()
 		t.Cursor.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("170"))
@@ -106,28 +311,71 @@ func NewNotesApp(s storage.Storage) *NotesApp {
 		case 0:
 			t.Placeholder = "Title"
 			t.Focus()
-		case 1:
-			t.Placeholder = "Content/Description"
-			t.CharLimit = 500
 		case 2:
-			t.Placeholder = "Due Date (YYYY-MM-DD)"
+			t.Placeholder = "Due Date (YYYY-MM-DD [HH:MM]; ctrl+d: calendar)"
 		case 3:
-			t.Placeholder = "Reminder (e.g., 1h, 30m, 1d before due date)"
+			t.Placeholder = "Reminder (e.g., 1h, 30m, 1d before due date, or cron 0 9 * * MON)"
+		case 4:
+			t.Placeholder = "Estimate (e.g., 30m, 2h)"
+		case 5:
+			t.Placeholder = "Fields (e.g., client=Acme, ticket=OPS-42)"
+		case projectInput:
+			t = newProjectInput(t)
+		case contextInput:
+			t = newContextInput(t)
+		case tagsInput:
+			t = newTagsInput(t)
 		}
 
 		inputs[i] = t
 	}
 
+	// The config was validated when it was loaded
+	dueTime, _ := models.ParseTimeOfDay(cfg.Tasks.DueTime)
+	taskSort, _ := models.ParseTaskSort(cfg.Tasks.Sort)
+	noteSort, _ := models.ParseNoteSort(cfg.Notes.Sort)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pausePath := reminder.PausePath(dataDir)
+	pause, _ := reminder.LoadPause(pausePath)
+
 	return &NotesApp{
+		ctx:          ctx,
+		cancel:       cancel,
 		storage:      s,
 		notesList:    notesList,
 		tasksList:    tasksList,
+		reviewList:   newReviewList(),
 		activeView:   "notes",
 		inputs:       inputs,
+		content:      newContentArea(),
+		markdown:     newMarkdown(),
 		activeInput:  0,
 		creating:     false,
 		creatingTask: false,
 		editing:      false,
+
+		marked:          make(map[string]bool),
+		activeList:      -1,
+		fetchingContent: make(map[*models.Note]bool),
+
+		dailyCapacity: cfg.Planning.DailyCapacity,
+		upcomingDays:  cfg.Tasks.UpcomingDays,
+		dueTime:       dueTime,
+		taskSort:      taskSort,
+		noteSort:      noteSort,
+		pausePath:     pausePath,
+		pause:         pause,
+
+		dataDir:     dataDir,
+		escalations: escalationNames(cfg),
+
+		storageEvents: make(chan storage.Event),
+		unsubscribe:   func() {},
+
+		transcriber:    transcribe.New(cfg.Transcription.Command),
+		transcriptions: make(map[string]time.Time),
 	}
 }
 
@@ -136,18 +384,105 @@ func (m *NotesApp) Init() tea.Cmd {
 	return tea.Batch(
 		m.loadNotes(),
 		m.loadTasks(),
+		m.loadChanges(),
+		m.loadSmartLists(),
+		m.loadProjects(),
+		m.subscribe(),
+		m.waitForReminder(),
+		countdownTick(),
+		m.startCmd,
 	)
 }
 
 func (m *NotesApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	status, statusErr := m.status, m.statusErr
+	m.statusErr = false
+	model, cmd := m.update(msg)
+	m.advanceTour()
+	return model, tea.Batch(cmd, m.trackStatus(status, statusErr), m.fetchSelectedContent())
+}
+
+func (m *NotesApp) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if msg.String() == "ctrl+g" {
+			m.toggleTour()
+			return m, nil
+		}
+		if m.prompt != nil {
+			return m.updatePrompt(msg)
+		}
+		if m.templatePicker != nil {
+			return m.updateTemplatePicker(msg)
+		}
+		if m.datePicker != nil {
+			return m.updateDatePicker(msg)
+		}
+		if m.bulk != nil {
+			return m.updateBulkEdit(msg)
+		}
+		if m.activeView == "review" {
+			return m.updateReview(msg)
+		}
+		if m.activeView == "triage" {
+			return m.updateTriage(msg)
+		}
+		if m.activeView == "forecast" {
+			return m.updateForecast(msg)
+		}
+		if m.activeView == "agenda" {
+			return m.updateAgenda(msg)
+		}
+		if m.activeView == "board" {
+			return m.updateBoard(msg)
+		}
+		if m.activeView == "matrix" {
+			return m.updateMatrix(msg)
+		}
+		if m.activeView == "archive" {
+			return m.updateArchive(msg)
+		}
+		if m.activeView == "trash" {
+			return m.updateTrash(msg)
+		}
+		if m.activeView == "tags" {
+			return m.updateTags(msg)
+		}
+		if m.activeView == "search" {
+			return m.updateSearch(msg)
+		}
+		if m.activeView == "jump" {
+			return m.updateJump(msg)
+		}
+		if m.activeView == "help" {
+			return m.updateKeyHelp(msg)
+		}
+		if m.activeView == "reader" {
+			return m.updateReader(msg)
+		}
+		if m.activeView == "deliveries" {
+			return m.updateDeliveries(msg)
+		}
+		if m.checklist != nil {
+			return m.updateChecklist(msg)
+		}
+
+		if m.shownList().SettingFilter() && msg.String() != "ctrl+c" {
+			// Typing into the list's filter; let the list have the keys
+			break
+		}
+
 		// Global keys
 		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
+		case "ctrl+c":
+			return m, m.quit()
+
+		case "q":
+			if !m.creating && !m.editing {
+				return m, m.quit()
+			}
 
 		case "tab":
 			if !m.creating && !m.editing {
@@ -157,15 +492,20 @@ func (m *NotesApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				} else {
 					m.activeView = "notes"
 				}
+				return m, nil
 			}
-			return m, nil
 
 		case "n":
 			if !m.creating && !m.editing {
 				// Start creating a new note/task
 				m.creating = true
 				m.creatingTask = m.activeView == "tasks"
+				m.status = ""
 				m.resetInputs()
+				m.inputs[projectInput].SetValue(m.activeProjectName())
+				m.inputs[contextInput].SetValue(m.activeContext)
+				m.setFormPriority(models.MediumPriority)
+				m.loadTagNames()
 				m.inputs[0].Focus()
 				m.activeInput = 0
 				return m, nil
@@ -174,20 +514,45 @@ func (m *NotesApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "e":
 			if !m.creating && !m.editing {
 				// Start editing the selected note/task
+				m.status = ""
 				if m.activeView == "notes" && m.selectedNote != nil {
-					m.editing = true
-					m.inputs[0].SetValue(m.selectedNote.Title)
-					m.inputs[1].SetValue(m.selectedNote.Content)
-					m.inputs[0].Focus()
-					m.activeInput = 0
+					note := m.selectedNote
+					return m, m.withContent(func() tea.Cmd {
+						// The selection may have moved while the body loaded
+						if m.selectedNote != note || m.creating || m.editing {
+							return nil
+						}
+						m.editing = true
+						m.inputs[0].SetValue(note.Title)
+						m.setContent(note.Content)
+						m.setFormPriority(note.Priority)
+						m.inputs[tagsInput].SetValue(models.FormatTags(note.Tags))
+						m.loadTagNames()
+						m.inputs[projectInput].SetValue(models.ProjectName(m.projects, note.ProjectID))
+						m.inputs[0].Focus()
+						m.activeInput = 0
+						return nil
+					}, note)
 				} else if m.activeView == "tasks" && m.selectedTask != nil {
 					m.editing = true
 					m.creatingTask = true
 					m.inputs[0].SetValue(m.selectedTask.Title)
-					m.inputs[1].SetValue(m.selectedTask.Description)
-					m.inputs[2].SetValue(m.selectedTask.DueDate.Format("2006-01-02"))
+					m.setContent(m.selectedTask.Description)
+					m.setFormPriority(m.selectedTask.Priority)
+					m.inputs[tagsInput].SetValue(models.FormatTags(m.selectedTask.Tags))
+					m.loadTagNames()
+					m.inputs[dueInput].SetValue(formatDueInput(m.selectedTask))
 					reminderPeriod := m.selectedTask.DueDate.Sub(m.selectedTask.ReminderAt)
-					m.inputs[3].SetValue(formatDuration(reminderPeriod))
+					m.inputs[reminderInput].SetValue(formatDuration(reminderPeriod))
+					if m.selectedTask.Cron != "" {
+						m.inputs[reminderInput].SetValue(m.selectedTask.Cron)
+					}
+					if m.selectedTask.Estimate > 0 {
+						m.inputs[estimateInput].SetValue(models.FormatEffort(m.selectedTask.Estimate))
+					}
+					m.inputs[fieldsInput].SetValue(models.FormatFields(m.selectedTask.Fields))
+					m.inputs[projectInput].SetValue(models.ProjectName(m.projects, m.selectedTask.ProjectID))
+					m.inputs[contextInput].SetValue(m.selectedTask.Context)
 					m.inputs[0].Focus()
 					m.activeInput = 0
 				}
@@ -198,37 +563,342 @@ func (m *NotesApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if !m.creating && !m.editing {
 				// Delete the selected note/task
 				if m.activeView == "notes" && m.selectedNote != nil {
-					return m, tea.Batch(
+					m.rememberNoteTrashed(m.selectedNote)
+					m.status = fmt.Sprintf("Moved %q to the trash • u: undo", m.selectedNote.Title)
+					return m, tea.Sequence(
 						m.deleteNote(m.selectedNote.ID),
 						m.loadNotes(),
 					)
 				} else if m.activeView == "tasks" && m.selectedTask != nil {
-					return m, tea.Batch(
+					m.rememberTaskTrashed(m.selectedTask)
+					m.status = fmt.Sprintf("Moved %q to the trash • u: undo", m.selectedTask.Title)
+					return m, tea.Sequence(
 						m.deleteTask(m.selectedTask.ID),
 						m.loadTasks(),
 					)
 				}
 			}
 
+		case "enter":
+			if !m.creating && !m.editing && m.activeView == "notes" && m.selectedNote != nil {
+				// Read the selected note full screen
+				return m, m.openReader()
+			}
+			if !m.creating && !m.editing && m.activeView == "tasks" && m.selectedTask != nil {
+				// Expand the selected task to check off subtasks
+				m.openChecklist()
+				return m, nil
+			}
+
+		case " ":
+			if !m.creating && !m.editing {
+				// Mark the selected item for bulk editing
+				m.toggleMark()
+				return m, nil
+			}
+
+		case "B":
+			if !m.creating && !m.editing {
+				// Bulk edit the marked items
+				m.openBulkEdit()
+				return m, nil
+			}
+
+		case "u":
+			if !m.creating && !m.editing {
+				// Undo the last delete, completion or edit
+				return m, m.undoLast()
+			}
+
+		case "ctrl+r":
+			if !m.creating && !m.editing {
+				return m, m.redoLast()
+			}
+
+		case "O":
+			if !m.creating && !m.editing {
+				// Triage overdue tasks
+				return m, m.openTriage()
+			}
+
+		case "F":
+			if !m.creating && !m.editing {
+				// Show the due-load forecast
+				return m, m.openForecast()
+			}
+
+		case "A":
+			if !m.creating && !m.editing {
+				// Show the week's agenda
+				return m, m.openAgenda("")
+			}
+
+		case "K":
+			if !m.creating && !m.editing {
+				// Show the tasks as a kanban board
+				return m, m.openBoard("")
+			}
+
+		case "M":
+			if !m.creating && !m.editing {
+				// Triage tasks by urgency and importance
+				return m, m.openMatrix("")
+			}
+
+		case "ctrl+o":
+			if !m.creating && !m.editing && m.activeView == "notes" {
+				// Write the selected note in $EDITOR
+				return m, m.editSelectedNote()
+			}
+
+		case "+":
+			if !m.creating && !m.editing {
+				// Add a task written on one line
+				m.openQuickAdd()
+				return m, nil
+			}
+
+		case "H":
+			if !m.creating && !m.editing {
+				// Archive the selected note or finished task
+				return m, m.archiveSelected()
+			}
+
+		case "Y":
+			if !m.creating && !m.editing {
+				// Browse archived and finished items
+				return m, m.openArchive("")
+			}
+
+		case "W":
+			if !m.creating && !m.editing {
+				// Browse deleted items
+				return m, m.openTrash()
+			}
+
+		case "#":
+			if !m.creating && !m.editing && (m.activeView == "notes" || m.activeView == "tasks") {
+				// Browse tags and filter by one
+				return m, m.openTags()
+			}
+
+		case "/":
+			if !m.creating && !m.editing && (m.activeView == "notes" || m.activeView == "tasks") {
+				// Search notes and tasks together, in place of the
+				// list's own title filter
+				m.openSearch()
+				return m, nil
+			}
+
+		case "ctrl+p":
+			if !m.creating && !m.editing && (m.activeView == "notes" || m.activeView == "tasks") {
+				// Jump to any note or task by fuzzy-matching its title
+				return m, m.openJump()
+			}
+
+		case "x":
+			if !m.creating && !m.editing && len(m.banner) > 0 {
+				// Dismiss the reminder banner, which acknowledges its
+				// reminders so they stop repeating
+				return m, m.acknowledgeBanner()
+			}
+
+		case "N":
+			if !m.creating && !m.editing {
+				// Show how promptly reminders went out
+				return m, m.openDeliveries()
+			}
+
+		case "V":
+			if !m.creating && !m.editing && (m.activeView == "notes" || m.activeView == "tasks") {
+				// Cycle how much each list item shows
+				m.cycleDensity()
+				return m, tea.Batch(m.loadNotes(), m.loadTasks())
+			}
+
+		case "f":
+			if !m.creating && !m.editing && m.activeView == "notes" && m.selectedNote != nil {
+				// Jump to a linked note
+				return m, m.followLink()
+			}
+
+		case "o":
+			if !m.creating && !m.editing && (m.activeView == "notes" || m.activeView == "tasks") {
+				// Cycle the order of the shown list
+				return m, m.cycleSort()
+			}
+
+		case "v":
+			if !m.creating && !m.editing && (m.activeView == "notes" || m.activeView == "tasks") {
+				return m, m.toggleHideDone()
+			}
+
+		case "!":
+			if !m.creating && !m.editing && (m.activeView == "notes" || m.activeView == "tasks") {
+				return m, m.toggleOverdue()
+			}
+
+		case "=":
+			if !m.creating && !m.editing && (m.activeView == "notes" || m.activeView == "tasks") {
+				return m, m.toggleQuickTag()
+			}
+
+		case "i":
+			if !m.creating && !m.editing && m.narrow() && (m.activeView == "notes" || m.activeView == "tasks") {
+				// Switch between the list and the details
+				m.showDetail = !m.showDetail
+				return m, nil
+			}
+
+		case "pgup", "pgdown":
+			if !m.creating && !m.editing && (m.activeView == "notes" || m.activeView == "tasks") {
+				// Scroll the detail panel rather than page the list
+				m.scrollDetail(msg.String())
+				return m, nil
+			}
+
+		case "?":
+			if !m.creating && !m.editing && (m.activeView == "notes" || m.activeView == "tasks") {
+				// Show every key of the view
+				m.openKeyHelp()
+				return m, nil
+			}
+
+		case "s":
+			if !m.creating && !m.editing && m.activeView == "tasks" && m.selectedTask != nil {
+				// Start working on the selected task, or stop
+				return m, m.toggleStarted()
+			}
+
+		case "t":
+			if !m.creating && !m.editing && m.activeView == "tasks" && m.selectedTask != nil {
+				// Cycle pending, in progress and completed
+				return m, m.cycleStatus()
+			}
+
+		case "X":
+			if !m.creating && !m.editing && m.activeView == "tasks" && m.selectedTask != nil {
+				// Cancel the selected task, or reopen it
+				return m, m.toggleCancelled()
+			}
+
+		case "E":
+			if !m.creating && !m.editing && m.activeView == "tasks" && m.selectedTask != nil {
+				// Pick how the selected task's reminders escalate
+				m.openEscalation()
+				return m, nil
+			}
+
+		case "z":
+			if !m.creating && !m.editing && m.bannerTask() != nil {
+				// Postpone the reminder on the banner
+				m.snoozeBanner()
+				return m, nil
+			}
+			if !m.creating && !m.editing && m.activeView == "tasks" && m.selectedTask != nil {
+				// Snooze the selected task's reminders
+				m.openSnooze(m.selectedTask, nil)
+				return m, nil
+			}
+
+		case "D":
+			if !m.creating && !m.editing && m.activeView == "tasks" {
+				// Make the selected task depend on the marked ones
+				return m, m.setDependencies()
+			}
+
+		case "P":
+			if !m.creating && !m.editing {
+				// Pause reminders, or resume them
+				m.togglePause()
+				return m, nil
+			}
+
+		case "p":
+			if !m.creating && !m.editing && (m.activeView == "notes" || m.activeView == "tasks") {
+				// Cycle through projects
+				return m, m.cycleProject()
+			}
+
+		case "0", "1", "2", "3":
+			if !m.creating && !m.editing && m.activeView == "tasks" {
+				// Switch between all, today, upcoming and someday tasks
+				return m, m.setScope(models.Scope(msg.String()[0] - '0'))
+			}
+
+		case "@":
+			if !m.creating && !m.editing && m.activeView == "tasks" {
+				// Show what can be done in the next context
+				return m, m.cycleContext()
+			}
+
+		case "*":
+			if !m.creating && !m.editing {
+				// Pin the selected item to the top of its list
+				return m, m.togglePinned()
+			}
+
+		case "C":
+			if !m.creating && !m.editing {
+				// Convert the selected note into a task or vice versa
+				if m.activeView == "notes" && m.selectedNote != nil {
+					return m, m.convertNote(m.selectedNote)
+				}
+				if m.activeView == "tasks" && m.selectedTask != nil {
+					return m, m.convertTask(m.selectedTask)
+				}
+				return m, nil
+			}
+
+		case "T":
+			if !m.creating && !m.editing && m.activeView == "tasks" {
+				// Create a task from a template
+				m.openTaskTemplatePicker()
+				return m, nil
+			}
+
+		case "L":
+			if !m.creating && !m.editing {
+				// Cycle through saved smart lists
+				return m, m.cycleSmartList()
+			}
+
+		case "R":
+			if !m.creating && !m.editing {
+				// Open the review queue for imported changes
+				m.activeView = "review"
+				return m, m.loadChanges()
+			}
+
+		case "a":
+			if !m.creating && !m.editing && m.activeView == "notes" && m.selectedNote != nil {
+				// Attach an audio file to the selected note
+				note := m.selectedNote
+				m.prompt = newPrompt("Attach audio:", "path/to/memo.wav", func(path string) tea.Cmd {
+					return m.withContent(func() tea.Cmd { return m.attachAudio(note, path) }, note)
+				})
+				return m, nil
+			}
+
 		case "c":
 			if !m.creating && !m.editing {
 				// Toggle completion status
 				if m.activeView == "notes" && m.selectedNote != nil {
-					m.selectedNote.IsCompleted = !m.selectedNote.IsCompleted
-					return m, tea.Batch(
-						m.saveNote(m.selectedNote),
-						m.loadNotes(),
-					)
+					note := m.selectedNote
+					return m, m.withContent(func() tea.Cmd {
+						before := note.Clone()
+						note.IsCompleted = !note.IsCompleted
+						m.rememberEdit(fmt.Sprintf("completing %q", before.Title), itemSet{notes: []*models.Note{before}}, itemSet{notes: []*models.Note{note.Clone()}})
+						return tea.Sequence(
+							m.saveNote(note),
+							m.loadNotes(),
+						)
+					}, note)
 				} else if m.activeView == "tasks" && m.selectedTask != nil {
-					if m.selectedTask.Status == models.TaskStatusCompleted {
-						m.selectedTask.Status = models.TaskStatusPending
-					} else {
-						m.selectedTask.Complete()
+					if !m.selectedTask.IsOpen() {
+						return m, m.setTaskStatus(models.TaskStatusPending)
 					}
-					return m, tea.Batch(
-						m.saveTask(m.selectedTask),
-						m.loadTasks(),
-					)
+					return m, m.setTaskStatus(models.TaskStatusCompleted)
 				}
 			}
 		}
@@ -241,11 +911,28 @@ func (m *NotesApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.creating = false
 				m.editing = false
 				m.creatingTask = false
+				m.formErrors = nil
 				return m, nil
 
+			case "ctrl+s":
+				return m, m.handleFormSubmit()
+
+			case "ctrl+d":
+				if m.activeInput == dueInput {
+					m.openDatePicker()
+					return m, nil
+				}
+
+			case "ctrl+o":
+				// Write the content in $EDITOR
+				return m, m.openEditor(nil, m.content.Value())
+
 			case "enter":
-				if m.activeInput == len(m.inputs)-1 ||
-					(!m.creatingTask && m.activeInput == 1) {
+				if m.activeInput == contentInput {
+					// Start a new line of content
+					break
+				}
+				if inputs := m.formInputs(); m.activeInput == inputs[len(inputs)-1] {
 					// Submit the form
 					return m, m.handleFormSubmit()
 				}
@@ -253,6 +940,13 @@ func (m *NotesApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.nextInput()
 				return m, nil
 
+			case "ctrl+t":
+				if m.creating && !m.creatingTask {
+					// Start the note from a template
+					m.openTemplatePicker()
+				}
+				return m, nil
+
 			case "tab", "shift+tab":
 				// Navigate between inputs
 				if msg.String() == "tab" {
@@ -267,10 +961,121 @@ func (m *NotesApp) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmd := m.updateInputs(msg)
 			return m, cmd
 		}
+	case triageReadyMsg:
+		m.handleTriageReady(msg)
+		return m, nil
+
+	case forecastReadyMsg:
+		m.handleForecastReady(msg)
+		return m, nil
+
+	case agendaReadyMsg:
+		m.handleAgendaReady(msg)
+		return m, nil
+
+	case boardReadyMsg:
+		m.handleBoardReady(msg)
+		return m, nil
+
+	case matrixReadyMsg:
+		m.handleMatrixReady(msg)
+		return m, nil
+
+	case archiveReadyMsg:
+		m.handleArchiveReady(msg)
+		return m, nil
+
+	case trashReadyMsg:
+		m.handleTrashReady(msg)
+		return m, nil
+
+	case notesLoadedMsg:
+		return m, m.handleNotesLoaded(msg)
+
+	case tasksLoadedMsg:
+		return m, m.handleTasksLoaded(msg)
+
+	case projectsLoadedMsg:
+		m.handleProjectsLoaded(msg)
+		return m, nil
+
+	case smartListsLoadedMsg:
+		m.handleSmartListsLoaded(msg)
+		return m, nil
+
+	case changesLoadedMsg:
+		return m, m.handleChangesLoaded(msg)
+
+	case errMsg:
+		m.fail("Couldn't %s: %v", msg.action, msg.err)
+		return m, nil
+
+	case statusExpiredMsg:
+		m.handleStatusExpired(msg)
+		return m, nil
+
+	case countdownTickMsg:
+		// Nothing changes but the time; the redraw updates the countdowns
+		return m, countdownTick()
+
+	case tagsReadyMsg:
+		return m, m.handleTagsReady(msg)
+
+	case tagItemsMsg:
+		m.handleTagItems(msg)
+		return m, nil
+
+	case noteContentMsg:
+		return m, m.handleNoteContent(msg)
+
+	case searchResultsMsg:
+		m.handleSearchResults(msg)
+		return m, nil
+
+	case jumpItemsMsg:
+		m.handleJumpItems(msg)
+		return m, nil
+
+	case editorDoneMsg:
+		return m, m.handleEditorDone(msg)
+
+	case deliveriesReadyMsg:
+		m.handleDeliveriesReady(msg)
+		return m, nil
+	case reminderEventMsg:
+		return m, m.handleReminder(msg)
+	case bannerMsg:
+		m.showReminder(reminder.Event(msg))
+		return m, nil
+
+	case storageEventMsg:
+		return m, m.handleStorageEvent(msg)
+	case transcriptionTickMsg:
+		if len(m.transcriptions) == 0 {
+			return m, nil
+		}
+		m.status = transcriptionStatus(m.transcriptions)
+		return m, transcriptionTick()
+	case convertedMsg:
+		m.status = msg.status
+		return m, tea.Batch(m.loadNotes(), m.loadTasks())
+
+	case transcriptionDoneMsg:
+		delete(m.transcriptions, msg.path)
+		if msg.err != nil {
+			m.fail("Couldn't transcribe %s: %v", filepath.Base(msg.path), msg.err)
+		} else {
+			m.status = fmt.Sprintf("Transcript of %s appended", filepath.Base(msg.path))
+		}
+		return m, m.loadNotes()
 	case tea.WindowSizeMsg:
 		m.width, m.height = msg.Width, msg.Height
-		m.notesList.SetSize(msg.Width/2-2, msg.Height-10)
-		m.tasksList.SetSize(msg.Width/2-2, msg.Height-10)
+		m.sizeLists()
+		m.sizeContent()
+		m.sizeDetail()
+		if m.reader != nil {
+			m.sizeReader()
+		}
 		return m, nil
 	}
 
@@ -302,6 +1107,9 @@ func (m *NotesApp) View() string {
 	if m.creating || m.editing {
 		return m.formView()
 	}
+	if m.bulk != nil {
+		return m.bulkEditView()
+	}
 
 	var view string
 
@@ -310,114 +1118,168 @@ func (m *NotesApp) View() string {
 	view = lipgloss.NewStyle().
 		Bold(true).
 		Foreground(lipgloss.Color("170")).
-		Render(titleText) + "\n\n"
+		Render(titleText)
+	if name := m.activeProjectName(); name != "" && m.activeView != "review" {
+		view += statusStyle("  ▸ project: " + name)
+	}
+	if m.activeContext != "" && m.activeView == "tasks" {
+		view += statusStyle("  ▸ " + m.activeContext)
+	}
+	if m.scope != models.ScopeAll && m.activeView == "tasks" {
+		view += statusStyle("  ▸ " + m.scope.String())
+	}
+	if name := m.activeListName(); name != "" && m.activeView != "review" {
+		view += statusStyle("  ▸ " + name)
+	}
+	if chips := m.chipsView(); chips != "" && (m.activeView == "notes" || m.activeView == "tasks") {
+		view += "  " + chips
+	}
+	if banner := m.pauseBanner(); banner != "" {
+		view += statusStyle("  " + banner)
+	}
+	if pending := len(m.reviewList.Items()); pending > 0 && m.activeView != "review" {
+		view += statusStyle(fmt.Sprintf("  %d change(s) to review (R)", pending))
+	}
+	view += "\n\n"
+	view += m.bannerView()
 
 	// Content
 	var content string
-	if m.activeView == "notes" {
+	if m.activeView == "review" {
+		content = m.reviewView()
+	} else if m.activeView == "triage" {
+		content = m.triageView()
+	} else if m.activeView == "forecast" {
+		content = m.forecastView()
+	} else if m.activeView == "agenda" {
+		content = m.agendaView()
+	} else if m.activeView == "board" {
+		content = m.boardView()
+	} else if m.activeView == "matrix" {
+		content = m.matrixView()
+	} else if m.activeView == "archive" {
+		content = m.archiveView()
+	} else if m.activeView == "trash" {
+		content = m.trashView()
+	} else if m.activeView == "tags" {
+		content = m.tagsView()
+	} else if m.activeView == "search" {
+		content = m.searchView()
+	} else if m.activeView == "jump" {
+		content = m.jumpView()
+	} else if m.activeView == "help" {
+		content = m.keyHelpView()
+	} else if m.activeView == "reader" {
+		content = m.readerView()
+	} else if m.activeView == "deliveries" {
+		content = m.deliveriesView()
+	} else if m.activeView == "notes" {
 		notesList := m.notesList.View()
 
 		// Detail view for selected note
-		detailView := "Select a note to view details"
+		detailView, shown := "Select a note to view details", ""
 		if m.selectedNote != nil {
+			shown = "note " + string(m.selectedNote.ID)
 			detailView = fmt.Sprintf(
-				"Title: %s\n\nContent:\n%s\n\nCreated: %s\nUpdated: %s\n\nTags: %v\n\nStatus: %s",
+				"Title: %s\n%s\n\nContent:\n%s\n\nCreated: %s\nUpdated: %s\n\nTags: %v\n\nAttachments: %s\n\nStatus: %s",
 				m.selectedNote.Title,
-				m.selectedNote.Content,
+				noteStats(m.selectedNote, time.Now()),
+				m.noteBody(m.selectedNote),
 				m.selectedNote.CreatedAt.Format("Jan 2, 2006 15:04"),
 				m.selectedNote.UpdatedAt.Format("Jan 2, 2006 15:04"),
 				m.selectedNote.Tags,
+				formatAttachments(m.selectedNote.Attachments),
 				func() string {
 					if m.selectedNote.IsCompleted {
 						return "Completed"
 					}
 					return "Pending"
 				}(),
-			)
+			) + m.formatProject(m.selectedNote.ProjectID) + m.formatLinkedTasks(m.selectedNote) + m.formatLinks(m.selectedNote)
 		}
 
 		// Split view with notes list on the left and details on the right
-		notesPanel := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(1).
-			Width(m.width/2 - 4).
-			Render(notesList)
-
-		detailPanel := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(1).
-			Width(m.width/2 - 4).
-			Render(detailView)
-
-		content = lipgloss.JoinHorizontal(lipgloss.Top, notesPanel, detailPanel)
+		content = m.panes(notesList, m.scrolledDetail(shown, detailView))
 	} else {
 		tasksList := m.tasksList.View()
 
 		// Detail view for selected task
-		detailView := "Select a task to view details"
+		detailView, shown := "Select a task to view details", ""
 		if m.selectedTask != nil {
+			shown = "task " + string(m.selectedTask.ID)
+			task, cursor := m.selectedTask, -1
+			if m.checklist != nil {
+				task, cursor = m.checklist.task, m.checklist.cursor
+			}
 			detailView = fmt.Sprintf(
-				"Title: %s\n\nDescription:\n%s\n\nDue: %s\nReminder: %s\n\nStatus: %s\nPriority: %s\n\nTags: %v",
-				m.selectedTask.Title,
-				m.selectedTask.Description,
-				m.selectedTask.DueDate.Format("Jan 2, 2006 15:04"),
-				m.selectedTask.ReminderAt.Format("Jan 2, 2006 15:04"),
-				func() string {
-					switch m.selectedTask.Status {
-					case models.TaskStatusCompleted:
-						return "Completed"
-					case models.TaskStatusInProgress:
-						return "In Progress"
-					case models.TaskStatusOverdue:
-						return "Overdue"
-					default:
-						return "Pending"
-					}
-				}(),
-				func() string {
-					switch m.selectedTask.Priority {
-					case models.LowPriority:
-						return "Low"
-					case models.MediumPriority:
-						return "Medium"
-					case models.HighPriority:
-						return "High"
-					default:
-						return "Unknown"
-					}
-				}(),
-				m.selectedTask.Tags,
-			)
+				"Title: %s\n\nDescription:\n%s\n\nDue: %s\nReminder: %s\n\nStatus: %s\nPriority: %s\nUrgency: %.1f\nEffort: %s\n\nTags: %v\n\nSubtasks: %s\n\nDepends on: %s",
+				task.Title,
+				m.markdown.render(task.Description, m.detailWidth()),
+				formatTaskTime(task, task.DueDate, dueLayout)+formatCountdown(task, time.Now()),
+				formatReminder(task, time.Now()),
+				task.StatusName(time.Now()),
+				task.Priority,
+				task.Urgency(time.Now()),
+				formatEffort(task, time.Now()),
+				task.Tags,
+				formatSubtasks(task.Subtasks, cursor),
+				formatDependencies(task, m.taskIndex),
+			) + m.formatProject(task.ProjectID) + formatContext(task.Context) + formatParent(task, m.taskIndex) + m.formatLinkedNote(task) + formatFields(task.Fields) + formatEscalation(task) + formatLastReminder(task, m.lastReminders[task.ID], m.deadLetters, time.Now())
 		}
 
 		// Split view with tasks list on the left and details on the right
-		tasksPanel := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(1).
-			Width(m.width/2 - 4).
-			Render(tasksList)
-
-		detailPanel := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(1).
-			Width(m.width/2 - 4).
-			Render(detailView)
-
-		content = lipgloss.JoinHorizontal(lipgloss.Top, tasksPanel, detailPanel)
+		content = m.panes(tasksList, m.scrolledDetail(shown, detailView))
 	}
 
 	view += content + "\n\n"
 
+	// Prompt or status line above the help text
+	if m.prompt != nil {
+		view += m.prompt.View() + "\n"
+	} else if m.templatePicker != nil {
+		view += m.templatePicker.View() + "\n"
+	} else if m.tour != nil {
+		view += m.tour.View() + "\n"
+	} else if m.status != "" {
+		view += m.statusBar() + "\n"
+	}
+
 	// Help text at the bottom
 	var help string
-	if m.activeView == "notes" {
-		help = helpStyle("tab: switch to tasks • n: new note • e: edit note • d: delete note • c: toggle completion • q: quit")
+	if m.activeView == "review" {
+		help = helpStyle("a: accept change • x: reject change • esc: back • q: quit")
+	} else if m.activeView == "triage" {
+		help = helpStyle("space: accept/skip • a: toggle all • +/-: move a day • enter: apply • esc: cancel")
+	} else if m.activeView == "agenda" {
+		help = helpStyle("↑/↓: move • ←/→: previous/next day • enter: open task • +/-: move a day • esc: back • q: quit")
+	} else if m.activeView == "board" {
+		help = helpStyle("←/→: column • ↑/↓: card • </>: move card • enter: open task • esc: back • q: quit")
+	} else if m.activeView == "matrix" {
+		help = helpStyle("tab/←/→: quadrant • ↑/↓: task • 1-4: move to quadrant • enter: open task • esc: back • q: quit")
+	} else if m.activeView == "archive" {
+		help = helpStyle("↑/↓: move • r: restore/reopen • a: archive • A: archive all finished • D: delete • esc: back • q: quit")
+	} else if m.activeView == "trash" {
+		help = helpStyle("↑/↓: move • r: restore • E: empty trash • esc: back • q: quit")
+	} else if m.activeView == "tags" {
+		help = helpStyle("↑/↓: move • enter: filter by tag, again to clear • esc: back • q: quit")
+	} else if m.activeView == "help" {
+		help = helpStyle("?/esc: back • ctrl+c: quit")
+	} else if m.activeView == "search" {
+		help = helpStyle("type to search • ↑/↓: move • enter: open • esc: back • ctrl+c: quit")
+	} else if m.activeView == "jump" {
+		help = helpStyle("type part of a title • ↑/↓: move • enter: jump • esc: back • ctrl+c: quit")
+	} else if m.activeView == "reader" {
+		help = helpStyle("↑/↓/pgup/pgdn: scroll • g/G: top/bottom • esc: back • q: quit • " + m.readerPosition())
+	} else if m.activeView == "deliveries" && len(m.deadLetters) > 0 {
+		help = helpStyle("c: clear undelivered • esc: back • q: quit")
+	} else if m.activeView == "forecast" || m.activeView == "deliveries" {
+		help = helpStyle("esc: back • q: quit")
+	} else if m.checklist != nil {
+		help = helpStyle("space: check/uncheck • a: add subtask • d: remove subtask • p: promote to task • S: split into tasks • m: completion mode • esc: collapse • q: quit")
+	} else if m.activeView == "notes" {
+		help = helpStyle("tab: tasks • n: new note • enter: read • e: edit • d: delete • c: complete • u: undo • /: search • ?: all keys • q: quit")
 	} else {
-		help = helpStyle("tab: switch to notes • n: new task • e: edit task • d: delete task • c: toggle completion • q: quit")
+		help = helpStyle("tab: notes • n: new task • +: quick add • e: edit • d: delete • c: complete • u: undo • /: search • ?: all keys • q: quit")
 	}
 
 	view += help
@@ -449,16 +1311,42 @@ func (m *NotesApp) formView() string {
 		Render(title) + "\n\n"
 
 	// Add inputs
-	for i := range m.inputs {
-		if !m.creatingTask && i > 1 {
-			continue // Only show title and content for notes
+	for _, i := range m.formInputs() {
+		var field string
+		switch i {
+		case contentInput:
+			field = m.content.View()
+		case priorityInput:
+			field = m.priorityField()
+		default:
+			field = m.inputs[i].View()
 		}
-
-		field := m.inputs[i].View()
-		form += field + "\n"
+		form += field + "\n" + m.fieldError(i)
 	}
 
-	form += "\n" + helpStyle("enter: submit • tab: next field • esc: cancel")
+	if m.templatePicker != nil {
+		form += "\n" + m.templatePicker.View() + "\n"
+	}
+	if m.datePicker != nil {
+		form += "\n" + m.datePicker.View() + "\n"
+	}
+	if m.status != "" {
+		form += "\n" + statusStyle(m.status)
+	}
+	help := "enter: submit • ctrl+s: save • tab: next field • →: accept suggestion • esc: cancel"
+	if m.activeInput == priorityInput {
+		help = "←/→: change priority • l/m/h: low/medium/high • enter: next field • ctrl+s: save • esc: cancel"
+	}
+	if m.activeInput == dueInput {
+		help = "enter: submit • ctrl+d: pick date • tab: next field • esc: cancel"
+	}
+	if m.activeInput == contentInput {
+		help = "enter: new line • ctrl+s: save • ctrl+o: $EDITOR • tab: next field • esc: cancel"
+	}
+	if m.creating && !m.creatingTask {
+		help += " • ctrl+t: template"
+	}
+	form += "\n" + helpStyle(help)
 
 	return lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -470,28 +1358,122 @@ func (m *NotesApp) formView() string {
 
 // Helper methods
 
+// quit cancels outstanding storage work and exits the program
+func (m *NotesApp) quit() tea.Cmd {
+	m.Close()
+	return tea.Quit
+}
+
+// Close abandons outstanding storage work and stops following storage
+// events. It is safe to call more than once, e.g. after quitting on a
+// signal.
+func (m *NotesApp) Close() {
+	m.unsubscribe()
+	m.cancel()
+}
+
 // nextInput focuses the next input field
 func (m *NotesApp) nextInput() {
-	m.inputs[m.activeInput].Blur()
-	m.activeInput = (m.activeInput + 1) % len(m.inputs)
-	if !m.creatingTask && m.activeInput > 1 {
-		m.activeInput = 0 // Cycle back for notes (only title and content)
-	}
-	m.inputs[m.activeInput].Focus()
+	m.focusInput(1)
 }
 
 // prevInput focuses the previous input field
 func (m *NotesApp) prevInput() {
-	m.inputs[m.activeInput].Blur()
-	m.activeInput--
-	if m.activeInput < 0 {
-		if m.creatingTask {
-			m.activeInput = len(m.inputs) - 1
-		} else {
-			m.activeInput = 1 // For notes, only go back to content field
+	m.focusInput(-1)
+}
+
+// focusInput moves the focus by step through the inputs the form shows,
+// wrapping around at either end
+func (m *NotesApp) focusInput(step int) {
+	inputs := m.formInputs()
+	pos := 0
+	for i, index := range inputs {
+		if index == m.activeInput {
+			pos = i
+			break
+		}
+	}
+	m.blurField(m.activeInput)
+	m.activeInput = inputs[(pos+step+len(inputs))%len(inputs)]
+	m.focusField(m.activeInput)
+}
+
+// noteContentMsg carries note bodies fetched by withContent.
+type noteContentMsg struct {
+	contents map[*models.Note]string
+	err      error
+	// then carries on with whatever needed the bodies
+	then func() tea.Cmd
+}
+
+// withContent fetches the bodies of those notes that were listed without
+// one in the background, then runs then. Notes must have their content
+// before they are saved, which storage enforces.
+func (m *NotesApp) withContent(then func() tea.Cmd, notes ...*models.Note) tea.Cmd {
+	var missing []*models.Note
+	for _, note := range notes {
+		if note.ContentOmitted {
+			missing = append(missing, note)
+		}
+	}
+	if len(missing) == 0 {
+		if then == nil {
+			return nil
+		}
+		return then()
+	}
+
+	ids := make([]models.NoteID, len(missing))
+	for i, note := range missing {
+		ids[i] = note.ID
+	}
+	return func() tea.Msg {
+		contents := make(map[*models.Note]string, len(missing))
+		for i, id := range ids {
+			content, err := m.storage.GetNoteContent(m.ctx, id)
+			if err != nil {
+				return noteContentMsg{err: err}
+			}
+			contents[missing[i]] = content
 		}
+		return noteContentMsg{contents: contents, then: then}
 	}
-	m.inputs[m.activeInput].Focus()
+}
+
+func (m *NotesApp) handleNoteContent(msg noteContentMsg) tea.Cmd {
+	if msg.err != nil {
+		m.fail("Couldn't load note: %v", msg.err)
+		return nil
+	}
+	for note, content := range msg.contents {
+		if note.ContentOmitted {
+			note.Content = content
+			note.ContentOmitted = false
+		}
+	}
+	if msg.then == nil {
+		return nil
+	}
+	return msg.then()
+}
+
+// noteBody renders the body of a note for the detail panel
+func (m *NotesApp) noteBody(note *models.Note) string {
+	if note.ContentOmitted {
+		return helpStyle("Loading…")
+	}
+	return m.markdown.render(note.Content, m.detailWidth())
+}
+
+// fetchSelectedContent asks once for the body of the selected note, for
+// the detail panel
+func (m *NotesApp) fetchSelectedContent() tea.Cmd {
+	note := m.selectedNote
+	if note == nil || !note.ContentOmitted || m.fetchingContent[note] {
+		return nil
+	}
+	m.fetchingContent[note] = true
+	return m.withContent(nil, note)
 }
 
 // resetInputs clears all input fields
@@ -499,14 +1481,35 @@ func (m *NotesApp) resetInputs() {
 	for i := range m.inputs {
 		m.inputs[i].SetValue("")
 	}
+	m.content.Reset()
+	m.content.Blur()
+	m.formErrors = nil
 }
 
 // updateInputs handles input updates
 func (m *NotesApp) updateInputs(msg tea.Msg) tea.Cmd {
 	var cmd tea.Cmd
 
+	// A field's problem is cleared once it is edited
+	if _, ok := msg.(tea.KeyMsg); ok {
+		delete(m.formErrors, m.activeInput)
+	}
+
 	// Only update the active input
+	switch m.activeInput {
+	case contentInput:
+		m.content, cmd = m.content.Update(msg)
+		return cmd
+	case priorityInput:
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			m.updatePriority(msg)
+		}
+		return nil
+	}
 	m.inputs[m.activeInput], cmd = m.inputs[m.activeInput].Update(msg)
+	if m.activeInput == tagsInput {
+		m.setTagSuggestions()
+	}
 
 	return cmd
 }
@@ -516,52 +1519,62 @@ func (m *NotesApp) handleFormSubmit() tea.Cmd {
 	if m.creatingTask {
 		// Create or edit task
 		title := m.inputs[0].Value()
-		description := m.inputs[1].Value()
-		dueDateStr := m.inputs[2].Value()
-		reminderStr := m.inputs[3].Value()
+		description := m.content.Value()
 
-		// Validate inputs
-		if title == "" {
-			return nil // Ignore empty title
-		}
-
-		// Parse due date
-		dueDate, err := time.Parse("2006-01-02", dueDateStr)
-		if err != nil {
-			// Default to tomorrow if not valid
-			dueDate = time.Now().Add(24 * time.Hour)
+		// Validate inputs, keeping the form open until they are fixed
+		m.formErrors = make(map[int]string)
+		form := m.readTaskForm(time.Now())
+		if !m.checkForm() {
+			return nil
 		}
-
-		// Parse reminder period
-		reminderPeriod, err := parseDuration(reminderStr)
+		projectID, err := m.resolveProject(m.inputs[projectInput].Value())
 		if err != nil {
-			// Default to 1 hour before if not valid
-			reminderPeriod = 1 * time.Hour
+			m.fail("%v", err)
+			return nil
 		}
 
 		if m.editing && m.selectedTask != nil {
 			// Update existing task
-			m.selectedTask.Update(title, description, dueDate)
-			m.selectedTask.SetReminderPeriod(reminderPeriod)
+			before := m.selectedTask.Clone()
+			m.selectedTask.Update(title, description, form.due)
+			m.selectedTask.SetReminderPeriod(form.reminder)
+			m.selectedTask.Cron = form.cron
+			m.selectedTask.Timezone = form.zone
+			m.selectedTask.SetEstimate(form.estimate)
+			m.selectedTask.Fields = form.fields
+			m.selectedTask.ProjectID = projectID
+			m.selectedTask.Context = models.NormalizeContext(m.inputs[contextInput].Value())
+			m.selectedTask.SetPriority(m.formPriority)
+			m.selectedTask.Tags = models.ParseTags(m.inputs[tagsInput].Value())
+
+			m.rememberEdit(fmt.Sprintf("editing %q", before.Title), itemSet{tasks: []*models.Task{before}}, itemSet{tasks: []*models.Task{m.selectedTask.Clone()}})
 
 			m.editing = false
 			m.creatingTask = false
 			m.resetInputs()
 
-			return tea.Batch(
+			return tea.Sequence(
 				m.saveTask(m.selectedTask),
 				m.loadTasks(),
 			)
 		} else {
 			// Create new task
-			task := models.NewTask(title, description, dueDate)
-			task.SetReminderPeriod(reminderPeriod)
+			task := models.NewTask(title, description, form.due)
+			task.SetReminderPeriod(form.reminder)
+			task.Cron = form.cron
+			task.Timezone = form.zone
+			task.SetEstimate(form.estimate)
+			task.Fields = form.fields
+			task.ProjectID = projectID
+			task.Context = models.NormalizeContext(m.inputs[contextInput].Value())
+			task.SetPriority(m.formPriority)
+			task.Tags = models.ParseTags(m.inputs[tagsInput].Value())
 
 			m.creating = false
 			m.creatingTask = false
 			m.resetInputs()
 
-			return tea.Batch(
+			return tea.Sequence(
 				m.saveTask(task),
 				m.loadTasks(),
 			)
@@ -569,32 +1582,46 @@ func (m *NotesApp) handleFormSubmit() tea.Cmd {
 	} else {
 		// Create or edit note
 		title := m.inputs[0].Value()
-		content := m.inputs[1].Value()
+		content := m.content.Value()
 
 		// Validate inputs
-		if title == "" {
-			return nil // Ignore empty title
+		m.formErrors = make(map[int]string)
+		if !m.checkForm() {
+			return nil
+		}
+		projectID, err := m.resolveProject(m.inputs[projectInput].Value())
+		if err != nil {
+			m.fail("%v", err)
+			return nil
 		}
 
 		if m.editing && m.selectedNote != nil {
 			// Update existing note
+			before := m.selectedNote.Clone()
 			m.selectedNote.Update(title, content)
+			m.selectedNote.ProjectID = projectID
+			m.selectedNote.SetPriority(m.formPriority)
+			m.selectedNote.Tags = models.ParseTags(m.inputs[tagsInput].Value())
+			m.rememberEdit(fmt.Sprintf("editing %q", before.Title), itemSet{notes: []*models.Note{before}}, itemSet{notes: []*models.Note{m.selectedNote.Clone()}})
 
 			m.editing = false
 			m.resetInputs()
 
-			return tea.Batch(
+			return tea.Sequence(
 				m.saveNote(m.selectedNote),
 				m.loadNotes(),
 			)
 		} else {
 			// Create new note
 			note := models.NewNote(title, content)
+			note.ProjectID = projectID
+			note.SetPriority(m.formPriority)
+			note.Tags = models.ParseTags(m.inputs[tagsInput].Value())
 
 			m.creating = false
 			m.resetInputs()
 
-			return tea.Batch(
+			return tea.Sequence(
 				m.saveNote(note),
 				m.loadNotes(),
 			)
@@ -602,57 +1629,162 @@ func (m *NotesApp) handleFormSubmit() tea.Cmd {
 	}
 }
 
+// notesLoadedMsg carries the notes read by loadNotes, already sorted and
+// filtered into list items.
+type notesLoadedMsg struct {
+	notes []*models.Note
+	items []list.Item
+	focus models.NoteID
+	err   error
+}
+
+// tasksLoadedMsg carries the tasks read by loadTasks, with what the
+// detail panel shows about their reminders.
+type tasksLoadedMsg struct {
+	tasks         []*models.Task
+	items         []list.Item
+	index         map[models.TaskID]*models.Task
+	lastReminders map[models.TaskID][]reminder.Delivery
+	deadLetters   []reminder.DeadLetter
+	focus         models.TaskID
+	err           error
+}
+
+// errMsg reports a background command that failed, e.g. a save, in the
+// status line.
+type errMsg struct {
+	// action says what was being done, e.g. "save the note"
+	action string
+	err    error
+}
+
 // loadNotes loads notes from storage
 func (m *NotesApp) loadNotes() tea.Cmd {
+	filter := m.activeFilter
+	filter.Project = m.activeProject
+	focus := m.focusNote
+	m.focusNote = ""
+	order := m.noteSort
+	quick := m.quick
+	listDensity := m.density
+	marked := m.marked
 	return func() tea.Msg {
-		notes, err := m.storage.GetAllNotes()
+		notes, err := m.storage.GetNoteMeta(m.ctx)
 		if err != nil {
-			// Handle error
-			return nil
+			return notesLoadedMsg{err: err}
 		}
-
-		// Convert to list items
-		items := make([]list.Item, len(notes))
-		for i, note := range notes {
-			items[i] = noteItem{note: note}
+		models.SortNotes(notes, order)
+		pinNotesFirst(notes)
+
+		// Convert to list items, keeping only those in the active smart list
+		now := time.Now()
+		items := make([]list.Item, 0, len(notes))
+		for _, note := range notes {
+			if !note.IsArchived() && filter.MatchNote(note, now) && quick.matchNote(note, now) {
+				items = append(items, noteItem{note: note, marked: marked, density: listDensity})
+			}
 		}
+		return notesLoadedMsg{notes: notes, items: items, focus: focus}
+	}
+}
 
-		// Update the list
-		m.notesList.SetItems(items)
-
+func (m *NotesApp) handleNotesLoaded(msg notesLoadedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.fail("Couldn't load notes: %v", msg.err)
 		return nil
 	}
+	m.fetchingContent = make(map[*models.Note]bool)
+	m.noteIndex = make(map[models.NoteID]*models.Note, len(msg.notes))
+	for _, note := range msg.notes {
+		m.noteIndex[note.ID] = note
+	}
+
+	cmd := m.notesList.SetItems(msg.items)
+	if msg.focus != "" && !m.selectNote(msg.focus) {
+		m.status = "The note is hidden by the filter"
+	}
+	// The selection may have been reloaded, moved or deleted
+	m.selectedNote = nil
+	if i, ok := m.notesList.SelectedItem().(noteItem); ok {
+		m.selectedNote = i.note
+	}
+	return cmd
 }
 
 // loadTasks loads tasks from storage
 func (m *NotesApp) loadTasks() tea.Cmd {
+	filter := m.activeFilter
+	filter.Project = m.activeProject
+	actionableIn := m.activeContext
+	if actionableIn != "" {
+		filter.Contexts = []string{actionableIn}
+	}
+	scope, upcomingDays := m.scope, m.upcomingDays
+	focus := m.focusTask
+	m.focusTask = ""
+	order := m.taskSort
+	quick := m.quick
+	listDensity := m.density
+	marked := m.marked
 	return func() tea.Msg {
-		tasks, err := m.storage.GetAllTasks()
+		tasks, err := m.storage.GetAllTasks(m.ctx)
 		if err != nil {
-			// Handle error
-			return nil
+			return tasksLoadedMsg{err: err}
 		}
-
-		// Convert to list items
-		items := make([]list.Item, len(tasks))
-		for i, task := range tasks {
-			items[i] = taskItem{task: task}
+		models.SortTasks(tasks, order, time.Now())
+		pinTasksFirst(tasks)
+
+		// Convert to list items, keeping only those in the active smart list
+		now := time.Now()
+		index := models.IndexTasks(tasks)
+		items := make([]list.Item, 0, len(tasks))
+		for _, task := range tasks {
+			if task.IsArchived() || (actionableIn != "" && (!task.IsOpen() || task.IsBlocked(index))) {
+				continue
+			}
+			if filter.MatchTask(task, now) && scope.Match(task, now, upcomingDays) && quick.matchTask(task) {
+				items = append(items, taskItem{task: task, marked: marked, blocked: task.IsBlocked(index), density: listDensity})
+			}
 		}
+		deadLetters, _ := reminder.LoadDeadLetters(reminder.DeadLetterPath(m.dataDir))
+		return tasksLoadedMsg{
+			tasks:         tasks,
+			items:         items,
+			index:         index,
+			lastReminders: m.loadLastReminders(now),
+			deadLetters:   deadLetters,
+			focus:         focus,
+		}
+	}
+}
 
-		// Update the list
-		m.tasksList.SetItems(items)
-
+func (m *NotesApp) handleTasksLoaded(msg tasksLoadedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.fail("Couldn't load tasks: %v", msg.err)
 		return nil
 	}
+	m.taskIndex = msg.index
+	m.setContextSuggestions(msg.tasks)
+	m.lastReminders = msg.lastReminders
+	m.deadLetters = msg.deadLetters
+
+	cmd := m.tasksList.SetItems(msg.items)
+	if msg.focus != "" && !m.selectTask(msg.focus) {
+		m.status = "The task is hidden by the filter"
+	}
+	// The selection may have been reloaded, moved or deleted
+	m.selectedTask = nil
+	if i, ok := m.tasksList.SelectedItem().(taskItem); ok {
+		m.selectedTask = i.task
+	}
+	return cmd
 }
 
 // saveNote saves a note to storage
 func (m *NotesApp) saveNote(note *models.Note) tea.Cmd {
 	return func() tea.Msg {
-		err := m.storage.SaveNote(note)
-		if err != nil {
-			// Handle error
-			return nil
+		if err := m.storage.SaveNote(m.ctx, note); err != nil {
+			return errMsg{action: "save the note", err: err}
 		}
 		return nil
 	}
@@ -661,51 +1793,33 @@ func (m *NotesApp) saveNote(note *models.Note) tea.Cmd {
 // saveTask saves a task to storage
 func (m *NotesApp) saveTask(task *models.Task) tea.Cmd {
 	return func() tea.Msg {
-		err := m.storage.SaveTask(task)
-		if err != nil {
-			// Handle error
-			return nil
+		if err := m.storage.SaveTask(m.ctx, task); err != nil {
+			return errMsg{action: "save the task", err: err}
 		}
 		return nil
 	}
 }
 
-// deleteNote deletes a note from storage
+// deleteNote moves a note to the trash
 func (m *NotesApp) deleteNote(id models.NoteID) tea.Cmd {
 	return func() tea.Msg {
-		err := m.storage.DeleteNote(id)
-		if err != nil {
-			// Handle error
-			return nil
+		if err := m.storage.TrashNote(m.ctx, id); err != nil {
+			return errMsg{action: "delete the note", err: err}
 		}
-		m.selectedNote = nil
 		return nil
 	}
 }
 
-// deleteTask deletes a task from storage
+// deleteTask moves a task to the trash
 func (m *NotesApp) deleteTask(id models.TaskID) tea.Cmd {
 	return func() tea.Msg {
-		err := m.storage.DeleteTask(id)
-		if err != nil {
-			return nil
+		if err := m.storage.TrashTask(m.ctx, id); err != nil {
+			return errMsg{action: "delete the task", err: err}
 		}
-		m.selectedTask = nil
 		return nil
 	}
 }
 
-func parseDuration(s string) (time.Duration, error) {
-	if len(s) > 0 && s[len(s)-1] == 'd' {
-		days, err := fmt.Sscanf(s, "%dd", new(int))
-		if err == nil && days > 0 {
-			return time.Duration(days) * 24 * time.Hour, nil
-		}
-	}
-
-	return time.ParseDuration(s)
-}
-
 func formatDuration(d time.Duration) string {
 	hours := d.Hours()
 	if hours >= 24 && math.Mod(hours, 24) == 0 {
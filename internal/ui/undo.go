@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbletea"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+	"github.com/san-kum/reminder-tui/internal/storage"
+)
+
+// undoLimit caps how many actions are kept for undo.
+const undoLimit = 50
+
+// undoAction is a change that can be taken back and made again. undo and
+// redo return the commands doing so, reloading the lists they touch.
+type undoAction struct {
+	label string
+	undo  func() tea.Cmd
+	redo  func() tea.Cmd
+}
+
+// history holds the actions to undo, most recent last, and the undone
+// ones to redo. It lasts as long as the session.
+type history struct {
+	undo []undoAction
+	redo []undoAction
+}
+
+// itemSet is a copy of some notes and tasks as they stood at one moment.
+type itemSet struct {
+	notes []*models.Note
+	tasks []*models.Task
+}
+
+// remember records an action for undo. A new action can't follow ones
+// that were undone, so they are dropped from redo.
+func (m *NotesApp) remember(a undoAction) {
+	m.history.undo = append(m.history.undo, a)
+	if len(m.history.undo) > undoLimit {
+		m.history.undo = m.history.undo[1:]
+	}
+	m.history.redo = nil
+}
+
+// undoLast takes back the most recent action
+func (m *NotesApp) undoLast() tea.Cmd {
+	if len(m.history.undo) == 0 {
+		m.status = "Nothing to undo"
+		return nil
+	}
+	a := m.history.undo[len(m.history.undo)-1]
+	m.history.undo = m.history.undo[:len(m.history.undo)-1]
+	m.history.redo = append(m.history.redo, a)
+	m.status = fmt.Sprintf("Undid %s • ctrl+r: redo", a.label)
+	return a.undo()
+}
+
+// redoLast makes the most recently undone action again
+func (m *NotesApp) redoLast() tea.Cmd {
+	if len(m.history.redo) == 0 {
+		m.status = "Nothing to redo"
+		return nil
+	}
+	a := m.history.redo[len(m.history.redo)-1]
+	m.history.redo = m.history.redo[:len(m.history.redo)-1]
+	m.history.undo = append(m.history.undo, a)
+	m.status = fmt.Sprintf("Redid %s • u: undo", a.label)
+	return a.redo()
+}
+
+// rememberEdit records an edit by the items as they were before and
+// after it. The copies must not be changed afterwards.
+func (m *NotesApp) rememberEdit(label string, before, after itemSet) {
+	m.remember(undoAction{
+		label: label,
+		undo:  func() tea.Cmd { return m.saveItems(before) },
+		redo:  func() tea.Cmd { return m.saveItems(after) },
+	})
+}
+
+// saveItems writes the notes and tasks back in one write each
+func (m *NotesApp) saveItems(items itemSet) tea.Cmd {
+	var cmds []tea.Cmd
+	if len(items.notes) > 0 {
+		cmds = append(cmds, tea.Sequence(m.saveNotesBatch(items.notes), m.loadNotes()))
+	}
+	if len(items.tasks) > 0 {
+		cmds = append(cmds, tea.Sequence(m.saveTasksBatch(items.tasks), m.loadTasks()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// rememberNoteTrashed records moving a note to the trash; undo restores
+// it
+func (m *NotesApp) rememberNoteTrashed(note *models.Note) {
+	id := note.ID
+	m.remember(undoAction{
+		label: fmt.Sprintf("deleting %q", note.Title),
+		undo: func() tea.Cmd {
+			return tea.Sequence(m.restoreTrashed(trashEntry{note: &storage.TrashedNote{Note: note}}), m.loadNotes())
+		},
+		redo: func() tea.Cmd { return tea.Sequence(m.deleteNote(id), m.loadNotes()) },
+	})
+}
+
+// rememberTaskTrashed records moving a task to the trash; undo restores
+// it
+func (m *NotesApp) rememberTaskTrashed(task *models.Task) {
+	id := task.ID
+	m.remember(undoAction{
+		label: fmt.Sprintf("deleting %q", task.Title),
+		undo: func() tea.Cmd {
+			return tea.Sequence(m.restoreTrashed(trashEntry{task: &storage.TrashedTask{Task: task}}), m.loadTasks())
+		},
+		redo: func() tea.Cmd { return tea.Sequence(m.deleteTask(id), m.loadTasks()) },
+	})
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/san-kum/reminder-tui/internal/models"
+)
+
+// The task form's other text fields.
+const (
+	reminderInput = 3
+	estimateInput = 4
+	fieldsInput   = 5
+)
+
+var fieldErrorStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("203")).Render
+
+// taskForm holds the task form's fields once they have been read
+type taskForm struct {
+	due      time.Time
+	zone     string
+	cron     string
+	reminder time.Duration
+	estimate time.Duration
+	fields   map[string]string
+}
+
+// readTaskForm reads the task form, noting a problem against each field
+// that can't be read. Blank fields take their defaults: due tomorrow, a
+// reminder an hour before and no estimate. A date without a time falls
+// due at the configured time of day.
+func (m *NotesApp) readTaskForm(now time.Time) taskForm {
+	f := taskForm{due: now.Add(24 * time.Hour), reminder: time.Hour}
+
+	if value := strings.TrimSpace(m.inputs[dueInput].Value()); value != "" {
+		due, zone, withTime, err := parseDueInput(value)
+		switch {
+		case errors.Is(err, errUnknownZone):
+			m.formErrors[dueInput] = err.Error()
+		case err != nil:
+			m.formErrors[dueInput] = fmt.Sprintf("%q isn't a date like 2024-05-06 or 2024-05-06 14:30", value)
+		case withTime:
+			f.due, f.zone = due, zone
+		default:
+			f.due, f.zone = m.dueTime.On(due), zone
+		}
+	}
+
+	// A cron expression schedules the reminder instead of an offset
+	if value := strings.TrimSpace(m.inputs[reminderInput].Value()); models.IsCron(value) {
+		f.cron = value
+	} else if value != "" {
+		reminder, err := models.ParseQuickDuration(value)
+		switch {
+		case err == nil && reminder >= 0:
+		case strings.HasPrefix(value, "@") || strings.Contains(value, " "):
+			// Meant as a cron expression; say what is wrong with it
+			_, err := models.ParseCron(value)
+			m.formErrors[reminderInput] = err.Error()
+		default:
+			m.formErrors[reminderInput] = fmt.Sprintf("%q isn't a time before due like 30m, 2h or 1d, or a cron expression", value)
+		}
+		f.reminder = reminder
+	}
+
+	if value := strings.TrimSpace(m.inputs[estimateInput].Value()); value != "" {
+		estimate, err := models.ParseQuickDuration(value)
+		if err != nil || estimate < 0 {
+			m.formErrors[estimateInput] = fmt.Sprintf("%q isn't an estimate like 30m, 2h or 1d", value)
+		}
+		f.estimate = estimate
+	}
+
+	fields, err := models.ParseFields(m.inputs[fieldsInput].Value())
+	if err != nil {
+		m.formErrors[fieldsInput] = err.Error()
+	}
+	f.fields = fields
+	return f
+}
+
+// checkForm reports whether the form can be submitted, focusing the first
+// field with a problem if not
+func (m *NotesApp) checkForm() bool {
+	if strings.TrimSpace(m.inputs[0].Value()) == "" {
+		m.formErrors[0] = "A title is required"
+	}
+	for _, i := range m.formInputs() {
+		if _, ok := m.formErrors[i]; ok {
+			m.blurField(m.activeInput)
+			m.activeInput = i
+			m.focusField(i)
+			return false
+		}
+	}
+	return true
+}
+
+// fieldError is the problem shown under the form field at index
+func (m *NotesApp) fieldError(index int) string {
+	if msg, ok := m.formErrors[index]; ok {
+		return fieldErrorStyle("  ✗ "+msg) + "\n"
+	}
+	return ""
+}